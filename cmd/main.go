@@ -1,10 +1,20 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/schedule-rsreu/schedule-api/config"
 	"github.com/schedule-rsreu/schedule-api/internal/app"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := app.RunCommand(config.Get(), os.Args[1:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	app.Run(config.Get())
 }
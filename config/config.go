@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
@@ -18,6 +19,33 @@ type Config struct {
 	OtlEndpoint string `env:"OTL_ENDPOINT" env-default:"tempo:4317"`
 	DWHUrl      string `env:"DWH_URL"                               env-required:"true"`
 	Production  bool   `env:"PRODUCTION"   env-default:"true"`
+
+	// WebhooksInterval is how often new schedule revisions and due webhook deliveries are checked.
+	WebhooksInterval time.Duration `env:"WEBHOOKS_INTERVAL" env-default:"10s"`
+
+	// DWHToken is sent as a Bearer token to an http(s) DWH_URL. DWH_URL may also be stdout or file:///path.
+	DWHToken string `env:"DWH_TOKEN"`
+
+	// RateLimits are token bucket limits per API client or IP, see ratelimit.ParseRules. Buckets are kept in memory,
+	// so the limits apply per replica: with two replicas a client gets up to twice the limit.
+	RateLimits string `env:"RATE_LIMITS" env-default:"*=600/1m;POST /api/v1/schedule/groups/sample=30/1m;GET /api/v1/schedule/auditoriums=60/1m"` //nolint:lll // there is no way to fix it
+
+	// TrustedProxies are the CIDRs whose X-Forwarded-For is trusted for the client IP, empty uses the remote address.
+	TrustedProxies string `env:"TRUSTED_PROXIES" env-default:"127.0.0.0/8,::1/128,10.0.0.0/8,172.16.0.0/12,192.168.0.0/16,fc00::/7"` //nolint:lll // there is no way to fix it
+
+	// JWTSecret signs client JWTs with app_name, empty accepts only API keys.
+	JWTSecret string `env:"JWT_SECRET"`
+
+	// CacheBackend of the schedule response cache is memory, redis or off. The memory cache keeps at most
+	// CacheMaxEntries week schedules of CacheMaxBytes in total (32 MiB fits the 256Mi pod limit), 0 entries turn it off.
+	// Redis values at RedisURL expire after CacheTTL.
+	// An import is served after at most CacheRevisionInterval.
+	CacheBackend          string        `env:"CACHE_BACKEND"           env-default:"memory"`
+	CacheMaxEntries       int           `env:"CACHE_MAX_ENTRIES"       env-default:"10000"`
+	CacheMaxBytes         int64         `env:"CACHE_MAX_BYTES"         env-default:"33554432"`
+	RedisURL              string        `env:"REDIS_URL"`
+	CacheTTL              time.Duration `env:"CACHE_TTL"               env-default:"24h"`
+	CacheRevisionInterval time.Duration `env:"CACHE_REVISION_INTERVAL" env-default:"5s"`
 }
 
 var (
@@ -15,39 +15,36 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/schedule/auditoriums": {
+        "/api/v1/admin/academic-periods": {
             "get": {
-                "description": "Get auditorium schedule by auditorium_id",
-                "tags": [
-                    "Auditoriums"
-                ],
-                "summary": "Get auditorium schedule",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "example": 12,
-                        "description": "auditorium_id",
-                        "name": "auditorium_id",
-                        "in": "query",
-                        "required": true
-                    },
+                "security": [
                     {
-                        "type": "string",
-                        "example": "2025-06-13",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Семестры, каникулы, сессии и практики, по дате начала",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List academic periods",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumSchedule"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriod"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -59,72 +56,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/schedule/courses": {
-            "get": {
-                "description": "Курсы факультета. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "numerator_start — понедельник недели-числителя, обязателен для семестра",
                 "tags": [
-                    "Courses"
+                    "Admin"
                 ],
-                "summary": "Get faculty courses",
+                "summary": "Create an academic period",
                 "parameters": [
                     {
-                        "enum": [
-                            "иэф",
-                            "фаиту",
-                            "фвт",
-                            "фрт",
-                            "фэ"
-                        ],
-                        "type": "string",
-                        "description": "faculty",
-                        "name": "faculty",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "2025-01-08",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
+                        "description": "period",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.academicPeriodRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.FacultyCourses"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriod"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/schedule/day": {
-            "get": {
-                "description": "Информация о текущем дне",
-                "tags": [
-                    "Day"
-                ],
-                "summary": "Get day",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Day"
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "500": {
@@ -136,18 +113,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/faculties": {
+        "/api/v1/admin/academic-periods/{id}": {
             "get": {
-                "description": "Факультеты",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
-                    "Faculties"
+                    "Admin"
+                ],
+                "summary": "Get an academic period",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get faculties",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Faculties"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -163,44 +172,59 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/schedule/faculties/course": {
-            "get": {
-                "description": "Факультеты курса. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
-                    "Faculties"
+                    "Admin"
                 ],
-                "summary": "Get course faculties",
+                "summary": "Update an academic period",
                 "parameters": [
                     {
-                        "enum": [
-                            1,
-                            2,
-                            3,
-                            4,
-                            5,
-                            6
-                        ],
                         "type": "integer",
-                        "description": "course",
-                        "name": "course",
-                        "in": "query",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "example": "2025-01-08",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
+                        "description": "period",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.academicPeriodRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CourseFaculties"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriod"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -216,32 +240,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/schedule/faculties/courses": {
-            "get": {
-                "description": "Факультеты с курсами. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
-                    "Faculties"
+                    "Admin"
                 ],
-                "summary": "Get faculties with courses",
+                "summary": "Delete an academic period",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "2025-01-08",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.FacultyCourses"
-                            }
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -259,69 +298,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/groups": {
+        "/api/v1/admin/clients": {
             "get": {
-                "description": "Группы факультета курса. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
-                "tags": [
-                    "Groups"
-                ],
-                "summary": "Get course faculty groups",
-                "parameters": [
-                    {
-                        "enum": [
-                            1,
-                            2,
-                            3,
-                            4,
-                            5,
-                            6
-                        ],
-                        "type": "integer",
-                        "description": "course",
-                        "name": "course",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "enum": [
-                            "иэф",
-                            "фаиту",
-                            "фвт",
-                            "фрт",
-                            "фэ"
-                        ],
-                        "type": "string",
-                        "description": "faculty",
-                        "name": "faculty",
-                        "in": "query",
-                        "required": true
-                    },
+                "security": [
                     {
-                        "type": "string",
-                        "example": "2025-01-08",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
+                        "BearerAuth": []
                     }
                 ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "List API clients",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CourseFacultyGroups"
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.APIClient"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -333,45 +338,56 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/schedule/groups/sample": {
+            },
             "post": {
-                "description": "Рассписание для нескольких групп",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает ключ API, он показывается только один раз. Клиент передаёт его как \"Bearer {key}\"\nили подписывает JWT с app_name, равным имени клиента, секретом JWT_SECRET",
                 "tags": [
-                    "Groups"
+                    "Admin"
                 ],
-                "summary": "Get schedules by groups",
+                "summary": "Issue an API client",
                 "parameters": [
                     {
-                        "description": "groups",
-                        "name": "groups",
+                        "description": "client",
+                        "name": "client",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_http_handlers_v1.schedulesByGroupsRequest"
+                            "$ref": "#/definitions/internal_http_handlers_v1.apiClientRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "example": "2025-07-13",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentSchedule"
-                            }
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.APIClient"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -385,41 +401,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/groups/{group}": {
+        "/api/v1/admin/clients/{id}": {
             "get": {
-                "description": "Get schedule by group",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
-                    "Groups"
+                    "Admin"
                 ],
-                "summary": "Get schedule by group",
+                "summary": "Get an API client",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "344",
-                        "description": "group",
-                        "name": "group",
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "add empty lessons",
-                        "name": "add_empty_lessons",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "2025-07-13",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentSchedule"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.APIClient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -435,62 +460,60 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/schedule/groups/{group}/calendar.ics": {
-            "get": {
-                "description": "Returns an iCalendar feed with schedule updates and cancellations",
-                "produces": [
-                    "text/calendar"
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Меняет права клиента или отключает его. Отключённый клиент получает 401",
                 "tags": [
-                    "Groups"
+                    "Admin"
                 ],
-                "summary": "Subscribe to a group calendar.",
+                "summary": "Update an API client",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "344",
-                        "description": "group",
-                        "name": "group",
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "client",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.apiClientUpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.APIClient"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/schedule/lesson/types": {
-            "get": {
-                "description": "Get lesson types",
-                "tags": [
-                    "Lesson"
-                ],
-                "summary": "Get lesson types",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonType"
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -506,36 +529,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/schedule/teachers": {
-            "get": {
-                "description": "Расписание преподавателя",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "tags": [
-                    "Teachers"
+                    "Admin"
                 ],
-                "summary": "Get teacher schedule",
+                "summary": "Delete an API client",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "teacher",
-                        "name": "teacher_id",
-                        "in": "query",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "2025-07-13",
-                        "description": "date",
-                        "name": "date",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherSchedule"
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -553,18 +587,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/teachers/all": {
-            "get": {
-                "description": "Список всех преподавателей",
+        "/api/v1/admin/clients/{id}/key": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает новый ключ, старый перестаёт работать сразу",
                 "tags": [
-                    "Teachers"
+                    "Admin"
+                ],
+                "summary": "Rotate the API key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get teachers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeachersList"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.APIClient"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -582,19 +649,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/teachers/departments": {
+        "/api/v1/admin/holidays": {
             "get": {
-                "description": "Список кафедр. Если факультет не передана, то возвращаются все кафедры.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Праздники, перенесённые выходные и дни, когда университет закрыт. По умолчанию — текущий год",
                 "tags": [
-                    "Teachers"
+                    "Admin"
                 ],
-                "summary": "Get departments list by faculty",
+                "summary": "List holidays",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "example": 1,
-                        "description": "faculty_id",
-                        "name": "faculty_id",
+                        "type": "string",
+                        "example": "2026-01-01",
+                        "description": "from date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-12-31",
+                        "description": "to date, inclusive",
+                        "name": "to",
                         "in": "query"
                     }
                 ],
@@ -604,12 +683,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Department"
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Holiday"
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -623,19 +714,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/teachers/faculties": {
-            "get": {
-                "description": "Список факультетов. Если кафедра не передан, то возвращаются все факультеты.",
+        "/api/v1/admin/holidays/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Загружает встроенный производственный календарь РФ. Дни, изменённые вручную, сохраняются",
                 "tags": [
-                    "Teachers"
+                    "Admin"
                 ],
-                "summary": "Get faculties list by department",
+                "summary": "Import the production calendar",
                 "parameters": [
                     {
                         "type": "integer",
-                        "example": 123,
-                        "description": "department_id",
-                        "name": "department_id",
+                        "example": 2026,
+                        "description": "only this year",
+                        "name": "year",
                         "in": "query"
                     }
                 ],
@@ -643,10 +739,25 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Faculty"
-                            }
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.HolidaysImport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
                     "404": {
@@ -664,26 +775,239 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/schedule/teachers/list": {
-            "get": {
-                "description": "Список преподавателей по факультету и кафедре. Параметры не обязательны.",
-                "tags": [
-                    "Teachers"
+        "/api/v1/admin/holidays/{date}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
-                "summary": "Get teachers list by faculty and department",
+                "description": "День, сохранённый вручную, не перезаписывается импортом производственного календаря",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create or replace a holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2026-05-15",
+                        "description": "date",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "holiday",
+                        "name": "holiday",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.holidayRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Holiday"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete a holiday",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2026-05-15",
+                        "description": "date",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/calendar/feeds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет фильтр календаря группы и возвращает секретный token. Календарь доступен по /api/v1/calendar/feeds/{token}.ics\nЛенты создают только клиенты API, иначе анонимные запросы заполнили бы базу.",
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Create a custom calendar feed",
+                "parameters": [
+                    {
+                        "description": "feed",
+                        "name": "feed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.calendarFeedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/calendar/feeds/{token}": {
+            "get": {
+                "description": "Возвращает фильтр по token, а при суффиксе .ics — отфильтрованный iCalendar группы",
+                "produces": [
+                    "application/json",
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get a custom calendar feed",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token, with .ics suffix for the calendar",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "example": 4,
-                        "description": "faculty_id",
-                        "name": "faculty_id",
+                        "default": 30,
+                        "description": "reminder in minutes before the lesson, 0 disables reminders",
+                        "name": "alarm",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "example": 17,
-                        "description": "department_id",
-                        "name": "department_id",
+                        "type": "boolean",
+                        "default": true,
+                        "description": "emoji in event titles",
+                        "name": "emoji",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "default": "ru",
+                        "description": "calendar language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "#5288c1",
+                        "description": "calendar color",
+                        "name": "color",
                         "in": "query"
                     }
                 ],
@@ -691,8 +1015,97 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeachersList"
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeed"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Заменяет фильтр. Адрес подписки и UID событий не меняются",
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Update a custom calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "feed",
+                        "name": "feed",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.calendarFeedRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Delete a custom calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
                     "404": {
                         "description": "Not Found",
@@ -708,60 +1121,3164 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "echo.HTTPError": {
-            "type": "object",
-            "properties": {
-                "message": {}
+        },
+        "/api/v1/schedule/auditoriums": {
+            "get": {
+                "description": "Get auditorium schedule by auditorium_id",
+                "tags": [
+                    "Auditoriums"
+                ],
+                "summary": "Get auditorium schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "auditorium_id",
+                        "name": "auditorium_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "grid"
+                        ],
+                        "type": "string",
+                        "description": "week days or a day × pair grid",
+                        "name": "layout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-06-13",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumSchedule"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/auditoriums/free": {
+            "get": {
+                "description": "Аудитории без занятий в min_slots пар подряд, начиная с пары, которая идёт в time, или следующей.\nПары берутся из сетки lessons_times двухнедельного окна даты, free_slots — сколько пар подряд аудитория свободна.\nЕсли до конца дня осталось меньше min_slots пар, аудиторий нет. В праздник свободны все аудитории",
+                "tags": [
+                    "Auditoriums"
+                ],
+                "summary": "Find free auditoriums",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-06-18",
+                        "description": "date, today by default",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "11:50",
+                        "description": "clock time or a lessons_times value, now by default",
+                        "name": "time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "building_id, all buildings by default",
+                        "name": "building_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 8,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "free pairs in a row",
+                        "name": "min_slots",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.FreeAuditoriums"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/auditoriums/{auditorium_id}/calendar.ics": {
+            "get": {
+                "description": "Returns an iCalendar feed with auditorium lessons, updates and cancellations",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Auditoriums"
+                ],
+                "summary": "Subscribe to an auditorium calendar.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "auditorium_id",
+                        "name": "auditorium_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "reminder in minutes before the lesson, 0 disables reminders",
+                        "name": "alarm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "emoji in event titles",
+                        "name": "emoji",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "default": "ru",
+                        "description": "calendar language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "#5288c1",
+                        "description": "calendar color",
+                        "name": "color",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/auditoriums/{auditorium_id}/lessons": {
+            "get": {
+                "description": "Плоский список занятий в аудитории за период до семестра, отсортированный по времени начала",
+                "tags": [
+                    "Auditoriums"
+                ],
+                "summary": "Get auditorium lessons for a date range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "auditorium_id",
+                        "name": "auditorium_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-02-09",
+                        "description": "first date, today by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-06-30",
+                        "description": "last date, two weeks after from by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonsPage"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/auditoriums/{auditorium_id}/now": {
+            "get": {
+                "description": "Текущее и следующее занятие в аудитории по московскому времени сервера, сколько минут до их конца и начала,\nперерыв и конец учебного дня",
+                "tags": [
+                    "Auditoriums"
+                ],
+                "summary": "Get the current and the next auditorium lesson",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 12,
+                        "description": "auditorium_id",
+                        "name": "auditorium_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonsNow"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/bells": {
+            "get": {
+                "description": "Расписание звонков: номера пар, их начало и конец, перерыв в середине пары и перемены между парами.\npair_number занятий — номер пары, с которой занятие начинается, 0 для занятий вне расписания звонков",
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get the bell schedule",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.BellSchedule"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/conflicts": {
+            "get": {
+                "description": "Накладки в расписании: преподаватель в двух аудиториях одновременно, аудитория, занятая несвязанными занятиями,\nи группа с двумя занятиями в одно время. Занятия потока не считаются накладкой",
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Find schedule conflicts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2026-03-02",
+                        "description": "first date, today by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-15",
+                        "description": "last date, two weeks after from by default, at most 31 days after from",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "only conflicts involving groups of the faculty",
+                        "name": "faculty_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleConflicts"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/courses": {
+            "get": {
+                "description": "Курсы факультета. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get faculty courses",
+                "parameters": [
+                    {
+                        "enum": [
+                            "иэф",
+                            "фаиту",
+                            "фвт",
+                            "фрт",
+                            "фэ"
+                        ],
+                        "type": "string",
+                        "description": "faculty",
+                        "name": "faculty",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-08",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.FacultyCourses"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/day": {
+            "get": {
+                "description": "Информация о текущем дне",
+                "tags": [
+                    "Day"
+                ],
+                "summary": "Get day",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Day"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/faculties": {
+            "get": {
+                "description": "Факультеты",
+                "tags": [
+                    "Faculties"
+                ],
+                "summary": "Get faculties",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Faculties"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/faculties/course": {
+            "get": {
+                "description": "Факультеты курса. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+                "tags": [
+                    "Faculties"
+                ],
+                "summary": "Get course faculties",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            2,
+                            3,
+                            4,
+                            5,
+                            6
+                        ],
+                        "type": "integer",
+                        "description": "course",
+                        "name": "course",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-08",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CourseFaculties"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/faculties/courses": {
+            "get": {
+                "description": "Факультеты с курсами. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+                "tags": [
+                    "Faculties"
+                ],
+                "summary": "Get faculties with courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2025-01-08",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.FacultyCourses"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/free-slots": {
+            "get": {
+                "description": "Пары из сетки lessons_times, в которые свободны все перечисленные группы и преподаватели.\nВоскресенья и праздники пропускаются. С auditorium=true к каждой паре подбирается свободная аудитория",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Find common free slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "1,2",
+                        "description": "comma separated teacher ids",
+                        "name": "teachers",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "344,345",
+                        "description": "comma separated group numbers",
+                        "name": "groups",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-02",
+                        "description": "first date, today by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-03-08",
+                        "description": "last date, a week after from by default, at most 31 days after from",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "add a free auditorium to every slot",
+                        "name": "auditorium",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "building of the auditoriums",
+                        "name": "building_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CommonFreeSlots"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups": {
+            "get": {
+                "description": "Группы факультета курса. Фильтрует по наличию занятий в диапазоне ±6 месяцев от date. По умолчанию date = текущий день",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get course faculty groups",
+                "parameters": [
+                    {
+                        "enum": [
+                            1,
+                            2,
+                            3,
+                            4,
+                            5,
+                            6
+                        ],
+                        "type": "integer",
+                        "description": "course",
+                        "name": "course",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "иэф",
+                            "фаиту",
+                            "фвт",
+                            "фрт",
+                            "фэ"
+                        ],
+                        "type": "string",
+                        "description": "faculty",
+                        "name": "faculty",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-01-08",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CourseFacultyGroups"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups/sample": {
+            "post": {
+                "description": "Рассписание для нескольких групп",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get schedules by groups",
+                "parameters": [
+                    {
+                        "description": "groups",
+                        "name": "groups",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.schedulesByGroupsRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-07-13",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentSchedule"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups/{group}": {
+            "get": {
+                "description": "Расписание группы, layout=grid отдаёт матрицу дней и пар вместо add_empty_lessons",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get schedule by group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "344",
+                        "description": "group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "add empty lessons, ignored for the grid layout",
+                        "name": "add_empty_lessons",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "grid"
+                        ],
+                        "type": "string",
+                        "description": "week days or a day × pair grid",
+                        "name": "layout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-07-13",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentSchedule"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups/{group}/calendar.ics": {
+            "get": {
+                "description": "Returns an iCalendar feed with schedule updates and cancellations",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Subscribe to a group calendar.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "344",
+                        "description": "group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "reminder in minutes before the lesson, 0 disables reminders",
+                        "name": "alarm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "emoji in event titles",
+                        "name": "emoji",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "default": "ru",
+                        "description": "calendar language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "#5288c1",
+                        "description": "calendar color",
+                        "name": "color",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups/{group}/changes": {
+            "get": {
+                "description": "Добавленные, удалённые и изменённые занятия группы: перенос в другую аудиторию, замена преподавателя, сдвиг по времени.\nИзменения отдаются страницами по id: для следующей страницы или следующего опроса передайте cursor в after",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get group schedule changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "344",
+                        "description": "group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-02-10T09:00:00.123456+03:00",
+                        "description": "RFC 3339 timestamp or date, exclusive, for the first page; a week ago by default",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "cursor of the previous response, exclusive; since is ignored with it",
+                        "name": "after",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups/{group}/lessons": {
+            "get": {
+                "description": "Плоский список занятий группы за период до семестра, отсортированный по времени начала",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get group lessons for a date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "344",
+                        "description": "group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-02-09",
+                        "description": "first date, today by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-06-30",
+                        "description": "last date, two weeks after from by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonsPage"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/groups/{group}/now": {
+            "get": {
+                "description": "Текущее и следующее занятие группы по московскому времени сервера, сколько минут до их конца и начала,\nперерыв и конец учебного дня",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get the current and the next group lesson",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "344",
+                        "description": "group",
+                        "name": "group",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonsNow"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/lesson/types": {
+            "get": {
+                "description": "Get lesson types",
+                "tags": [
+                    "Lesson"
+                ],
+                "summary": "Get lesson types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonType"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/stream": {
+            "get": {
+                "description": "Server-Sent Events: событие schedule.changed с ревизией и затронутыми датами при изменении занятий\nгрупп, преподавателей или аудиторий. id события — номер последнего изменения, при переподключении\nбраузер передаёт его в Last-Event-ID и получает пропущенные события. Если их слишком много, приходит\nсобытие schedule.resync: клиент заново загружает расписания, поток продолжается с его id.\nРаз в 25 секунд приходит комментарий heartbeat",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Stream schedule updates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "344,345",
+                        "description": "comma separated group numbers",
+                        "name": "groups",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "12",
+                        "description": "comma separated teacher ids",
+                        "name": "teachers",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "7",
+                        "description": "comma separated auditorium ids",
+                        "name": "auditoriums",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "last received event id",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleStreamEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers": {
+            "get": {
+                "description": "Расписание преподавателя",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get teacher schedule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "teacher id, /api/v1/search finds it by name",
+                        "name": "teacher_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "week",
+                            "grid"
+                        ],
+                        "type": "string",
+                        "description": "week days or a day × pair grid",
+                        "name": "layout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2025-07-13",
+                        "description": "date",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherSchedule"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/all": {
+            "get": {
+                "description": "Список всех преподавателей",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get teachers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeachersList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/departments": {
+            "get": {
+                "description": "Список кафедр. Если факультет не передана, то возвращаются все кафедры.",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get departments list by faculty",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "faculty_id",
+                        "name": "faculty_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Department"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/faculties": {
+            "get": {
+                "description": "Список факультетов. Если кафедра не передан, то возвращаются все факультеты.",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get faculties list by department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 123,
+                        "description": "department_id",
+                        "name": "department_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Faculty"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/list": {
+            "get": {
+                "description": "Список преподавателей по факультету и кафедре. Параметры не обязательны.",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get teachers list by faculty and department",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 4,
+                        "description": "faculty_id",
+                        "name": "faculty_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 17,
+                        "description": "department_id",
+                        "name": "department_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeachersList"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/{teacher_id}/calendar.ics": {
+            "get": {
+                "description": "Returns an iCalendar feed with teacher lessons, updates and cancellations",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Subscribe to a teacher calendar.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "teacher_id",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "reminder in minutes before the lesson, 0 disables reminders",
+                        "name": "alarm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "emoji in event titles",
+                        "name": "emoji",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ru",
+                            "en"
+                        ],
+                        "type": "string",
+                        "default": "ru",
+                        "description": "calendar language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "#5288c1",
+                        "description": "calendar color",
+                        "name": "color",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/{teacher_id}/lessons": {
+            "get": {
+                "description": "Плоский список занятий преподавателя за период до семестра, отсортированный по времени начала",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get teacher lessons for a date range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "teacher_id",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-02-09",
+                        "description": "first date, today by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-06-30",
+                        "description": "last date, two weeks after from by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 500,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "page size",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonsPage"
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/schedule/teachers/{teacher_id}/now": {
+            "get": {
+                "description": "Текущее и следующее занятие преподавателя по московскому времени сервера, сколько минут до их конца и начала,\nперерыв и конец учебного дня",
+                "tags": [
+                    "Teachers"
+                ],
+                "summary": "Get the current and the next teacher lesson",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "teacher_id",
+                        "name": "teacher_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonsNow"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/search": {
+            "get": {
+                "description": "Нечёткий поиск по группам, преподавателям, аудиториям, корпусам и названиям занятий.\nНаходит по части фамилии и инициалам, с опечатками, в английской раскладке и с латинскими буквами в номере группы",
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search groups, teachers, auditoriums and subjects",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "Конюхов А",
+                        "description": "query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "group",
+                            "teacher",
+                            "auditorium",
+                            "building",
+                            "subject"
+                        ],
+                        "type": "string",
+                        "description": "only hits of the type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 50,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "hits count",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.SearchResults"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Подписки текущего клиента",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Webhook"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "При каждой новой ревизии расписания с изменениями в группе, у преподавателя или в аудитории\nна url отправляется POST с событием schedule.changed. Тело подписано HMAC-SHA256 секретом подписки:\nзаголовок X-Schedule-Signature: t={unix},v1={hex(hmac(secret, t + \".\" + body))}.\nНеуспешные доставки повторяются с экспоненциальной задержкой. Секрет возвращается только при создании",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Subscribe to schedule changes",
+                "parameters": [
+                    {
+                        "description": "webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.webhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возобновлённая подписка не получает изменения, сделанные во время паузы",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Pause or resume a webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "webhook",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_http_handlers_v1.webhookActiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Webhook"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Последние 50 попыток доставки, новые первыми. status_code равен null, если получатель не ответил",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Webhook delivery log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "echo.HTTPError": {
+            "type": "object",
+            "properties": {
+                "message": {}
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.APIClient": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "Yk3mJ0ZVbqzQ1o7b5b6k9pZ0JqgC2pXc3q2-7wEjRQ2"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "telegram-bot"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "enum": [
+                            "read",
+                            "admin",
+                            "webhooks"
+                        ],
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.APIClientScope"
+                    },
+                    "example": [
+                        "read",
+                        "webhooks"
+                    ]
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.APIClientScope": {
+            "type": "string",
+            "enum": [
+                "read",
+                "admin",
+                "webhooks"
+            ],
+            "x-enum-varnames": [
+                "APIClientScopeRead",
+                "APIClientScopeAdmin",
+                "APIClientScopeWebhooks"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriod": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-06-30"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "enum": [
+                        "semester",
+                        "holidays",
+                        "exam_session",
+                        "practice"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriodKind"
+                        }
+                    ],
+                    "example": "semester"
+                },
+                "numerator_start": {
+                    "type": "string",
+                    "example": "2026-02-09"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-09"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Весенний семестр 2025/2026"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriodKind": {
+            "type": "string",
+            "enum": [
+                "semester",
+                "holidays",
+                "exam_session",
+                "practice"
+            ],
+            "x-enum-varnames": [
+                "AcademicPeriodSemester",
+                "AcademicPeriodHolidays",
+                "AcademicPeriodExamSession",
+                "AcademicPeriodPractice"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Auditorium": {
+            "type": "object",
+            "properties": {
+                "building": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Building"
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "445 C"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "number": {
+                    "type": "string",
+                    "example": "445"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-06-18"
+                },
+                "faculties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "фаиту",
+                        "фвт"
+                    ]
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "344",
+                        "345"
+                    ]
+                },
+                "is_holiday": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "lesson": {
+                    "type": "string",
+                    "example": "Лек. Высшая математика\nКонюхов А.Н. 333 С"
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "teachers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentTeacherInfo"
+                    }
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Высшая математика"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "lab,practice"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumSchedule": {
+            "type": "object",
+            "properties": {
+                "auditorium": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Auditorium"
+                },
+                "denominator_period": {
+                    "type": "string",
+                    "example": "09.06-15.06"
+                },
+                "grid": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleGrid-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Holiday"
+                    }
+                },
+                "input_week_type": {
+                    "type": "string",
+                    "example": "numerator"
+                },
+                "numerator_period": {
+                    "type": "string",
+                    "example": "16.06-22.06"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumWeek"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumWeek": {
+            "type": "object",
+            "properties": {
+                "friday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                },
+                "monday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                },
+                "saturday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                },
+                "thursday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                },
+                "tuesday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                },
+                "wednesday": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Bell": {
+            "type": "object",
+            "properties": {
+                "break_end": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "break_start": {
+                    "type": "string",
+                    "example": "08:55"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "09:45"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
+                    "type": "string",
+                    "example": "08:10"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.BellBreak": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "end": {
+                    "type": "string",
+                    "example": "09:55"
+                },
+                "minutes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "start": {
+                    "type": "string",
+                    "example": "09:45"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.BellSchedule": {
+            "type": "object",
+            "properties": {
+                "bells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Bell"
+                    }
+                },
+                "breaks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.BellBreak"
+                    }
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Building": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "ул. Гагарина, 59/1"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 54.6132708
+                },
+                "letter": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 39.7236472
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Центральный корпус"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeed": {
+            "type": "object",
+            "properties": {
+                "exclude": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeedRule"
+                    }
+                },
+                "group": {
+                    "type": "string",
+                    "example": "344"
+                },
+                "include": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeedRule"
+                    }
+                },
+                "token": {
+                    "type": "string",
+                    "example": "3q2-7wEjRQ2m5xqJ0ZVbqzQ1o7b5b6k9pZ0JqgC2pXc"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CalendarFeedRule": {
+            "type": "object",
+            "properties": {
+                "auditorium": {
+                    "type": "string",
+                    "example": "106а C"
+                },
+                "lesson_type": {
+                    "type": "string",
+                    "example": "lab"
+                },
+                "teacher": {
+                    "type": "string",
+                    "example": "Конюхов"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Физическая культура"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CalendarTeacherAuditorium": {
+            "type": "object",
+            "properties": {
+                "auditorium": {
+                    "type": "string"
+                },
+                "teacher": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CommonFreeSlot": {
+            "type": "object",
+            "properties": {
+                "auditorium": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Auditorium"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-03-02"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "09:45"
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
+                    "type": "string",
+                    "example": "08:10"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CommonFreeSlots": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2026-03-02"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "344",
+                        "345"
+                    ]
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CommonFreeSlot"
+                    }
+                },
+                "teachers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-03-08"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.ConflictLesson": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "344",
+                        "345"
+                    ]
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "teacher_auditoriums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentTeacherAuditorium"
+                    }
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Высшая математика"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "lecture"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CourseFaculties": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ],
+                    "example": 1
+                },
+                "faculties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "иэф",
+                            "фаиту",
+                            "фвт",
+                            "фрт",
+                            "фэ"
+                        ]
+                    }
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.CourseFacultyGroups": {
+            "type": "object",
+            "properties": {
+                "course": {
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ],
+                    "example": 1
+                },
+                "faculty": {
+                    "type": "string",
+                    "enum": [
+                        "иэф",
+                        "фаиту",
+                        "фвт",
+                        "фрт",
+                        "фэ"
+                    ],
+                    "example": "фвт"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Day": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string",
+                    "enum": [
+                        "Monday",
+                        "Tuesday",
+                        "Wednesday",
+                        "Thursday",
+                        "Friday",
+                        "Saturday",
+                        "Sunday"
+                    ],
+                    "example": "Monday"
+                },
+                "day_ru": {
+                    "type": "string",
+                    "enum": [
+                        "Пн",
+                        "Вт",
+                        "Ср",
+                        "Чт",
+                        "Пт",
+                        "Сб",
+                        "Вс"
+                    ],
+                    "example": "Пн"
+                },
+                "holiday": {
+                    "description": "Holiday is the holiday of today, null on working days.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Holiday"
+                        }
+                    ]
+                },
+                "is_holiday": {
+                    "description": "IsHoliday is true on public holidays, days off and university closures.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "period": {
+                    "description": "Period is the academic period of today, null outside of known periods.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AcademicPeriod"
+                        }
+                    ]
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10"
+                },
+                "week_type": {
+                    "type": "string",
+                    "enum": [
+                        "числитель",
+                        "знаменатель"
+                    ],
+                    "example": "знаменатель"
+                },
+                "week_type_eng": {
+                    "type": "string",
+                    "enum": [
+                        "numerator",
+                        "denominator"
+                    ],
+                    "example": "numerator"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Department": {
+            "type": "object",
+            "properties": {
+                "faculty": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Faculty"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Кафедра вычислительной и прикладной математики"
+                },
+                "title_short": {
+                    "type": "string",
+                    "example": "ВПМ"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Faculties": {
+            "type": "object",
+            "properties": {
+                "faculties": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Faculty": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Факультет автоматики и информационных технологий в управлении"
+                },
+                "title_short": {
+                    "type": "string",
+                    "example": "фаиту"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.FacultyCourses": {
+            "type": "object",
+            "properties": {
+                "courses": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "faculty": {
+                    "type": "string",
+                    "enum": [
+                        "иэф",
+                        "фаиту",
+                        "фвт",
+                        "фрт",
+                        "фэ"
+                    ],
+                    "example": "фвт"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.FreeAuditorium": {
+            "type": "object",
+            "properties": {
+                "building": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Building"
+                },
+                "display_name": {
+                    "type": "string",
+                    "example": "445 C"
+                },
+                "free_slots": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "number": {
+                    "type": "string",
+                    "example": "445"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.FreeAuditoriums": {
+            "type": "object",
+            "properties": {
+                "auditoriums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.FreeAuditorium"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2025-06-18"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonSlot"
+                    }
+                },
+                "week_type": {
+                    "type": "string",
+                    "example": "numerator"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.GridCell-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson": {
+            "type": "object",
+            "properties": {
+                "break_end": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "break_start": {
+                    "type": "string",
+                    "example": "08:55"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "09:45"
+                },
+                "lessons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                    }
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
+                    "type": "string",
+                    "example": "08:10"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.GridCell-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson": {
+            "type": "object",
+            "properties": {
+                "break_end": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "break_start": {
+                    "type": "string",
+                    "example": "08:55"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "09:45"
+                },
+                "lessons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentLesson"
+                    }
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
+                    "type": "string",
+                    "example": "08:10"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
+                }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.Auditorium": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.GridCell-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson": {
             "type": "object",
             "properties": {
-                "building": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Building"
+                "break_end": {
+                    "type": "string",
+                    "example": "09:00"
                 },
-                "display_name": {
+                "break_start": {
                     "type": "string",
-                    "example": "445 C"
+                    "example": "08:55"
                 },
-                "id": {
+                "end": {
+                    "type": "string",
+                    "example": "09:45"
+                },
+                "lessons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherLesson"
+                    }
+                },
+                "number": {
                     "type": "integer",
                     "example": 1
                 },
-                "number": {
+                "start": {
                     "type": "string",
-                    "example": "445"
+                    "example": "08:10"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson": {
             "type": "object",
             "properties": {
-                "courses": {
+                "cells": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        1
-                    ]
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridCell-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson"
+                    }
                 },
-                "date": {
+                "day": {
                     "type": "string",
-                    "example": "2025-06-18"
+                    "enum": [
+                        "monday",
+                        "tuesday",
+                        "wednesday",
+                        "thursday",
+                        "friday",
+                        "saturday"
+                    ],
+                    "example": "monday"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson": {
+            "type": "object",
+            "properties": {
+                "cells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridCell-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson"
+                    }
                 },
-                "faculties": {
+                "day": {
+                    "type": "string",
+                    "enum": [
+                        "monday",
+                        "tuesday",
+                        "wednesday",
+                        "thursday",
+                        "friday",
+                        "saturday"
+                    ],
+                    "example": "monday"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson": {
+            "type": "object",
+            "properties": {
+                "cells": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "фаиту",
-                        "фвт"
-                    ]
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridCell-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson"
+                    }
+                },
+                "day": {
+                    "type": "string",
+                    "enum": [
+                        "monday",
+                        "tuesday",
+                        "wednesday",
+                        "thursday",
+                        "friday",
+                        "saturday"
+                    ],
+                    "example": "monday"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Holiday": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-02-23"
+                },
+                "kind": {
+                    "enum": [
+                        "holiday",
+                        "non_working",
+                        "closure"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.HolidayKind"
+                        }
+                    ],
+                    "example": "holiday"
+                },
+                "source": {
+                    "enum": [
+                        "production_calendar",
+                        "manual"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.HolidaySource"
+                        }
+                    ],
+                    "example": "production_calendar"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "День защитника Отечества"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.HolidayKind": {
+            "type": "string",
+            "enum": [
+                "holiday",
+                "non_working",
+                "closure"
+            ],
+            "x-enum-varnames": [
+                "HolidayKindHoliday",
+                "HolidayKindNonWorking",
+                "HolidayKindClosure"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.HolidaySource": {
+            "type": "string",
+            "enum": [
+                "production_calendar",
+                "manual"
+            ],
+            "x-enum-varnames": [
+                "HolidaySourceProductionCalendar",
+                "HolidaySourceManual"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.HolidaysImport": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer",
+                    "example": 14
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Lesson": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-06-18"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2025-06-18T09:45:00"
                 },
                 "groups": {
                     "type": "array",
@@ -773,14 +4290,22 @@ const docTemplate = `{
                         "345"
                     ]
                 },
-                "lesson": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
                     "type": "string",
-                    "example": "Лек. Высшая математика\nКонюхов А.Н. 333 С"
+                    "example": "2025-06-18T08:10:00"
                 },
-                "teachers": {
+                "teacher_auditoriums": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentTeacherInfo"
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentTeacherAuditorium"
                     }
                 },
                 "time": {
@@ -793,317 +4318,578 @@ const docTemplate = `{
                 },
                 "type": {
                     "type": "string",
-                    "example": "lab,practice"
+                    "example": "lecture"
+                },
+                "week_type": {
+                    "type": "string",
+                    "example": "numerator"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumSchedule": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonBreak": {
             "type": "object",
             "properties": {
-                "auditorium": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Auditorium"
+                "active": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "denominator_period": {
+                "end": {
                     "type": "string",
-                    "example": "09.06-15.06"
+                    "example": "09:00"
                 },
-                "input_week_type": {
+                "start": {
                     "type": "string",
-                    "example": "numerator"
+                    "example": "08:55"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonChange": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonVersion"
                 },
-                "numerator_period": {
+                "before": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonVersion"
+                },
+                "changed_at": {
                     "type": "string",
-                    "example": "16.06-22.06"
+                    "example": "2026-02-10T09:00:00.123456+03:00"
                 },
-                "schedule": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumWeek"
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "time",
+                            "teacher",
+                            "auditorium"
+                        ]
+                    },
+                    "example": [
+                        "auditorium"
+                    ]
+                },
+                "group": {
+                    "type": "string",
+                    "example": "344"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "kind": {
+                    "enum": [
+                        "added",
+                        "removed",
+                        "modified"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonChangeKind"
+                        }
+                    ],
+                    "example": "modified"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "schedule-rsreu-0d9f6c1e@rsreu-schedule.ru"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumWeek": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonChangeKind": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "modified"
+            ],
+            "x-enum-varnames": [
+                "LessonChangeAdded",
+                "LessonChangeRemoved",
+                "LessonChangeModified"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonChanges": {
             "type": "object",
             "properties": {
-                "friday": {
+                "changes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonChange"
                     }
                 },
-                "monday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
-                    }
+                "cursor": {
+                    "description": "Cursor is the id of the last change, passed as after for the next page or poll.",
+                    "type": "integer",
+                    "example": 1
                 },
-                "saturday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
-                    }
+                "group": {
+                    "type": "string",
+                    "example": "344"
                 },
-                "thursday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
-                    }
+                "has_more": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "tuesday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
-                    }
+                "since": {
+                    "type": "string",
+                    "example": "2026-02-03T00:00:00+03:00"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonSlot": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "09:45"
                 },
-                "wednesday": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumLesson"
-                    }
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start": {
+                    "type": "string",
+                    "example": "08:10"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "08.10-09.45"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.Building": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonType": {
             "type": "object",
             "properties": {
-                "id": {
+                "description": {
+                    "type": "string",
+                    "example": "лабораторная"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "lab"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonVersion": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string",
+                    "example": "2026-02-09T09:45:00"
+                },
+                "lesson_type": {
+                    "type": "string",
+                    "example": "lecture"
+                },
+                "pair_number": {
                     "type": "integer",
                     "example": 1
                 },
-                "letter": {
+                "start_time": {
                     "type": "string",
-                    "example": "C"
+                    "example": "2026-02-09T08:10:00"
+                },
+                "teacher_auditoriums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.CalendarTeacherAuditorium"
+                    }
                 },
                 "title": {
                     "type": "string",
-                    "example": "Центральный корпус"
+                    "example": "Высшая математика"
+                },
+                "uid": {
+                    "type": "string",
+                    "example": "schedule-rsreu-0d9f6c1e@rsreu-schedule.ru"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonsNow": {
+            "type": "object",
+            "properties": {
+                "break": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.LessonBreak"
+                },
+                "current": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NowLesson"
+                },
+                "day_end": {
+                    "type": "string",
+                    "example": "15:10"
+                },
+                "next": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.NowLesson"
+                },
+                "now": {
+                    "type": "string",
+                    "example": "2026-03-02T08:45:00+03:00"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.CourseFaculties": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.LessonsPage": {
             "type": "object",
             "properties": {
-                "course": {
-                    "type": "integer",
-                    "enum": [
-                        1,
-                        2,
-                        3,
-                        4,
-                        5
-                    ],
-                    "example": 1
+                "from": {
+                    "type": "string",
+                    "example": "2026-02-09"
                 },
-                "faculties": {
+                "lessons": {
                     "type": "array",
                     "items": {
-                        "type": "string",
-                        "enum": [
-                            "иэф",
-                            "фаиту",
-                            "фвт",
-                            "фрт",
-                            "фэ"
-                        ]
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Lesson"
                     }
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "MjAyNi0wMi0wOVQwODoxMDowMHwxMg"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-06-30"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.CourseFacultyGroups": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.NotFoundResponse": {
             "type": "object",
             "properties": {
-                "course": {
-                    "type": "integer",
-                    "enum": [
-                        1,
-                        2,
-                        3,
-                        4,
-                        5
-                    ],
-                    "example": 1
-                },
-                "faculty": {
+                "message": {
                     "type": "string",
-                    "enum": [
-                        "иэф",
-                        "фаиту",
-                        "фвт",
-                        "фрт",
-                        "фэ"
-                    ],
-                    "example": "фвт"
+                    "example": "schedule for group 34 not found"
                 },
-                "groups": {
+                "suggestions": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Suggestion"
                     }
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.Day": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.NowLesson": {
             "type": "object",
             "properties": {
-                "day": {
+                "date": {
                     "type": "string",
-                    "enum": [
-                        "Monday",
-                        "Tuesday",
-                        "Wednesday",
-                        "Thursday",
-                        "Friday",
-                        "Saturday",
-                        "Sunday"
-                    ],
-                    "example": "Monday"
+                    "example": "2025-06-18"
                 },
-                "day_ru": {
+                "end_time": {
                     "type": "string",
-                    "enum": [
-                        "Пн",
-                        "Вт",
-                        "Ср",
-                        "Чт",
-                        "Пт",
-                        "Сб",
-                        "Вс"
-                    ],
-                    "example": "Пн"
+                    "example": "2025-06-18T09:45:00"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "344",
+                        "345"
+                    ]
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "minutes": {
+                    "type": "integer",
+                    "example": 35
+                },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2025-06-18T08:10:00"
+                },
+                "teacher_auditoriums": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentTeacherAuditorium"
+                    }
                 },
                 "time": {
                     "type": "string",
-                    "example": "08.10"
+                    "example": "08.10-09.45"
                 },
-                "week_type": {
+                "title": {
                     "type": "string",
-                    "enum": [
-                        "числитель",
-                        "знаменатель"
-                    ],
-                    "example": "знаменатель"
+                    "example": "Высшая математика"
                 },
-                "week_type_eng": {
+                "type": {
+                    "type": "string",
+                    "example": "lecture"
+                },
+                "week_type": {
                     "type": "string",
-                    "enum": [
-                        "numerator",
-                        "denominator"
-                    ],
                     "example": "numerator"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.Department": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumWeek": {
             "type": "object",
             "properties": {
-                "faculty": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Faculty"
+                "denominator": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumWeek"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "numerator": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumWeek"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_StudentWeek": {
+            "type": "object",
+            "properties": {
+                "denominator": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentWeek"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Кафедра вычислительной и прикладной математики"
+                "numerator": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentWeek"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_TeacherWeek": {
+            "type": "object",
+            "properties": {
+                "denominator": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherWeek"
                 },
-                "title_short": {
-                    "type": "string",
-                    "example": "ВПМ"
+                "numerator": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherWeek"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.Faculties": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleConflict": {
             "type": "object",
             "properties": {
-                "faculties": {
+                "auditorium": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Auditorium"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-03-02"
+                },
+                "group": {
+                    "type": "string",
+                    "example": "344"
+                },
+                "kind": {
+                    "enum": [
+                        "teacher",
+                        "auditorium",
+                        "group"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleConflictKind"
+                        }
+                    ],
+                    "example": "teacher"
+                },
+                "lessons": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ConflictLesson"
                     }
+                },
+                "teacher": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentTeacherInfo"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.Faculty": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleConflictKind": {
+            "type": "string",
+            "enum": [
+                "teacher",
+                "auditorium",
+                "group"
+            ],
+            "x-enum-varnames": [
+                "ScheduleConflictTeacher",
+                "ScheduleConflictAuditorium",
+                "ScheduleConflictGroup"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleConflicts": {
             "type": "object",
             "properties": {
-                "id": {
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleConflict"
+                    }
+                },
+                "faculty_id": {
                     "type": "integer",
                     "example": 1
                 },
-                "title": {
+                "from": {
                     "type": "string",
-                    "example": "Факультет автоматики и информационных технологий в управлении"
+                    "example": "2026-03-02"
                 },
-                "title_short": {
+                "to": {
                     "type": "string",
-                    "example": "фаиту"
+                    "example": "2026-03-15"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.FacultyCourses": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleGrid-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson": {
             "type": "object",
             "properties": {
-                "courses": {
+                "denominator": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson"
                     }
                 },
-                "faculty": {
-                    "type": "string",
-                    "enum": [
-                        "иэф",
-                        "фаиту",
-                        "фвт",
-                        "фрт",
-                        "фэ"
-                    ],
-                    "example": "фвт"
+                "numerator": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumLesson"
+                    }
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.LessonType": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleGrid-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "лабораторная"
+                "denominator": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson"
+                    }
                 },
-                "type": {
-                    "type": "string",
-                    "example": "lab"
+                "numerator": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson"
+                    }
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_AuditoriumWeek": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleGrid-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson": {
             "type": "object",
             "properties": {
                 "denominator": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumWeek"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson"
+                    }
+                },
+                "numerator": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.GridDay-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson"
+                    }
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.ScheduleStreamEvent": {
+            "type": "object",
+            "properties": {
+                "auditoriums": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "dates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-02-20"
+                    ]
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "344"
+                    ]
                 },
-                "numerator": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.AuditoriumWeek"
+                "revision": {
+                    "type": "integer",
+                    "example": 43
+                },
+                "teachers": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_StudentWeek": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.SearchHit": {
             "type": "object",
             "properties": {
-                "denominator": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentWeek"
+                "id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "numerator": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.StudentWeek"
+                "score": {
+                    "type": "number",
+                    "example": 0.95
+                },
+                "subtitle": {
+                    "type": "string",
+                    "example": "Конюхов А.Н."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Конюхов Алексей Николаевич"
+                },
+                "type": {
+                    "enum": [
+                        "group",
+                        "teacher",
+                        "auditorium",
+                        "building",
+                        "subject"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.SearchHitType"
+                        }
+                    ],
+                    "example": "teacher"
                 }
             }
         },
-        "github_com_schedule-rsreu_schedule-api_internal_models.NumeratorDenominator-github_com_schedule-rsreu_schedule-api_internal_models_TeacherWeek": {
+        "github_com_schedule-rsreu_schedule-api_internal_models.SearchHitType": {
+            "type": "string",
+            "enum": [
+                "group",
+                "teacher",
+                "auditorium",
+                "building",
+                "subject"
+            ],
+            "x-enum-varnames": [
+                "SearchHitGroup",
+                "SearchHitTeacher",
+                "SearchHitAuditorium",
+                "SearchHitBuilding",
+                "SearchHitSubject"
+            ]
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.SearchResults": {
             "type": "object",
             "properties": {
-                "denominator": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherWeek"
+                "hits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.SearchHit"
+                    }
                 },
-                "numerator": {
-                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.TeacherWeek"
+                "query": {
+                    "type": "string",
+                    "example": "Конюхов А"
                 }
             }
         },
@@ -1118,10 +4904,18 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2025-06-18T16:55:00"
                 },
+                "is_holiday": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "lesson": {
                     "type": "string",
                     "example": "Лек. Высшая математика\nКонюхов А.Н. 333 С"
                 },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "start_time": {
                     "type": "string",
                     "example": "2025-06-18T15:20:00"
@@ -1161,10 +4955,19 @@ const docTemplate = `{
                     "type": "string",
                     "example": "фвт"
                 },
+                "grid": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleGrid-github_com_schedule-rsreu_schedule-api_internal_models_StudentLesson"
+                },
                 "group": {
                     "type": "string",
                     "example": "344"
                 },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Holiday"
+                    }
+                },
                 "input_week_type": {
                     "type": "string",
                     "example": "numerator"
@@ -1253,6 +5056,65 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Suggestion": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "enum": [
+                        "no_lessons",
+                        "similar_name"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.SuggestionReason"
+                        }
+                    ],
+                    "example": "similar_name"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 0.95
+                },
+                "subtitle": {
+                    "type": "string",
+                    "example": "Конюхов А.Н."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Конюхов Алексей Николаевич"
+                },
+                "type": {
+                    "enum": [
+                        "group",
+                        "teacher",
+                        "auditorium",
+                        "building",
+                        "subject"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.SearchHitType"
+                        }
+                    ],
+                    "example": "teacher"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.SuggestionReason": {
+            "type": "string",
+            "enum": [
+                "no_lessons",
+                "similar_name"
+            ],
+            "x-enum-varnames": [
+                "SuggestionNoLessons",
+                "SuggestionSimilarName"
+            ]
+        },
         "github_com_schedule-rsreu_schedule-api_internal_models.TeacherLesson": {
             "type": "object",
             "properties": {
@@ -1292,10 +5154,18 @@ const docTemplate = `{
                         "345"
                     ]
                 },
+                "is_holiday": {
+                    "type": "boolean",
+                    "example": false
+                },
                 "lesson": {
                     "type": "string",
                     "example": "Лек. Высшая математика\nКонюхов А.Н. 333 С"
                 },
+                "pair_number": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "time": {
                     "type": "string",
                     "example": "08.10-09.45"
@@ -1327,6 +5197,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Конюхов Алексей Николаевич"
                 },
+                "grid": {
+                    "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.ScheduleGrid-github_com_schedule-rsreu_schedule-api_internal_models_TeacherLesson"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.Holiday"
+                    }
+                },
                 "id": {
                     "type": "integer",
                     "example": 1
@@ -1410,6 +5289,268 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_schedule-rsreu_schedule-api_internal_models.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_revision": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "scope": {
+                    "enum": [
+                        "group",
+                        "teacher",
+                        "auditorium"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_schedule-rsreu_schedule-api_internal_models.WebhookScope"
+                        }
+                    ],
+                    "example": "group"
+                },
+                "scope_id": {
+                    "type": "string",
+                    "example": "344"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "Yk3mJ0ZVbqzQ1o7b5b6k9pZ0JqgC2pXc3q2-7wEjRQ2"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://bot.example.com/schedule-webhook"
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "outbox_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                }
+            }
+        },
+        "github_com_schedule-rsreu_schedule-api_internal_models.WebhookScope": {
+            "type": "string",
+            "enum": [
+                "group",
+                "teacher",
+                "auditorium"
+            ],
+            "x-enum-varnames": [
+                "WebhookScopeGroup",
+                "WebhookScopeTeacher",
+                "WebhookScopeAuditorium"
+            ]
+        },
+        "internal_http_handlers_v1.academicPeriodRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "kind",
+                "start_date",
+                "title"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2026-06-30"
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "semester",
+                        "holidays",
+                        "exam_session",
+                        "practice"
+                    ],
+                    "example": "semester"
+                },
+                "numerator_start": {
+                    "type": "string",
+                    "example": "2026-02-09"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2026-02-09"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Весенний семестр 2025/2026"
+                }
+            }
+        },
+        "internal_http_handlers_v1.apiClientRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 63,
+                    "example": "telegram-bot"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "webhooks"
+                    ]
+                }
+            }
+        },
+        "internal_http_handlers_v1.apiClientUpdateRequest": {
+            "type": "object",
+            "required": [
+                "scopes"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "read",
+                        "webhooks"
+                    ]
+                }
+            }
+        },
+        "internal_http_handlers_v1.calendarFeedRequest": {
+            "type": "object",
+            "required": [
+                "group"
+            ],
+            "properties": {
+                "exclude": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers_v1.calendarFeedRule"
+                    }
+                },
+                "group": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "344"
+                },
+                "include": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/internal_http_handlers_v1.calendarFeedRule"
+                    }
+                }
+            }
+        },
+        "internal_http_handlers_v1.calendarFeedRule": {
+            "type": "object",
+            "properties": {
+                "auditorium": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "106а C"
+                },
+                "lesson_type": {
+                    "type": "string",
+                    "enum": [
+                        "lecture",
+                        "lab",
+                        "practice",
+                        "coursework",
+                        "course_project",
+                        "exam",
+                        "zachet",
+                        "consultation",
+                        "elective",
+                        "unknown"
+                    ],
+                    "example": "lab"
+                },
+                "teacher": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Конюхов"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Физическая культура"
+                }
+            }
+        },
+        "internal_http_handlers_v1.holidayRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "title"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "holiday",
+                        "non_working",
+                        "closure"
+                    ],
+                    "example": "closure"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "День защитника Отечества"
+                }
+            }
+        },
         "internal_http_handlers_v1.schedulesByGroupsRequest": {
             "type": "object",
             "required": [
@@ -1428,6 +5569,52 @@ const docTemplate = `{
                     ]
                 }
             }
+        },
+        "internal_http_handlers_v1.webhookActiveRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "internal_http_handlers_v1.webhookRequest": {
+            "type": "object",
+            "required": [
+                "scope",
+                "scope_id",
+                "url"
+            ],
+            "properties": {
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "group",
+                        "teacher",
+                        "auditorium"
+                    ],
+                    "example": "group"
+                },
+                "scope_id": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "344"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "https://bot.example.com/schedule-webhook"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Ключ API клиента или JWT с app_name клиента: \"Bearer {token}\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     },
     "externalDocs": {
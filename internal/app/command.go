@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/schedule-rsreu/schedule-api/config"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+	"github.com/schedule-rsreu/schedule-api/pkg/postgres"
+)
+
+var (
+	ErrUnknownCommand    = errors.New("unknown command, expected create-client or check-conflicts")
+	ErrScheduleConflicts = errors.New("schedule has conflicts")
+)
+
+// RunCommand runs a maintenance subcommand instead of the server.
+func RunCommand(cfg *config.Config, args []string, stdout io.Writer) error {
+	switch args[0] {
+	case "create-client":
+		return createClientCommand(cfg, args[1:], stdout)
+	case "check-conflicts":
+		return checkConflictsCommand(cfg, args[1:], stdout)
+	default:
+		return fmt.Errorf("%w: %v", ErrUnknownCommand, args[0])
+	}
+}
+
+// createClientCommand issues an API client, the first admin client can only be created this way.
+func createClientCommand(cfg *config.Config, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("create-client", flag.ContinueOnError)
+	name := flags.String("name", "", "client name, app_name in its JWTs")
+	scopes := flags.String("scopes", string(models.APIClientScopeRead), "comma separated scopes: read, admin, webhooks")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	postgresDB, err := postgres.New(cfg.PostgresDSN)
+	if err != nil {
+		return err
+	}
+	defer postgresDB.Close()
+
+	client := &models.APIClient{Name: *name}
+	for scope := range strings.SplitSeq(*scopes, ",") {
+		client.Scopes = append(client.Scopes, models.APIClientScope(strings.TrimSpace(scope)))
+	}
+	client, err = services.NewScheduleService(repo.NewScheduleRepo(postgresDB)).CreateAPIClient(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(stdout, "client %v (id %v), scopes %v\nkey: %v\n", client.Name, client.Id, client.Scopes, client.Key)
+	return err
+}
+
+// checkConflictsCommand prints the schedule conflicts of the date range and fails if there are any,
+// so a data import can be checked before it is published.
+func checkConflictsCommand(cfg *config.Config, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("check-conflicts", flag.ContinueOnError)
+	from := flags.String("from", "", "first date YYYY-MM-DD, today by default")
+	to := flags.String("to", "", "last date YYYY-MM-DD, two weeks after from by default")
+	facultyID := flags.Int("faculty", 0, "only conflicts involving groups of the faculty id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	postgresDB, err := postgres.New(cfg.PostgresDSN)
+	if err != nil {
+		return err
+	}
+	defer postgresDB.Close()
+
+	report, err := services.NewScheduleService(repo.NewScheduleRepo(postgresDB)).
+		GetScheduleConflicts(context.Background(), *from, *to, *facultyID)
+	if err != nil {
+		return err
+	}
+
+	for _, conflict := range report.Conflicts {
+		if _, err := fmt.Fprintln(stdout, formatConflict(&conflict)); err != nil {
+			return err
+		}
+	}
+	if len(report.Conflicts) > 0 {
+		return fmt.Errorf("%w: %v from %v to %v", ErrScheduleConflicts, len(report.Conflicts), report.From, report.To)
+	}
+	_, err = fmt.Fprintf(stdout, "no conflicts from %v to %v\n", report.From, report.To)
+	return err
+}
+
+func formatConflict(conflict *models.ScheduleConflict) string {
+	subject := conflict.Group
+	switch {
+	case conflict.Teacher != nil:
+		subject = conflict.Teacher.ShortName
+	case conflict.Auditorium != nil:
+		subject = conflict.Auditorium.DisplayName
+	}
+
+	lessons := make([]string, 0, len(conflict.Lessons))
+	for _, lesson := range conflict.Lessons {
+		lessons = append(lessons, fmt.Sprintf("%v %v (%v)", lesson.Time, lesson.Title, strings.Join(lesson.Groups, ", ")))
+	}
+	return fmt.Sprintf("%v %v %v: %v", conflict.Date, conflict.Kind, subject, strings.Join(lessons, " / "))
+}
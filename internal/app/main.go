@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -20,15 +21,19 @@ import (
 
 	"github.com/schedule-rsreu/schedule-api/pkg/postgres"
 
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
 	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/dwh"
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/ratelimit"
 
 	"github.com/labstack/gommon/color"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	mwp "github.com/schedule-rsreu/schedule-api/internal/http/middleware/prometheus"
 
 	"github.com/go-playground/validator/v10"
 
+	"github.com/schedule-rsreu/schedule-api/internal/cache"
 	"github.com/schedule-rsreu/schedule-api/internal/http/handlers"
 	"github.com/schedule-rsreu/schedule-api/internal/repo"
 	"github.com/schedule-rsreu/schedule-api/internal/services"
@@ -162,13 +167,66 @@ func Run(cfg *config.Config) {
 	e.HideBanner = true
 	e.HidePort = true
 
+	dwhSink, dwhSinkCloser, err := dwh.NewSink(cfg.DWHUrl, cfg.DWHToken)
+	if err != nil {
+		logger.Error().Err(err).Msg("app - Run - dwh.NewSink")
+		return
+	}
+	dwhLogger := logger.With().Str("component", "dwh").Logger()
+	dwhExporter := dwh.NewExporter(dwhSink, dwh.Options{}, &dwhLogger, prometheus.DefaultRegisterer)
+
+	rateLimitRules, err := ratelimit.ParseRules(cfg.RateLimits)
+	if err != nil {
+		logger.Error().Err(err).Msg("app - Run - ratelimit.ParseRules")
+		return
+	}
+	e.IPExtractor, err = ratelimit.IPExtractor(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error().Err(err).Msg("app - Run - ratelimit.IPExtractor")
+		return
+	}
+
+	cacheBackend, cacheBackendCloser, err := cache.NewBackend(cache.Options{
+		Backend:    cfg.CacheBackend,
+		MaxEntries: cfg.CacheMaxEntries,
+		MaxBytes:   cfg.CacheMaxBytes,
+		RedisURL:   cfg.RedisURL,
+		TTL:        cfg.CacheTTL,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("app - Run - cache.NewBackend")
+		return
+	}
+
 	postgresDB, err := postgres.New(cfg.PostgresDSN)
 	if err != nil {
 		logger.Error().Err(err).Msg("Postgres connection failed")
 		return
 	}
 
-	handlers.NewRouter(e, services.NewScheduleService(repo.NewScheduleRepo(postgresDB)))
+	scheduleService := services.NewScheduleService(repo.NewScheduleRepo(postgresDB))
+	if cacheBackend != nil {
+		scheduleService.Cache = cache.New(
+			cacheBackend,
+			scheduleService.CheckRevision,
+			cfg.CacheRevisionInterval,
+			prometheus.DefaultRegisterer,
+		)
+	}
+	streamLogger := logger.With().Str("component", "stream").Logger()
+	scheduleHub := services.NewScheduleHub(scheduleService, &streamLogger)
+	handlers.NewRouter(e, scheduleService, scheduleHub)
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	workers.Go(func() {
+		scheduleHub.Run(workersCtx, cfg.PostgresDSN)
+	})
+	webhookLogger := logger.With().Str("component", "webhooks").Logger()
+	webhookWorker := services.NewWebhookWorker(scheduleService, services.NewWebhookSender(), cfg.WebhooksInterval, &webhookLogger)
+	workers.Go(func() {
+		webhookWorker.Run(workersCtx)
+	})
 
 	go func() {
 		if cfg.Production {
@@ -176,7 +234,7 @@ func Run(cfg *config.Config) {
 		} else {
 			printBanner(cfg.Version, "http://localhost:"+cfg.Port)
 		}
-		setupEcho(e, &logger, cfg.DWHUrl)
+		setupEcho(e, &logger, dwhExporter, auth.New(scheduleService, cfg.JWTSecret), ratelimit.NewLimiter(rateLimitRules))
 
 		err := e.Start(net.JoinHostPort(cfg.Host, cfg.Port))
 		if err != nil {
@@ -195,17 +253,40 @@ func Run(cfg *config.Config) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
 
+	// Открытые потоки не дали бы серверу остановиться.
+	scheduleHub.Close()
 	if err := e.Shutdown(ctx); err != nil {
 		logger.Error().Err(err).Msg("app - Run - httpServer.Shutdown")
 	}
 
+	stopWorkers()
+	workers.Wait()
+	logger.Info().Msg("app - Run - workers stopped")
+
+	if err := dwhExporter.Close(ctx); err != nil {
+		logger.Error().Err(err).Msg("app - Run - dwhExporter.Close")
+	}
+	if err := dwhSinkCloser.Close(); err != nil {
+		logger.Error().Err(err).Msg("app - Run - dwhSinkCloser.Close")
+	}
+
+	if err := cacheBackendCloser.Close(); err != nil {
+		logger.Error().Err(err).Msg("app - Run - cacheBackendCloser.Close")
+	}
+
 	postgresDB.Close()
 	logger.Info().Msg("app - Run - postgresDB.Close - exit")
 
 	logger.Info().Msg("app - Run - exit")
 }
 
-func setupEcho(e *echo.Echo, logger *zerolog.Logger, dwhURL string) {
+func setupEcho(
+	e *echo.Echo,
+	logger *zerolog.Logger,
+	dwhExporter *dwh.Exporter,
+	identifyClient echo.MiddlewareFunc,
+	limiter *ratelimit.Limiter,
+) {
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORS())
 	e.Use(middleware.RequestID())
@@ -219,7 +300,10 @@ func setupEcho(e *echo.Echo, logger *zerolog.Logger, dwhURL string) {
 
 	e.Validator = &CustomValidator{validator: validator.New()}
 
-	e.Use(dwh.New(dwhURL, "", "secret"))
+	e.Use(ratelimit.Authentication(limiter))
+	e.Use(identifyClient)
+	e.Use(ratelimit.New(limiter, "schedule_api", prometheus.DefaultRegisterer))
+	e.Use(dwh.New(dwhExporter))
 }
 
 func addTraceToLogMiddleware(defaultLogger *zerolog.Logger) echo.MiddlewareFunc {
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendOff    = "off"
+
+	redisPrefix = "schedule-api:cache:"
+)
+
+// Backend stores encoded values. Keys start with the calendar revision they were read at.
+type Backend interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+	// Purge is called when the revision changes.
+	Purge(ctx context.Context) error
+}
+
+// Options select and size the backend.
+type Options struct {
+	Backend    string
+	MaxEntries int
+	MaxBytes   int64
+	RedisURL   string
+	TTL        time.Duration
+}
+
+// NewBackend returns the memory or Redis backend, or nil when the cache is off.
+// The memory cache without entries is off too, CACHE_MAX_ENTRIES=0 disabled the cache before the backends.
+// The Redis connection is closed by the returned closer.
+func NewBackend(options Options) (Backend, io.Closer, error) {
+	switch options.Backend {
+	case BackendMemory:
+		if options.MaxEntries <= 0 {
+			return nil, io.NopCloser(nil), nil
+		}
+		return NewMemory(options.MaxEntries, options.MaxBytes), io.NopCloser(nil), nil
+	case BackendRedis:
+		redisOptions, err := redis.ParseURL(options.RedisURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse REDIS_URL: %w", err)
+		}
+		client := redis.NewClient(redisOptions)
+		return NewRedis(client, redisPrefix, options.TTL), client, nil
+	case BackendOff:
+		return nil, io.NopCloser(nil), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported CACHE_BACKEND %q, expected memory, redis or off", options.Backend)
+	}
+}
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/singleflight"
+)
+
+// RevisionFunc returns the calendar revision, every schedule import bumps it.
+type RevisionFunc func(ctx context.Context) (int64, error)
+
+// Cache stores encoded query results under the calendar revision they were read at.
+// The revision is checked at most once per interval, so an import is served after at most that long.
+// Backend errors are counted and treated as misses, so the schedule is read from the database instead.
+type Cache struct {
+	store    Backend
+	revision RevisionFunc
+	interval time.Duration
+	Now      func() time.Time
+
+	group singleflight.Group
+
+	mu        sync.Mutex
+	current   int64
+	checkedAt time.Time
+
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+	failed *prometheus.CounterVec
+}
+
+func New(store Backend, revision RevisionFunc, interval time.Duration, registerer prometheus.Registerer) *Cache {
+	c := &Cache{
+		store:    store,
+		revision: revision,
+		interval: interval,
+		Now:      time.Now,
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "schedule_cache_hits_total",
+			Help: "Schedule queries served from the cache.",
+		}, []string{"entity"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "schedule_cache_misses_total",
+			Help: "Schedule queries not found in the cache, including the ones that waited for a concurrent query.",
+		}, []string{"entity"}),
+		failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "schedule_cache_errors_total",
+			Help: "Failed cache backend operations.",
+		}, []string{"operation"}),
+	}
+	registerer.MustRegister(c.hits, c.misses, c.failed)
+	if memory, ok := store.(*Memory); ok {
+		registerer.MustRegister(
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name: "schedule_cache_entries",
+				Help: "Schedule query results in the memory cache.",
+			}, func() float64 { return float64(memory.Len()) }),
+			prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name: "schedule_cache_size_bytes",
+				Help: "Size of the schedule query results in the memory cache.",
+			}, func() float64 { return float64(memory.Size()) }),
+		)
+	}
+	return c
+}
+
+// Get returns the cached value of the entity key, concurrent misses of the same key share one load.
+// load does not inherit the caller's cancellation, so one canceled request does not fail the others.
+func (c *Cache) Get(ctx context.Context, entity, key string, load func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	revision, err := c.Revision(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key = fmt.Sprintf("r%d:%s:%s", revision, entity, key)
+	value, ok, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.failed.WithLabelValues("get").Inc()
+	}
+	if ok {
+		c.hits.WithLabelValues(entity).Inc()
+		return value, nil
+	}
+	c.misses.WithLabelValues(entity).Inc()
+
+	shared, err, _ := c.group.Do(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.store.Set(ctx, key, value); err != nil {
+			c.failed.WithLabelValues("set").Inc()
+		}
+		return value, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shared.([]byte), nil //nolint:forcetypeassert // load returns bytes
+}
+
+// Revision returns the last checked calendar revision, the cache is purged when it changes.
+func (c *Cache) Revision(ctx context.Context) (int64, error) {
+	c.mu.Lock()
+	if !c.checkedAt.IsZero() && c.Now().Sub(c.checkedAt) < c.interval {
+		revision := c.current
+		c.mu.Unlock()
+		return revision, nil
+	}
+	c.mu.Unlock()
+
+	// Value keys always contain colons, so they never share a call with the revision check.
+	revision, err, _ := c.group.Do("revision", func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		revision, err := c.revision(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get calendar revision: %w", err)
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if revision != c.current {
+			if err := c.store.Purge(ctx); err != nil {
+				c.failed.WithLabelValues("purge").Inc()
+			}
+			c.current = revision
+		}
+		c.checkedAt = c.Now()
+		return revision, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return revision.(int64), nil //nolint:forcetypeassert // the revision is int64
+}
@@ -0,0 +1,187 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/schedule-rsreu/schedule-api/internal/cache"
+)
+
+func TestMemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	memory := cache.NewMemory(2, 0)
+	memory.Set(t.Context(), "a", []byte("1"))
+	memory.Set(t.Context(), "b", []byte("2"))
+	memory.Get(t.Context(), "a")
+	memory.Set(t.Context(), "c", []byte("3"))
+
+	if _, ok, _ := memory.Get(t.Context(), "b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := memory.Get(t.Context(), key); !ok {
+			t.Errorf("expected %v to be cached", key)
+		}
+	}
+}
+
+func TestMemoryLimitsSize(t *testing.T) {
+	memory := cache.NewMemory(0, 10)
+	memory.Set(t.Context(), "a", []byte("1234"))
+	memory.Set(t.Context(), "b", []byte("1234"))
+	if memory.Size() != 10 || memory.Len() != 2 {
+		t.Fatalf("unexpected size %v of %v entries", memory.Size(), memory.Len())
+	}
+
+	memory.Set(t.Context(), "c", []byte("1"))
+	if _, ok, _ := memory.Get(t.Context(), "a"); ok {
+		t.Error("expected a to be evicted")
+	}
+	if memory.Size() != 7 {
+		t.Errorf("unexpected size %v", memory.Size())
+	}
+
+	memory.Set(t.Context(), "d", []byte("1234567890"))
+	if _, ok, _ := memory.Get(t.Context(), "d"); ok {
+		t.Error("expected a value larger than the cache to be skipped")
+	}
+
+	memory.Set(t.Context(), "b", []byte("1"))
+	if memory.Size() != 4 {
+		t.Errorf("unexpected size %v after replacing a value", memory.Size())
+	}
+}
+
+type revisionSource struct {
+	revision atomic.Int64
+	calls    atomic.Int64
+}
+
+func (r *revisionSource) get(context.Context) (int64, error) {
+	r.calls.Add(1)
+	return r.revision.Load(), nil
+}
+
+func TestCacheInvalidatesOnRevision(t *testing.T) {
+	source := &revisionSource{}
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	c := cache.New(cache.NewMemory(10, 0), source.get, 5*time.Second, prometheus.NewRegistry())
+	c.Now = func() time.Time { return now }
+
+	loads := 0
+	load := func(context.Context) ([]byte, error) {
+		loads++
+		return []byte{byte(loads)}, nil
+	}
+	get := func() byte {
+		t.Helper()
+		value, err := c.Get(t.Context(), "group", "1", load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value[0]
+	}
+
+	if get() != 1 || get() != 1 {
+		t.Fatal("expected the second call to be served from the cache")
+	}
+	if source.calls.Load() != 1 {
+		t.Errorf("expected one revision check, got %v", source.calls.Load())
+	}
+
+	source.revision.Store(1)
+	if get() != 1 {
+		t.Error("expected the revision to be checked only after the interval")
+	}
+	now = now.Add(5 * time.Second)
+	if get() != 2 {
+		t.Error("expected a new revision to load the value again")
+	}
+	if get() != 2 || loads != 2 {
+		t.Errorf("expected the new value to be cached, loaded %v times", loads)
+	}
+}
+
+func TestCacheSharesConcurrentLoads(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := cache.New(cache.NewMemory(10, 0), (&revisionSource{}).get, time.Minute, registry)
+
+	var loads atomic.Int64
+	release := make(chan struct{})
+	load := func(context.Context) ([]byte, error) {
+		loads.Add(1)
+		<-release
+		return []byte("schedule"), nil
+	}
+
+	const callers = 10
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	for range callers {
+		done.Go(func() {
+			started.Done()
+			value, err := c.Get(t.Context(), "teacher", "1", load)
+			if err != nil || string(value) != "schedule" {
+				t.Errorf("unexpected value %q, %v", value, err)
+			}
+		})
+	}
+	started.Wait()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if loads.Load() != 1 {
+		t.Errorf("expected one load, got %v", loads.Load())
+	}
+	if _, err := c.Get(t.Context(), "teacher", "1", load); err != nil {
+		t.Fatal(err)
+	}
+
+	// Callers that came after the load finished are hits, the rest waited for the shared load.
+	hits := counterValue(t, registry, "schedule_cache_hits_total")
+	misses := counterValue(t, registry, "schedule_cache_misses_total")
+	if hits < 1 || hits+misses != callers+1 {
+		t.Errorf("unexpected %v hits and %v misses", hits, misses)
+	}
+}
+
+func counterValue(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value float64
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			value += metric.GetCounter().GetValue()
+		}
+	}
+	return value
+}
+
+func TestCacheDoesNotStoreErrors(t *testing.T) {
+	c := cache.New(cache.NewMemory(10, 0), (&revisionSource{}).get, time.Minute, prometheus.NewRegistry())
+	errLoad := errors.New("no schedule")
+
+	if _, err := c.Get(t.Context(), "auditorium", "1", func(context.Context) ([]byte, error) {
+		return nil, errLoad
+	}); !errors.Is(err, errLoad) {
+		t.Fatalf("expected load error, got %v", err)
+	}
+	value, err := c.Get(t.Context(), "auditorium", "1", func(context.Context) ([]byte, error) {
+		return []byte("schedule"), nil
+	})
+	if err != nil || string(value) != "schedule" {
+		t.Errorf("unexpected value %q, %v", value, err)
+	}
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+)
+
+// Memory is an LRU of encoded values limited by the number of entries and their total size.
+type Memory struct {
+	maxEntries int
+	maxBytes   int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+	size    int64
+}
+
+type memoryEntry struct {
+	key   string
+	value []byte
+}
+
+// NewMemory creates an LRU, zero limits are not enforced.
+func NewMemory(maxEntries int, maxBytes int64) *Memory {
+	return &Memory{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	m.order.MoveToFront(element)
+	return element.Value.(*memoryEntry).value, true, nil //nolint:forcetypeassert // only entries are stored
+}
+
+// Set stores the value evicting the least recently used entries, a value larger than the whole cache is skipped.
+func (m *Memory) Set(_ context.Context, key string, value []byte) error {
+	size := entrySize(key, value)
+	if m.maxBytes > 0 && size > m.maxBytes {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, value: value})
+	m.size += size
+
+	for (m.maxEntries > 0 && m.order.Len() > m.maxEntries) || (m.maxBytes > 0 && m.size > m.maxBytes) {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+// Purge drops everything, values of the previous revision are never read again.
+func (m *Memory) Purge(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.order.Init()
+	m.size = 0
+	return nil
+}
+
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// Size is the total length of the stored keys and values in bytes.
+func (m *Memory) Size() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.size
+}
+
+func (m *Memory) remove(element *list.Element) {
+	entry := m.order.Remove(element).(*memoryEntry) //nolint:forcetypeassert // only entries are stored
+	delete(m.entries, entry.key)
+	m.size -= entrySize(entry.key, entry.value)
+}
+
+func entrySize(key string, value []byte) int64 {
+	return int64(len(key) + len(value))
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis shares the cache between replicas. Values are gzip-compressed JSON that expire after ttl,
+// keys start with the revision, so values of older revisions are never read again and just expire.
+type Redis struct {
+	client redis.UniversalClient
+	prefix string
+	ttl    time.Duration
+}
+
+func NewRedis(client redis.UniversalClient, prefix string, ttl time.Duration) *Redis {
+	return &Redis{client: client, prefix: prefix, ttl: ttl}
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	compressed, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis get: %w", err)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, false, fmt.Errorf("decompress cached value: %w", err)
+	}
+	value, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false, fmt.Errorf("decompress cached value: %w", err)
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte) error {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(value); err != nil {
+		return fmt.Errorf("compress cached value: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("compress cached value: %w", err)
+	}
+
+	if err := r.client.Set(ctx, r.prefix+key, compressed.Bytes(), r.ttl).Err(); err != nil {
+		return fmt.Errorf("redis set: %w", err)
+	}
+	return nil
+}
+
+// Purge does nothing, the new revision is a new namespace for every replica at once.
+func (r *Redis) Purge(context.Context) error {
+	return nil
+}
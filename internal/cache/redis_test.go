@@ -0,0 +1,130 @@
+package cache_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/schedule-rsreu/schedule-api/internal/cache"
+)
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, *cache.Redis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	return server, cache.NewRedis(client, "test:", time.Hour)
+}
+
+func TestRedisStoresCompressedValues(t *testing.T) {
+	server, backend := newRedis(t)
+	value := []byte(`{"schedule":"` + strings.Repeat("лекция ", 100) + `"}`)
+
+	if err := backend.Set(t.Context(), "r1:group:1", value); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := server.Get("test:r1:group:1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) >= len(value) || strings.HasPrefix(stored, "{") {
+		t.Errorf("expected a compressed value, got %v of %v bytes", len(stored), len(value))
+	}
+	if ttl := server.TTL("test:r1:group:1"); ttl != time.Hour {
+		t.Errorf("unexpected ttl %v", ttl)
+	}
+
+	cached, ok, err := backend.Get(t.Context(), "r1:group:1")
+	if err != nil || !ok || string(cached) != string(value) {
+		t.Errorf("unexpected cached value %q, %v, %v", cached, ok, err)
+	}
+	if _, ok, err := backend.Get(t.Context(), "r1:group:2"); ok || err != nil {
+		t.Errorf("expected a miss, got %v, %v", ok, err)
+	}
+}
+
+func TestRedisIsSharedAndKeyedByRevision(t *testing.T) {
+	server, backend := newRedis(t)
+	source := &revisionSource{}
+	replicas := []*cache.Cache{
+		cache.New(backend, source.get, 0, prometheus.NewRegistry()),
+		cache.New(backend, source.get, 0, prometheus.NewRegistry()),
+	}
+
+	loads := 0
+	load := func(context.Context) ([]byte, error) {
+		loads++
+		return []byte("schedule"), nil
+	}
+	for _, replica := range replicas {
+		if _, err := replica.Get(t.Context(), "group", "1", load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("expected the second replica to use the shared value, loaded %v times", loads)
+	}
+
+	source.revision.Store(2)
+	if _, err := replicas[1].Get(t.Context(), "group", "1", load); err != nil {
+		t.Fatal(err)
+	}
+	if loads != 2 {
+		t.Errorf("expected a new revision to load the value again, loaded %v times", loads)
+	}
+	for _, key := range []string{"test:r0:group:1", "test:r2:group:1"} {
+		if !server.Exists(key) {
+			t.Errorf("expected %v to exist", key)
+		}
+	}
+}
+
+func TestCacheLoadsWhenRedisIsDown(t *testing.T) {
+	server, backend := newRedis(t)
+	registry := prometheus.NewRegistry()
+	c := cache.New(backend, (&revisionSource{}).get, time.Minute, registry)
+	server.Close()
+
+	value, err := c.Get(t.Context(), "teacher", "1", func(context.Context) ([]byte, error) {
+		return []byte("schedule"), nil
+	})
+	if err != nil || string(value) != "schedule" {
+		t.Fatalf("unexpected value %q, %v", value, err)
+	}
+	if failed := counterValue(t, registry, "schedule_cache_errors_total"); failed != 2 {
+		t.Errorf("expected failed get and set, got %v", failed)
+	}
+}
+
+func TestNewBackend(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	backend, closer, err := cache.NewBackend(cache.Options{Backend: cache.BackendRedis, RedisURL: "redis://" + server.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := backend.(*cache.Redis); !ok {
+		t.Errorf("unexpected backend %T", backend)
+	}
+	if err := closer.Close(); err != nil {
+		t.Error(err)
+	}
+
+	if backend, _, err := cache.NewBackend(cache.Options{Backend: cache.BackendMemory, MaxEntries: 1}); err != nil || backend == nil {
+		t.Errorf("unexpected memory backend %v, %v", backend, err)
+	}
+	if backend, _, err := cache.NewBackend(cache.Options{Backend: cache.BackendMemory}); err != nil || backend != nil {
+		t.Errorf("expected no memory backend without entries, got %v, %v", backend, err)
+	}
+	if backend, _, err := cache.NewBackend(cache.Options{Backend: cache.BackendOff}); err != nil || backend != nil {
+		t.Errorf("expected no backend, got %v, %v", backend, err)
+	}
+	if _, _, err := cache.NewBackend(cache.Options{Backend: "memcached"}); err == nil {
+		t.Error("expected unsupported backend error")
+	}
+}
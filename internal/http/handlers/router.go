@@ -18,7 +18,14 @@ import (
 // @description     API for RSREU schedule.
 // @externalDocs.description  GitHub
 // @externalDocs.url          https://github.com/schedule-rsreu/schedule-api
-func NewRouter(e *echo.Echo, scheduleService *services.ScheduleService) {
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 Ключ API клиента или JWT с app_name клиента: "Bearer {token}"
+func NewRouter(e *echo.Echo,
+	scheduleService *services.ScheduleService,
+	scheduleHub *services.ScheduleHub,
+) {
 	e.GET("/docs", func(c echo.Context) error {
 		return c.Redirect(http.StatusMovedPermanently, "/docs/index.html")
 	})
@@ -34,5 +41,10 @@ func NewRouter(e *echo.Echo, scheduleService *services.ScheduleService) {
 		return echoSwagger.WrapHandler(c)
 	})
 
-	v1.NewRouter(e.Group("/api/v1"), scheduleService)
+	// RFC 6764: клиенты CalDAV ищут сервис по этому адресу.
+	e.Any("/.well-known/caldav", func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, "/api/v1/caldav/")
+	})
+
+	v1.NewRouter(e.Group("/api/v1"), scheduleService, scheduleHub)
 }
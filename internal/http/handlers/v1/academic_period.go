@@ -0,0 +1,174 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+type academicPeriodRequest struct {
+	Kind           string `json:"kind"            validate:"required,oneof=semester holidays exam_session practice" example:"semester"`                   //nolint:lll // there is no way to fix it
+	Title          string `json:"title"           validate:"required,max=200"                                       example:"Весенний семестр 2025/2026"` //nolint:lll // there is no way to fix it
+	StartDate      string `json:"start_date"      validate:"required,datetime=2006-01-02"                           example:"2026-02-09"`
+	EndDate        string `json:"end_date"        validate:"required,datetime=2006-01-02"                           example:"2026-06-30"`
+	NumeratorStart string `json:"numerator_start" validate:"omitempty,datetime=2006-01-02"                          example:"2026-02-09"`
+}
+
+func (sh *ScheduleHandler) bindAcademicPeriod(c echo.Context) (*models.AcademicPeriod, error) {
+	var req academicPeriodRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return &models.AcademicPeriod{
+		Kind:           models.AcademicPeriodKind(req.Kind),
+		Title:          req.Title,
+		StartDate:      req.StartDate,
+		EndDate:        req.EndDate,
+		NumeratorStart: req.NumeratorStart,
+	}, nil
+}
+
+// getAcademicPeriods
+// @Summary     List academic periods
+// @Description Семестры, каникулы, сессии и практики, по дате начала
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/academic-periods [get]
+// @Success     200  {array}  models.AcademicPeriod
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAcademicPeriods(c echo.Context) error {
+	resp, err := sh.s.GetAcademicPeriods(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// getAcademicPeriod
+// @Summary     Get an academic period
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/academic-periods/{id} [get]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     200  {object}  models.AcademicPeriod
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAcademicPeriod(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "id path param must be integer")
+	}
+
+	resp, err := sh.s.GetAcademicPeriod(c.Request().Context(), id)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// createAcademicPeriod
+// @Summary     Create an academic period
+// @Description numerator_start — понедельник недели-числителя, обязателен для семестра
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/academic-periods [post]
+// @Param       period  body  academicPeriodRequest  true  "period"
+// @Success     201  {object}  models.AcademicPeriod
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) createAcademicPeriod(c echo.Context) error {
+	period, err := sh.bindAcademicPeriod(c)
+	if err != nil {
+		return err
+	}
+
+	if err = sh.s.CreateAcademicPeriod(c.Request().Context(), period); err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusCreated, period)
+}
+
+// updateAcademicPeriod
+// @Summary     Update an academic period
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/academic-periods/{id} [put]
+// @Param       id  path  int  true  "id" example(1)
+// @Param       period  body  academicPeriodRequest  true  "period"
+// @Success     200  {object}  models.AcademicPeriod
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) updateAcademicPeriod(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "id path param must be integer")
+	}
+
+	period, err := sh.bindAcademicPeriod(c)
+	if err != nil {
+		return err
+	}
+	period.Id = id
+
+	if err = sh.s.UpdateAcademicPeriod(c.Request().Context(), period); err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, period)
+}
+
+// deleteAcademicPeriod
+// @Summary     Delete an academic period
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/academic-periods/{id} [delete]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     204
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) deleteAcademicPeriod(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "id path param must be integer")
+	}
+
+	if err = sh.s.DeleteAcademicPeriod(c.Request().Context(), id); err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,218 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+type apiClientRequest struct {
+	Name   string   `json:"name"   validate:"required,max=63"                               example:"telegram-bot"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=read admin webhooks" example:"read,webhooks"`
+}
+
+type apiClientUpdateRequest struct {
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=read admin webhooks" example:"read,webhooks"`
+	Active bool     `json:"active"                                                          example:"true"`
+}
+
+func apiClientScopes(scopes []string) []models.APIClientScope {
+	result := make([]models.APIClientScope, 0, len(scopes))
+	for _, scope := range scopes {
+		result = append(result, models.APIClientScope(scope))
+	}
+	return result
+}
+
+func apiClientID(c echo.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "id path param must be integer")
+	}
+	return id, nil
+}
+
+// getAPIClients
+// @Summary     List API clients
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/clients [get]
+// @Success     200  {array}  models.APIClient
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAPIClients(c echo.Context) error {
+	resp, err := sh.s.GetAPIClients(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// createAPIClient
+// @Summary     Issue an API client
+// @Description Возвращает ключ API, он показывается только один раз. Клиент передаёт его как "Bearer {key}"
+// @Description или подписывает JWT с app_name, равным имени клиента, секретом JWT_SECRET
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/clients [post]
+// @Param       client  body  apiClientRequest  true  "client"
+// @Success     201  {object}  models.APIClient
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     409  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) createAPIClient(c echo.Context) error {
+	var req apiClientRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	client := &models.APIClient{Name: req.Name, Scopes: apiClientScopes(req.Scopes)}
+	resp, err := sh.s.CreateAPIClient(c.Request().Context(), client)
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if errors.As(err, &services.ConflictError{}) {
+			return echo.NewHTTPError(http.StatusConflict, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// getAPIClient
+// @Summary     Get an API client
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/clients/{id} [get]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     200  {object}  models.APIClient
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAPIClient(c echo.Context) error {
+	id, err := apiClientID(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sh.s.GetAPIClient(c.Request().Context(), id)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// updateAPIClient
+// @Summary     Update an API client
+// @Description Меняет права клиента или отключает его. Отключённый клиент получает 401
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/clients/{id} [put]
+// @Param       id  path  int  true  "id" example(1)
+// @Param       client  body  apiClientUpdateRequest  true  "client"
+// @Success     200  {object}  models.APIClient
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) updateAPIClient(c echo.Context) error {
+	id, err := apiClientID(c)
+	if err != nil {
+		return err
+	}
+
+	var req apiClientUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	client := &models.APIClient{Scopes: apiClientScopes(req.Scopes), Active: req.Active, Id: id}
+	resp, err := sh.s.UpdateAPIClient(c.Request().Context(), client)
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// rotateAPIClientKey
+// @Summary     Rotate the API key
+// @Description Выпускает новый ключ, старый перестаёт работать сразу
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/clients/{id}/key [post]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     200  {object}  models.APIClient
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) rotateAPIClientKey(c echo.Context) error {
+	id, err := apiClientID(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sh.s.RotateAPIClientKey(c.Request().Context(), id)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// deleteAPIClient
+// @Summary     Delete an API client
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/clients/{id} [delete]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     204
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) deleteAPIClient(c echo.Context) error {
+	id, err := apiClientID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := sh.s.DeleteAPIClient(c.Request().Context(), id); err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,660 @@
+package v1
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+	"github.com/schedule-rsreu/schedule-api/pkg/conditional"
+)
+
+// Read-only CalDAV (RFC 4791) and WebDAV sync (RFC 6578) on top of the calendar feeds.
+// Collections are /caldav/{groups|teachers|auditoriums}/{id}/, events and holidays are {uid}.ics inside them.
+
+const (
+	davNamespace            = "DAV:"
+	caldavNamespace         = "urn:ietf:params:xml:ns:caldav"
+	calendarServerNamespace = "http://calendarserver.org/ns/"
+	appleICalNamespace      = "http://apple.com/ns/ical/"
+
+	methodPropfind = "PROPFIND"
+	methodReport   = "REPORT"
+
+	caldavPath            = "/caldav/"
+	caldavSyncTokenPrefix = "https://github.com/schedule-rsreu/schedule-api/sync/"
+	caldavMaxRequestBody  = 1 << 20
+	caldavContentType     = "text/calendar; charset=utf-8; component=vevent"
+	caldavTimeFormat      = "20060102T150405Z"
+
+	statusOK       = "HTTP/1.1 200 OK"
+	statusNotFound = "HTTP/1.1 404 Not Found"
+)
+
+// caldavStore is the part of the schedule service the CalDAV handlers use.
+type caldavStore interface {
+	GetRevision(ctx context.Context) (*models.CalendarRevision, error)
+	GetCalendar(ctx context.Context, owner models.CalendarOwner, id string) (*models.Calendar, error)
+	GetCalendarChanges(
+		ctx context.Context,
+		owner models.CalendarOwner,
+		id string,
+		since int64,
+	) (*models.CalendarChanges, error)
+}
+
+type caldavHandler struct {
+	store caldavStore
+}
+
+func registerCalDAV(g *echo.Group, store caldavStore) {
+	h := &caldavHandler{store: store}
+	caldavGroup := g.Group("/caldav")
+
+	for _, route := range []struct {
+		path    string
+		handler echo.HandlerFunc
+	}{
+		{"", h.caldavRoot},
+		{"/", h.caldavRoot},
+		{"/:owner", h.caldavOwner},
+		{"/:owner/", h.caldavOwner},
+		{"/:owner/:id", h.caldavCalendar},
+		{"/:owner/:id/", h.caldavCalendar},
+		{"/:owner/:id/:resource", h.caldavObject},
+	} {
+		for _, method := range []string{http.MethodOptions, http.MethodGet, http.MethodHead, methodPropfind, methodReport} {
+			caldavGroup.Add(method, route.path, route.handler)
+		}
+	}
+}
+
+type davName struct {
+	XMLName xml.Name
+}
+
+type davPropNames struct {
+	Names []davName `xml:",any"`
+}
+
+type davPropfindRequest struct {
+	AllProp *struct{}    `xml:"DAV: allprop"`
+	Prop    davPropNames `xml:"DAV: prop"`
+}
+
+type caldavTimeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type caldavCompFilter struct {
+	Name        string             `xml:"name,attr"`
+	TimeRange   *caldavTimeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	CompFilters []caldavCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type caldavFilter struct {
+	CompFilter caldavCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type davReportRequest struct {
+	XMLName   xml.Name
+	AllProp   *struct{}    `xml:"DAV: allprop"`
+	Prop      davPropNames `xml:"DAV: prop"`
+	Hrefs     []string     `xml:"DAV: href"`
+	SyncToken string       `xml:"DAV: sync-token"`
+	Filter    caldavFilter `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+type davProperty struct {
+	XMLName xml.Name
+	Inner   string `xml:",innerxml"`
+}
+
+type davProp struct {
+	Properties []davProperty
+}
+
+type davPropstat struct {
+	Prop   davProp `xml:"D:prop"`
+	Status string  `xml:"D:status"`
+}
+
+type davResponse struct {
+	Href      string        `xml:"D:href"`
+	Propstats []davPropstat `xml:"D:propstat,omitempty"`
+	Status    string        `xml:"D:status,omitempty"`
+}
+
+type davMultistatus struct {
+	XMLName        xml.Name      `xml:"D:multistatus"`
+	DAV            string        `xml:"xmlns:D,attr"`
+	CalDAV         string        `xml:"xmlns:C,attr"`
+	CalendarServer string        `xml:"xmlns:CS,attr"`
+	AppleICal      string        `xml:"xmlns:A,attr"`
+	Responses      []davResponse `xml:"D:response"`
+	SyncToken      string        `xml:"D:sync-token,omitempty"`
+}
+
+// davResource is a collection or a calendar object with all properties it has, as inner XML.
+type davResource struct {
+	href       string
+	properties map[xml.Name]string
+	// defaults are returned for allprop, expensive properties like calendar-data are not among them.
+	defaults []xml.Name
+}
+
+type caldavObjectResource struct {
+	object *services.CalendarObject
+	etag   string
+}
+
+func (h *caldavHandler) caldavRoot(c echo.Context) error {
+	if handled, err := caldavPreflight(c, methodPropfind); handled {
+		return err
+	}
+
+	root := caldavRootHref(c)
+	resources := []davResource{caldavHomeResource(root, root, "Расписание РГРТУ")}
+	if c.Request().Header.Get("Depth") != "0" {
+		for _, owner := range []string{"groups", "teachers", "auditoriums"} {
+			resources = append(resources, caldavHomeResource(root, root+owner+"/", owner))
+		}
+	}
+	return propfindResponse(c, resources)
+}
+
+func (h *caldavHandler) caldavOwner(c echo.Context) error {
+	if handled, err := caldavPreflight(c, methodPropfind); handled {
+		return err
+	}
+	if _, ok := caldavOwners()[c.Param("owner")]; !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "calendar owner not found")
+	}
+
+	// Коллекции всех групп и преподавателей слишком велики для перечисления,
+	// клиенты подключают календарь по его адресу.
+	root := caldavRootHref(c)
+	return propfindResponse(c, []davResource{
+		caldavHomeResource(root, root+url.PathEscape(c.Param("owner"))+"/", c.Param("owner")),
+	})
+}
+
+func (h *caldavHandler) caldavCalendar(c echo.Context) error {
+	if handled, err := caldavPreflight(c, http.MethodGet, http.MethodHead, methodPropfind, methodReport); handled {
+		return err
+	}
+
+	// Ревизия читается до календаря: изменения между ними клиент получит ещё раз со следующим токеном.
+	revision, err := h.store.GetRevision(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	if c.Request().Method == http.MethodGet || c.Request().Method == http.MethodHead {
+		c.Response().Header().Set(echo.HeaderCacheControl, calendarCacheControl)
+		if revisionNotModified(c, revision, false) {
+			return notModifiedResponse(c)
+		}
+		calendar, err := h.caldavLoadCalendar(c)
+		if err != nil {
+			return err
+		}
+		calendar.Source = calendarSource(c)
+		return writeCalendar(c, "schedule.ics", services.GenerateCalendar(calendar, services.DefaultCalendarOptions()))
+	}
+
+	calendar, err := h.caldavLoadCalendar(c)
+	if err != nil {
+		return err
+	}
+
+	collectionHref := caldavCollectionHref(c)
+	objects := services.GenerateCalendarObjects(calendar, services.DefaultCalendarOptions())
+	resources := caldavObjectResources(objects)
+	collection := caldavCollectionResource(c, calendar, collectionHref, revision.Revision)
+
+	if c.Request().Method == methodPropfind {
+		davResources := []davResource{collection}
+		if c.Request().Header.Get("Depth") != "0" {
+			for index := range resources {
+				davResources = append(davResources, caldavObjectDAVResource(collectionHref, &resources[index]))
+			}
+		}
+		return propfindResponse(c, davResources)
+	}
+
+	var req davReportRequest
+	if err = readDAVRequest(c, &req); err != nil {
+		return err
+	}
+
+	multistatus := newDAVMultistatus()
+	switch req.XMLName {
+	case xml.Name{Space: caldavNamespace, Local: "calendar-query"}:
+		start, end, err := caldavQueryRange(req.Filter.CompFilter)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		for _, object := range services.CalendarObjectsInRange(objects, start, end) {
+			resource := caldavObjectDAVResource(collectionHref, findCaldavObject(resources, object.UID))
+			multistatus.Responses = append(multistatus.Responses, resource.response(req.Prop.names(), req.AllProp != nil))
+		}
+	case xml.Name{Space: caldavNamespace, Local: "calendar-multiget"}:
+		for _, href := range req.Hrefs {
+			object := findCaldavObject(resources, caldavResourceUID(href))
+			if object == nil {
+				multistatus.Responses = append(multistatus.Responses, davResponse{Href: href, Status: statusNotFound})
+				continue
+			}
+			resource := caldavObjectDAVResource(collectionHref, object)
+			multistatus.Responses = append(multistatus.Responses, resource.response(req.Prop.names(), req.AllProp != nil))
+		}
+	case xml.Name{Space: davNamespace, Local: "sync-collection"}:
+		return h.syncCollection(c, &req, collectionHref, revision.Revision, resources)
+	default:
+		return davError(c, http.StatusForbidden, "supported-report")
+	}
+	return writeMultistatus(c, multistatus)
+}
+
+// syncCollection returns the resources changed after the revision in the client token: present ones with their
+// properties, removed ones as 404. An empty token requests all resources.
+func (h *caldavHandler) syncCollection(
+	c echo.Context,
+	req *davReportRequest,
+	collectionHref string,
+	revision int64,
+	resources []caldavObjectResource,
+) error {
+	multistatus := newDAVMultistatus()
+	multistatus.SyncToken = caldavSyncToken(revision)
+	token := strings.TrimSpace(req.SyncToken)
+	if token == "" {
+		for index := range resources {
+			resource := caldavObjectDAVResource(collectionHref, &resources[index])
+			multistatus.Responses = append(multistatus.Responses, resource.response(req.Prop.names(), req.AllProp != nil))
+		}
+		return writeMultistatus(c, multistatus)
+	}
+
+	since, ok := parseCaldavSyncToken(token)
+	if !ok || since > revision {
+		return davError(c, http.StatusForbidden, "valid-sync-token")
+	}
+	if since == revision {
+		return writeMultistatus(c, multistatus)
+	}
+
+	owner := caldavOwners()[c.Param("owner")]
+	changes, err := h.store.GetCalendarChanges(c.Request().Context(), owner, c.Param("id"), since)
+	if err != nil {
+		return err
+	}
+	if changes.Resync {
+		return davError(c, http.StatusForbidden, "valid-sync-token")
+	}
+	for _, uid := range changes.UIDs {
+		object := findCaldavObject(resources, uid)
+		if object == nil {
+			multistatus.Responses = append(multistatus.Responses,
+				davResponse{Href: caldavObjectHref(collectionHref, uid), Status: statusNotFound})
+			continue
+		}
+		resource := caldavObjectDAVResource(collectionHref, object)
+		multistatus.Responses = append(multistatus.Responses, resource.response(req.Prop.names(), req.AllProp != nil))
+	}
+	return writeMultistatus(c, multistatus)
+}
+
+func (h *caldavHandler) caldavObject(c echo.Context) error {
+	if handled, err := caldavPreflight(c, http.MethodGet, http.MethodHead, methodPropfind); handled {
+		return err
+	}
+
+	calendar, err := h.caldavLoadCalendar(c)
+	if err != nil {
+		return err
+	}
+	resources := caldavObjectResources(services.GenerateCalendarObjects(calendar, services.DefaultCalendarOptions()))
+	resource := findCaldavObject(resources, caldavResourceUID(c.Param("resource")))
+	if resource == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "calendar event not found")
+	}
+
+	if c.Request().Method == methodPropfind {
+		return propfindResponse(c, []davResource{caldavObjectDAVResource(caldavCollectionHref(c), resource)})
+	}
+
+	conditional.SetValidators(c.Response().Header(), resource.etag, time.Time{})
+	if conditional.NotModified(c.Request(), resource.etag, time.Time{}) {
+		return notModifiedResponse(c)
+	}
+	return c.Blob(http.StatusOK, caldavContentType, resource.object.Data)
+}
+
+func (h *caldavHandler) caldavLoadCalendar(c echo.Context) (*models.Calendar, error) {
+	owner, ok := caldavOwners()[c.Param("owner")]
+	if !ok {
+		return nil, echo.NewHTTPError(http.StatusNotFound, "calendar owner not found")
+	}
+	calendar, err := h.store.GetCalendar(c.Request().Context(), owner, c.Param("id"))
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return nil, echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func caldavOwners() map[string]models.CalendarOwner {
+	return map[string]models.CalendarOwner{
+		"groups":      models.CalendarOwnerGroup,
+		"teachers":    models.CalendarOwnerTeacher,
+		"auditoriums": models.CalendarOwnerAuditorium,
+	}
+}
+
+// caldavPreflight answers OPTIONS and rejects methods the resource does not support.
+func caldavPreflight(c echo.Context, methods ...string) (bool, error) {
+	allowed := append([]string{http.MethodOptions}, methods...)
+	header := c.Response().Header()
+	header.Set("DAV", "1, 3, calendar-access")
+	header.Set(echo.HeaderAllow, strings.Join(allowed, ", "))
+
+	method := c.Request().Method
+	if method == http.MethodOptions {
+		return true, c.NoContent(http.StatusOK)
+	}
+	for _, allowedMethod := range allowed {
+		if method == allowedMethod {
+			return false, nil
+		}
+	}
+	return true, echo.NewHTTPError(http.StatusMethodNotAllowed)
+}
+
+func caldavRootHref(c echo.Context) string {
+	escapedPath := c.Request().URL.EscapedPath()
+	if index := strings.Index(escapedPath, caldavPath); index >= 0 {
+		return escapedPath[:index+len(caldavPath)]
+	}
+	return strings.TrimSuffix(escapedPath, "/") + "/"
+}
+
+func caldavCollectionHref(c echo.Context) string {
+	return caldavRootHref(c) + url.PathEscape(c.Param("owner")) + "/" + url.PathEscape(c.Param("id")) + "/"
+}
+
+func caldavObjectHref(collectionHref, uid string) string {
+	return collectionHref + url.PathEscape(uid) + ".ics"
+}
+
+func caldavResourceUID(href string) string {
+	if parsed, err := url.Parse(href); err == nil {
+		href = parsed.EscapedPath()
+	}
+	name := path.Base(href)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.TrimSuffix(name, ".ics")
+}
+
+func caldavHomeResource(root, href, displayName string) davResource {
+	resource := davResource{
+		href: href,
+		properties: map[xml.Name]string{
+			{Space: davNamespace, Local: "resourcetype"}:               "<D:collection/>",
+			{Space: davNamespace, Local: "displayname"}:                escapeXML(displayName),
+			{Space: davNamespace, Local: "current-user-principal"}:     "<D:href>" + escapeXML(root) + "</D:href>",
+			{Space: davNamespace, Local: "current-user-privilege-set"}: "<D:privilege><D:read/></D:privilege>",
+			{Space: caldavNamespace, Local: "calendar-home-set"}:       "<D:href>" + escapeXML(root) + "</D:href>",
+		},
+	}
+	resource.defaults = resource.names()
+	return resource
+}
+
+func caldavCollectionResource(
+	c echo.Context,
+	calendar *models.Calendar,
+	href string,
+	revision int64,
+) davResource {
+	options := services.DefaultCalendarOptions()
+	root := caldavRootHref(c)
+	resource := davResource{
+		href: href,
+		properties: map[xml.Name]string{
+			{Space: davNamespace, Local: "resourcetype"}:                        "<D:collection/><C:calendar/>",
+			{Space: davNamespace, Local: "displayname"}:                         escapeXML(services.CalendarDisplayName(calendar, options.Lang)),
+			{Space: davNamespace, Local: "current-user-principal"}:              "<D:href>" + escapeXML(root) + "</D:href>",
+			{Space: davNamespace, Local: "current-user-privilege-set"}:          "<D:privilege><D:read/></D:privilege>",
+			{Space: davNamespace, Local: "sync-token"}:                          escapeXML(caldavSyncToken(revision)),
+			{Space: calendarServerNamespace, Local: "getctag"}:                  strconv.FormatInt(revision, 10),
+			{Space: caldavNamespace, Local: "supported-calendar-component-set"}: `<C:comp name="VEVENT"/>`,
+			{Space: appleICalNamespace, Local: "calendar-color"}:                escapeXML(options.Color),
+			{Space: davNamespace, Local: "supported-report-set"}: "" +
+				"<D:supported-report><D:report><C:calendar-query/></D:report></D:supported-report>" +
+				"<D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>" +
+				"<D:supported-report><D:report><D:sync-collection/></D:report></D:supported-report>",
+		},
+	}
+	resource.defaults = resource.names()
+	return resource
+}
+
+// caldavObjectResources tags the objects with etags of their content. DTSTAMP and SEQUENCE follow the schedule
+// revision, so they are left out: an import keeps the etags of events it did not change.
+func caldavObjectResources(objects []services.CalendarObject) []caldavObjectResource {
+	const etagBytes = 12
+	resources := make([]caldavObjectResource, 0, len(objects))
+	for index := range objects {
+		hash := sha256.New()
+		for _, line := range strings.SplitAfter(string(objects[index].Data), "\r\n") {
+			if strings.HasPrefix(line, "DTSTAMP:") || strings.HasPrefix(line, "SEQUENCE:") {
+				continue
+			}
+			_, _ = io.WriteString(hash, line)
+		}
+		resources = append(resources, caldavObjectResource{
+			object: &objects[index],
+			etag:   conditional.StrongETag(hex.EncodeToString(hash.Sum(nil)[:etagBytes])),
+		})
+	}
+	return resources
+}
+
+// caldavSyncToken is the schedule revision, changes after it are found in the lesson change log.
+func caldavSyncToken(revision int64) string {
+	return caldavSyncTokenPrefix + strconv.FormatInt(revision, 10)
+}
+
+func parseCaldavSyncToken(token string) (int64, bool) {
+	value, ok := strings.CutPrefix(token, caldavSyncTokenPrefix)
+	if !ok {
+		return 0, false
+	}
+	revision, err := strconv.ParseInt(value, 10, 64)
+	return revision, err == nil && revision >= 0
+}
+
+func findCaldavObject(resources []caldavObjectResource, uid string) *caldavObjectResource {
+	for index := range resources {
+		if resources[index].object.UID == uid {
+			return &resources[index]
+		}
+	}
+	return nil
+}
+
+func caldavObjectDAVResource(collectionHref string, resource *caldavObjectResource) davResource {
+	return davResource{
+		href: caldavObjectHref(collectionHref, resource.object.UID),
+		properties: map[xml.Name]string{
+			{Space: davNamespace, Local: "resourcetype"}:     "",
+			{Space: davNamespace, Local: "getetag"}:          escapeXML(resource.etag),
+			{Space: davNamespace, Local: "getcontenttype"}:   caldavContentType,
+			{Space: davNamespace, Local: "getcontentlength"}: strconv.Itoa(len(resource.object.Data)),
+			{Space: caldavNamespace, Local: "calendar-data"}: escapeXML(string(resource.object.Data)),
+		},
+		defaults: []xml.Name{
+			{Space: davNamespace, Local: "resourcetype"},
+			{Space: davNamespace, Local: "getetag"},
+			{Space: davNamespace, Local: "getcontenttype"},
+			{Space: davNamespace, Local: "getcontentlength"},
+		},
+	}
+}
+
+func (r *davResource) names() []xml.Name {
+	names := make([]xml.Name, 0, len(r.properties))
+	for name := range r.properties {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i].Space+" "+names[i].Local < names[j].Space+" "+names[j].Local
+	})
+	return names
+}
+
+// response splits requested properties into found and missing ones, allprop returns the defaults.
+func (r *davResource) response(requested []xml.Name, allProp bool) davResponse {
+	if allProp || len(requested) == 0 {
+		requested = append(append([]xml.Name(nil), r.defaults...), requested...)
+	}
+
+	var found, missing []davProperty
+	for _, name := range requested {
+		if value, ok := r.properties[name]; ok {
+			found = append(found, davProperty{XMLName: davElementName(name), Inner: value})
+		} else {
+			missing = append(missing, davProperty{XMLName: davElementName(name)})
+		}
+	}
+
+	response := davResponse{Href: r.href}
+	if len(found) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{Prop: davProp{found}, Status: statusOK})
+	}
+	if len(missing) > 0 {
+		response.Propstats = append(response.Propstats, davPropstat{Prop: davProp{missing}, Status: statusNotFound})
+	}
+	return response
+}
+
+func (p davPropNames) names() []xml.Name {
+	names := make([]xml.Name, 0, len(p.Names))
+	for _, name := range p.Names {
+		names = append(names, name.XMLName)
+	}
+	return names
+}
+
+// davElementName uses the prefixes declared on multistatus, other namespaces are declared in place.
+func davElementName(name xml.Name) xml.Name {
+	prefixes := map[string]string{
+		davNamespace:            "D",
+		caldavNamespace:         "C",
+		calendarServerNamespace: "CS",
+		appleICalNamespace:      "A",
+	}
+	if prefix, ok := prefixes[name.Space]; ok {
+		return xml.Name{Local: prefix + ":" + name.Local}
+	}
+	return name
+}
+
+func caldavQueryRange(filter caldavCompFilter) (start, end time.Time, err error) {
+	for _, eventFilter := range filter.CompFilters {
+		if eventFilter.Name != "VEVENT" || eventFilter.TimeRange == nil {
+			continue
+		}
+		if eventFilter.TimeRange.Start != "" {
+			if start, err = time.Parse(caldavTimeFormat, eventFilter.TimeRange.Start); err != nil {
+				return start, end, errors.New("time-range start must be in UTC date-time format")
+			}
+		}
+		if eventFilter.TimeRange.End != "" {
+			if end, err = time.Parse(caldavTimeFormat, eventFilter.TimeRange.End); err != nil {
+				return start, end, errors.New("time-range end must be in UTC date-time format")
+			}
+		}
+	}
+	return start, end, nil
+}
+
+func readDAVRequest(c echo.Context, req any) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, caldavMaxRequestBody))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return nil
+	}
+	if err = xml.Unmarshal(body, req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid xml body: "+err.Error())
+	}
+	return nil
+}
+
+func propfindResponse(c echo.Context, resources []davResource) error {
+	if c.Request().Method != methodPropfind {
+		return echo.NewHTTPError(http.StatusMethodNotAllowed)
+	}
+
+	var req davPropfindRequest
+	if err := readDAVRequest(c, &req); err != nil {
+		return err
+	}
+
+	multistatus := newDAVMultistatus()
+	for index := range resources {
+		multistatus.Responses = append(multistatus.Responses, resources[index].response(req.Prop.names(), req.AllProp != nil))
+	}
+	return writeMultistatus(c, multistatus)
+}
+
+func newDAVMultistatus() *davMultistatus {
+	return &davMultistatus{
+		DAV:            davNamespace,
+		CalDAV:         caldavNamespace,
+		CalendarServer: calendarServerNamespace,
+		AppleICal:      appleICalNamespace,
+	}
+}
+
+func writeMultistatus(c echo.Context, multistatus *davMultistatus) error {
+	body, err := xml.Marshal(multistatus)
+	if err != nil {
+		return err
+	}
+	return c.Blob(http.StatusMultiStatus, echo.MIMEApplicationXMLCharsetUTF8, append([]byte(xml.Header), body...))
+}
+
+// davError reports a failed precondition as the DAV:error body.
+func davError(c echo.Context, status int, precondition string) error {
+	body := xml.Header + `<D:error xmlns:D="DAV:"><D:` + precondition + `/></D:error>`
+	return c.Blob(status, echo.MIMEApplicationXMLCharsetUTF8, []byte(body))
+}
+
+func escapeXML(value string) string {
+	var result strings.Builder
+	_ = xml.EscapeText(&result, []byte(value))
+	return result.String()
+}
@@ -0,0 +1,244 @@
+package v1
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+const testCollection = "/api/v1/caldav/groups/344/"
+
+type fakeCalDAVStore struct {
+	calendar *models.Calendar
+	revision int64
+	// changes are returned for any revision older than the current one.
+	changes *models.CalendarChanges
+}
+
+func (s *fakeCalDAVStore) GetRevision(context.Context) (*models.CalendarRevision, error) {
+	return &models.CalendarRevision{Revision: s.revision, UpdatedAt: s.calendar.UpdatedAt}, nil
+}
+
+func (s *fakeCalDAVStore) GetCalendar(_ context.Context, owner models.CalendarOwner, id string) (*models.Calendar, error) {
+	if owner != models.CalendarOwnerGroup || id != "344" {
+		return nil, services.NotFoundError{}
+	}
+	calendar := *s.calendar
+	return &calendar, nil
+}
+
+func (s *fakeCalDAVStore) GetCalendarChanges(
+	_ context.Context,
+	owner models.CalendarOwner,
+	id string,
+	_ int64,
+) (*models.CalendarChanges, error) {
+	if owner != models.CalendarOwnerGroup || id != "344" {
+		return nil, services.NotFoundError{}
+	}
+	return s.changes, nil
+}
+
+type testMultistatus struct {
+	Responses []struct {
+		Href   string `xml:"href"`
+		Status string `xml:"status"`
+		Prop   struct {
+			ETag         string `xml:"getetag"`
+			SyncToken    string `xml:"sync-token"`
+			CalendarData string `xml:"calendar-data"`
+		} `xml:"propstat>prop"`
+	} `xml:"response"`
+	SyncToken string `xml:"sync-token"`
+}
+
+func (m *testMultistatus) statuses() map[string]string {
+	statuses := make(map[string]string, len(m.Responses))
+	for _, response := range m.Responses {
+		statuses[response.Href] = response.Status
+	}
+	return statuses
+}
+
+func newCalDAVTestServer() (*echo.Echo, *fakeCalDAVStore) {
+	store := &fakeCalDAVStore{
+		calendar: &models.Calendar{
+			Owner:     models.CalendarOwnerGroup,
+			Name:      "344",
+			UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
+			Events: []models.CalendarEvent{
+				{
+					UID:        "first@rsreu-schedule.ru",
+					StartTime:  time.Date(2026, 9, 1, 8, 10, 0, 0, time.UTC),
+					EndTime:    time.Date(2026, 9, 1, 9, 45, 0, 0, time.UTC),
+					Title:      "Физика",
+					LessonType: "lecture",
+				},
+				{
+					UID:        "second@rsreu-schedule.ru",
+					StartTime:  time.Date(2026, 9, 2, 8, 10, 0, 0, time.UTC),
+					EndTime:    time.Date(2026, 9, 2, 9, 45, 0, 0, time.UTC),
+					Title:      "Химия",
+					LessonType: "lecture",
+				},
+			},
+			Holidays: []models.Holiday{{Date: "2026-11-04", Title: "День народного единства", Kind: models.HolidayKindHoliday}},
+		},
+		revision: 1,
+		changes:  &models.CalendarChanges{},
+	}
+	e := echo.New()
+	registerCalDAV(e.Group("/api/v1"), store)
+	return e, store
+}
+
+func davRequest(t *testing.T, e *echo.Echo, method, target, depth, body string) (int, *testMultistatus) {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationXMLCharsetUTF8)
+	if depth != "" {
+		req.Header.Set("Depth", depth)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var multistatus testMultistatus
+	if rec.Code == http.StatusMultiStatus {
+		if err := xml.Unmarshal(rec.Body.Bytes(), &multistatus); err != nil {
+			t.Fatalf("invalid multistatus: %v\n%s", err, rec.Body)
+		}
+	}
+	return rec.Code, &multistatus
+}
+
+func syncCollectionBody(token string) string {
+	return `<?xml version="1.0" encoding="utf-8"?>
+<D:sync-collection xmlns:D="DAV:">
+  <D:sync-token>` + token + `</D:sync-token>
+  <D:sync-level>1</D:sync-level>
+  <D:prop><D:getetag/></D:prop>
+</D:sync-collection>`
+}
+
+func TestCalDAVPropfind(t *testing.T) {
+	e, _ := newCalDAVTestServer()
+	const body = `<D:propfind xmlns:D="DAV:"><D:prop><D:getetag/><D:sync-token/></D:prop></D:propfind>`
+
+	code, multistatus := davRequest(t, e, methodPropfind, testCollection, "0", body)
+	if code != http.StatusMultiStatus || len(multistatus.Responses) != 1 {
+		t.Fatalf("expected only the collection for depth 0, got %v %+v", code, multistatus.Responses)
+	}
+	if token := multistatus.Responses[0].Prop.SyncToken; token != caldavSyncTokenPrefix+"1" {
+		t.Fatalf("expected the revision as the sync token, got %q", token)
+	}
+
+	code, multistatus = davRequest(t, e, methodPropfind, testCollection, "1", body)
+	statuses := multistatus.statuses()
+	if code != http.StatusMultiStatus || len(statuses) != 4 {
+		t.Fatalf("expected the collection, two events and a holiday for depth 1, got %v %v", code, statuses)
+	}
+	for _, href := range []string{
+		testCollection,
+		testCollection + "first@rsreu-schedule.ru.ics",
+		testCollection + "second@rsreu-schedule.ru.ics",
+		testCollection + "holiday-20261104@rsreu-schedule.ru.ics",
+	} {
+		if _, ok := statuses[href]; !ok {
+			t.Errorf("expected %v among %v", href, statuses)
+		}
+	}
+}
+
+func TestCalDAVMultiget(t *testing.T) {
+	e, _ := newCalDAVTestServer()
+	const body = `<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-multiget xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop><D:getetag/><C:calendar-data/></D:prop>
+  <D:href>` + testCollection + `second@rsreu-schedule.ru.ics</D:href>
+  <D:href>` + testCollection + `holiday-20261104@rsreu-schedule.ru.ics</D:href>
+  <D:href>` + testCollection + `missing@rsreu-schedule.ru.ics</D:href>
+</C:calendar-multiget>`
+
+	code, multistatus := davRequest(t, e, methodReport, testCollection, "", body)
+	if code != http.StatusMultiStatus || len(multistatus.Responses) != 3 {
+		t.Fatalf("expected three responses, got %v %+v", code, multistatus.Responses)
+	}
+	event, holiday, missing := multistatus.Responses[0], multistatus.Responses[1], multistatus.Responses[2]
+	if event.Prop.ETag == "" || !strings.Contains(event.Prop.CalendarData, "UID:second@rsreu-schedule.ru") {
+		t.Errorf("expected the second event with its data, got %+v", event)
+	}
+	if !strings.Contains(holiday.Prop.CalendarData, "DTSTART;VALUE=DATE:20261104") {
+		t.Errorf("expected the holiday with its data, got %+v", holiday)
+	}
+	if missing.Status != statusNotFound {
+		t.Errorf("expected 404 for the missing event, got %+v", missing)
+	}
+}
+
+func TestCalDAVSyncCollection(t *testing.T) {
+	e, store := newCalDAVTestServer()
+
+	code, initial := davRequest(t, e, methodReport, testCollection, "", syncCollectionBody(""))
+	if code != http.StatusMultiStatus || len(initial.Responses) != 3 || initial.SyncToken == "" {
+		t.Fatalf("expected all resources and a token on the initial sync, got %v %+v", code, initial)
+	}
+
+	code, unchanged := davRequest(t, e, methodReport, testCollection, "", syncCollectionBody(initial.SyncToken))
+	if code != http.StatusMultiStatus || len(unchanged.Responses) != 0 || unchanged.SyncToken != initial.SyncToken {
+		t.Fatalf("expected no changes for the current token, got %v %+v", code, unchanged)
+	}
+	initialETags := make(map[string]string, len(initial.Responses))
+	for index := range initial.Responses {
+		initialETags[initial.Responses[index].Href] = initial.Responses[index].Prop.ETag
+	}
+
+	// Импорт удалил первое занятие и не менял второе, но увеличил ревизию.
+	calendar := *store.calendar
+	calendar.Events = []models.CalendarEvent{calendar.Events[1]}
+	calendar.Events[0].Sequence = 1
+	calendar.UpdatedAt = calendar.UpdatedAt.Add(time.Hour)
+	store.calendar = &calendar
+	store.revision = 2
+	store.changes = &models.CalendarChanges{UIDs: []string{"first@rsreu-schedule.ru"}}
+
+	code, changed := davRequest(t, e, methodReport, testCollection, "", syncCollectionBody(initial.SyncToken))
+	statuses := changed.statuses()
+	if code != http.StatusMultiStatus || len(statuses) != 1 || changed.SyncToken != caldavSyncTokenPrefix+"2" {
+		t.Fatalf("expected only the removed event with a new token, got %v %+v", code, changed)
+	}
+	if status := statuses[testCollection+"first@rsreu-schedule.ru.ics"]; status != statusNotFound {
+		t.Errorf("expected 404 for the removed event, got %q", status)
+	}
+
+	code, current := davRequest(t, e, methodReport, testCollection, "", syncCollectionBody(""))
+	if code != http.StatusMultiStatus || len(current.Responses) != 2 {
+		t.Fatalf("expected the remaining resources, got %v %+v", code, current)
+	}
+	for index := range current.Responses {
+		if current.Responses[index].Href == testCollection+"second@rsreu-schedule.ru.ics" &&
+			current.Responses[index].Prop.ETag != initialETags[current.Responses[index].Href] {
+			t.Error("expected the unchanged event to keep its etag across revisions")
+		}
+	}
+
+	store.changes = &models.CalendarChanges{Resync: true}
+	for _, token := range []string{
+		initial.SyncToken,
+		caldavSyncTokenPrefix + "3",
+		caldavSyncTokenPrefix + "unknown",
+		"http://example.com/sync/1",
+	} {
+		if code, _ = davRequest(t, e, methodReport, testCollection, "", syncCollectionBody(token)); code != http.StatusForbidden {
+			t.Errorf("expected 403 for the token %q, got %v", token, code)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+type calendarFeedRule struct {
+	Title      string `json:"title"       validate:"max=200"                                                                                                 example:"Физическая культура"` //nolint:lll // there is no way to fix it
+	LessonType string `json:"lesson_type" validate:"omitempty,oneof=lecture lab practice coursework course_project exam zachet consultation elective unknown" example:"lab"`                //nolint:lll // there is no way to fix it
+	Teacher    string `json:"teacher"     validate:"max=200"                                                                                                 example:"Конюхов"`             //nolint:lll // there is no way to fix it
+	Auditorium string `json:"auditorium"  validate:"max=50"                                                                                                  example:"106а C"`              //nolint:lll // there is no way to fix it
+}
+
+type calendarFeedRequest struct {
+	Group   string             `json:"group"   validate:"required,max=20" example:"344"`
+	Include []calendarFeedRule `json:"include" validate:"max=50,dive"`
+	Exclude []calendarFeedRule `json:"exclude" validate:"max=50,dive"`
+}
+
+func (r *calendarFeedRequest) toModel() (*models.CalendarFeed, error) {
+	include, err := calendarFeedRules(r.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := calendarFeedRules(r.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	return &models.CalendarFeed{Group: r.Group, Include: include, Exclude: exclude}, nil
+}
+
+func calendarFeedRules(rules []calendarFeedRule) ([]models.CalendarFeedRule, error) {
+	result := make([]models.CalendarFeedRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Title == "" && rule.LessonType == "" && rule.Teacher == "" && rule.Auditorium == "" {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "calendar feed rule must have at least one field")
+		}
+		result = append(result, models.CalendarFeedRule(rule))
+	}
+	return result, nil
+}
+
+func (sh *ScheduleHandler) bindCalendarFeed(c echo.Context) (*models.CalendarFeed, error) {
+	var req calendarFeedRequest
+	if err := c.Bind(&req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return req.toModel()
+}
+
+// createCalendarFeed
+// @Summary     Create a custom calendar feed
+// @Description Сохраняет фильтр календаря группы и возвращает секретный token. Календарь доступен по /api/v1/calendar/feeds/{token}.ics
+// @Description Ленты создают только клиенты API, иначе анонимные запросы заполнили бы базу.
+// @Tags        Calendar
+// @Security    BearerAuth
+// @Router      /api/v1/calendar/feeds [post]
+// @Param       feed  body  calendarFeedRequest  true  "feed"
+// @Success     201  {object}  models.CalendarFeed
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) createCalendarFeed(c echo.Context) error {
+	feed, err := sh.bindCalendarFeed(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sh.s.CreateCalendarFeed(c.Request().Context(), feed)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// getCalendarFeed
+// @Summary     Get a custom calendar feed
+// @Description Возвращает фильтр по token, а при суффиксе .ics — отфильтрованный iCalendar группы
+// @Tags        Calendar
+// @Router      /api/v1/calendar/feeds/{token} [get]
+// @Param       token  path  string  true  "token, with .ics suffix for the calendar"
+// @Param       alarm  query  int  false  "reminder in minutes before the lesson, 0 disables reminders" default(30)
+// @Param       emoji  query  bool  false  "emoji in event titles" default(true)
+// @Param       lang  query  string  false  "calendar language" Enums(ru, en) default(ru)
+// @Param       color  query  string  false  "calendar color" example(#5288c1)
+// @Produce     json,text/calendar
+// @Success     200  {object}  models.CalendarFeed
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getCalendarFeed(c echo.Context) error {
+	token, isCalendar := strings.CutSuffix(c.Param("token"), ".ics")
+	ctx := c.Request().Context()
+
+	feed, err := sh.s.GetCalendarFeed(ctx, token)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+
+	if !isCalendar {
+		return c.JSON(http.StatusOK, feed)
+	}
+
+	options, err := calendarOptions(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.calendarNotModified(c, feed.UpdatedAt) {
+		return notModifiedResponse(c)
+	}
+
+	calendar, err := sh.s.GetCalendarFeedCalendar(ctx, feed, calendarSource(c), options)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return writeCalendar(c, "schedule-"+safeFilename(feed.Group)+".ics", calendar)
+}
+
+// updateCalendarFeed
+// @Summary     Update a custom calendar feed
+// @Description Заменяет фильтр. Адрес подписки и UID событий не меняются
+// @Tags        Calendar
+// @Router      /api/v1/calendar/feeds/{token} [put]
+// @Param       token  path  string  true  "token"
+// @Param       feed  body  calendarFeedRequest  true  "feed"
+// @Success     200  {object}  models.CalendarFeed
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) updateCalendarFeed(c echo.Context) error {
+	feed, err := sh.bindCalendarFeed(c)
+	if err != nil {
+		return err
+	}
+
+	// Адрес подписки с .ics копируют целиком, поэтому он принимается и здесь.
+	token, _ := strings.CutSuffix(c.Param("token"), ".ics")
+	resp, err := sh.s.UpdateCalendarFeed(c.Request().Context(), token, feed)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// deleteCalendarFeed
+// @Summary     Delete a custom calendar feed
+// @Tags        Calendar
+// @Router      /api/v1/calendar/feeds/{token} [delete]
+// @Param       token  path  string  true  "token"
+// @Success     204
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) deleteCalendarFeed(c echo.Context) error {
+	token, _ := strings.CutSuffix(c.Param("token"), ".ics")
+	err := sh.s.DeleteCalendarFeed(c.Request().Context(), token)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+func TestCreateCalendarFeedRequiresClient(t *testing.T) {
+	tests := []struct {
+		name     string
+		client   *models.APIClient
+		expected int
+	}{
+		{"anonymous", nil, http.StatusUnauthorized},
+		{"without read scope", &models.APIClient{Name: "bot", Scopes: []models.APIClientScope{models.APIClientScopeWebhooks}},
+			http.StatusForbidden},
+		// Клиент проходит проверку и получает ошибку разбора тела, до сервиса запрос не доходит.
+		{"client", &models.APIClient{Name: "bot", Scopes: []models.APIClientScope{models.APIClientScopeRead}},
+			http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					if test.client != nil {
+						c.Set(auth.ClientCtxKey, test.client)
+					}
+					return next(c)
+				}
+			})
+			NewRouter(e.Group("/api/v1"), nil, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/calendar/feeds", strings.NewReader("{"))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			if rec.Code != test.expected {
+				t.Errorf("expected %v, got %v: %s", test.expected, rec.Code, rec.Body)
+			}
+		})
+	}
+}
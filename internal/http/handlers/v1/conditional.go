@@ -0,0 +1,86 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+	"github.com/schedule-rsreu/schedule-api/pkg/conditional"
+	"github.com/schedule-rsreu/schedule-api/pkg/logger"
+)
+
+const calendarCacheControl = "public, max-age=300"
+
+// notModified sets ETag and Last-Modified derived from the schedule revision and reports
+// whether the client copy is still fresh, so the schedule query can be skipped.
+// dependsOnToday marks responses built for the current date: their validators also change daily.
+// versions are modification times of other data the response depends on.
+func (sh *ScheduleHandler) notModified(c echo.Context, dependsOnToday bool, versions ...time.Time) bool {
+	revision, err := sh.s.GetRevision(c.Request().Context())
+	if err != nil {
+		logger.GetLoggerFromCtx(c).Err(err).Msg("failed to get schedule revision")
+		return false
+	}
+	return revisionNotModified(c, revision, dependsOnToday, versions...)
+}
+
+// revisionNotModified is notModified for a revision that is already loaded.
+func revisionNotModified(
+	c echo.Context,
+	revision *models.CalendarRevision,
+	dependsOnToday bool,
+	versions ...time.Time,
+) bool {
+	lastModified := revision.UpdatedAt
+	key := strconv.FormatInt(revision.Revision, 10) + "|" + c.Request().URL.Path + "?" + c.QueryParams().Encode()
+	if dependsOnToday {
+		now := utils.GetNowWithZone()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		versions = append(versions, today)
+	}
+	for _, version := range versions {
+		key += "|" + version.UTC().Format(time.RFC3339Nano)
+		if version.After(lastModified) {
+			lastModified = version
+		}
+	}
+
+	const etagBytes = 12
+	sum := sha256.Sum256([]byte(key))
+	etag := conditional.WeakETag(hex.EncodeToString(sum[:etagBytes]))
+
+	conditional.SetValidators(c.Response().Header(), etag, lastModified)
+	// Валидаторы выставляются до запроса расписания, ошибки вроде 404 с подсказками их не несут.
+	c.Response().Before(func() {
+		if c.Response().Status >= http.StatusBadRequest {
+			conditional.ClearValidators(c.Response().Header())
+		}
+	})
+	// If-None-Match: * совпадает с любой версией существующего ресурса, на него отвечает foundNotModified.
+	if conditional.MatchesAny(c.Request()) {
+		return false
+	}
+	return conditional.NotModified(c.Request(), etag, lastModified)
+}
+
+// foundNotModified reports If-None-Match: * for a found resource whose validators revisionNotModified set,
+// it leaves the wildcard to the handler: a missing group must get 404 rather than 304.
+func foundNotModified(c echo.Context) bool {
+	return c.Response().Header().Get("ETag") != "" && conditional.MatchesAny(c.Request())
+}
+
+// calendarNotModified is notModified for calendar feeds, which are cached by clients and proxies.
+func (sh *ScheduleHandler) calendarNotModified(c echo.Context, versions ...time.Time) bool {
+	c.Response().Header().Set(echo.HeaderCacheControl, calendarCacheControl)
+	return sh.notModified(c, false, versions...)
+}
+
+func notModifiedResponse(c echo.Context) error {
+	return c.NoContent(http.StatusNotModified)
+}
@@ -0,0 +1,64 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+func TestRevisionNotModifiedClearsValidatorsOnErrors(t *testing.T) {
+	revision := &models.CalendarRevision{Revision: 42, UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		name       string
+		status     int
+		validators bool
+	}{
+		{"found", http.StatusOK, true},
+		{"not found", http.StatusNotFound, false},
+		{"error", http.StatusInternalServerError, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/schedule/groups/344", http.NoBody), rec)
+
+			if revisionNotModified(c, revision, false) {
+				t.Fatal("expected a request without validators to be modified")
+			}
+			if err := c.JSON(test.status, map[string]string{"message": "group not found"}); err != nil {
+				t.Fatal(err)
+			}
+			if hasValidators := rec.Header().Get("ETag") != "" && rec.Header().Get("Last-Modified") != ""; hasValidators != test.validators {
+				t.Errorf("expected validators %v, got ETag %q, Last-Modified %q",
+					test.validators, rec.Header().Get("ETag"), rec.Header().Get("Last-Modified"))
+			}
+		})
+	}
+}
+
+func TestWildcardIsAnsweredAfterTheResourceIsFound(t *testing.T) {
+	revision := &models.CalendarRevision{Revision: 42, UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC)}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/schedule/groups/344", http.NoBody)
+	req.Header.Set("If-None-Match", "*")
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	if revisionNotModified(c, revision, false) {
+		t.Fatal("expected If-None-Match: * to wait for the resource")
+	}
+	if !foundNotModified(c) {
+		t.Error("expected If-None-Match: * to match the found resource")
+	}
+
+	c = e.NewContext(req, httptest.NewRecorder())
+	if foundNotModified(c) {
+		t.Error("expected a response without validators to ignore If-None-Match: *")
+	}
+}
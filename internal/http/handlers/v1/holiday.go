@@ -0,0 +1,141 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+type holidayRequest struct {
+	Title string `json:"title" validate:"required,max=200"                           example:"День защитника Отечества"`
+	Kind  string `json:"kind"  validate:"required,oneof=holiday non_working closure" example:"closure"`
+}
+
+type holidaysImportRequest struct {
+	Year int `query:"year" validate:"omitempty,min=2000,max=2100"`
+}
+
+// getHolidays
+// @Summary     List holidays
+// @Description Праздники, перенесённые выходные и дни, когда университет закрыт. По умолчанию — текущий год
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/holidays [get]
+// @Param       from  query  string  false  "from date" example(2026-01-01)
+// @Param       to    query  string  false  "to date, inclusive" example(2026-12-31)
+// @Success     200  {array}  models.Holiday
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getHolidays(c echo.Context) error {
+	resp, err := sh.s.GetHolidays(c.Request().Context(), c.QueryParam("from"), c.QueryParam("to"))
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// saveHoliday
+// @Summary     Create or replace a holiday
+// @Description День, сохранённый вручную, не перезаписывается импортом производственного календаря
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/holidays/{date} [put]
+// @Param       date  path  string  true  "date" example(2026-05-15)
+// @Param       holiday  body  holidayRequest  true  "holiday"
+// @Success     200  {object}  models.Holiday
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) saveHoliday(c echo.Context) error {
+	date := c.Param("date")
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "date path param "+services.ErrInvalidDateFormat.Error())
+	}
+
+	var req holidayRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	holiday := &models.Holiday{Date: date, Title: req.Title, Kind: models.HolidayKind(req.Kind)}
+	if err := sh.s.SaveHoliday(c.Request().Context(), holiday); err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, holiday)
+}
+
+// deleteHoliday
+// @Summary     Delete a holiday
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/holidays/{date} [delete]
+// @Param       date  path  string  true  "date" example(2026-05-15)
+// @Success     204
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) deleteHoliday(c echo.Context) error {
+	date := c.Param("date")
+	if _, err := time.Parse(time.DateOnly, date); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "date path param "+services.ErrInvalidDateFormat.Error())
+	}
+
+	if err := sh.s.DeleteHoliday(c.Request().Context(), date); err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// importHolidays
+// @Summary     Import the production calendar
+// @Description Загружает встроенный производственный календарь РФ. Дни, изменённые вручную, сохраняются
+// @Tags        Admin
+// @Security    BearerAuth
+// @Router      /api/v1/admin/holidays/import [post]
+// @Param       year  query  int  false  "only this year" example(2026)
+// @Success     200  {object}  models.HolidaysImport
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) importHolidays(c echo.Context) error {
+	var req holidaysImportRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := sh.s.ImportProductionCalendar(c.Request().Context(), req.Year)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
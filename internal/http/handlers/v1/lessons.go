@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+// getGroupLessons
+// @Summary     Get group lessons for a date range
+// @Description Плоский список занятий группы за период до семестра, отсортированный по времени начала
+// @Tags        Groups
+// @Router      /api/v1/schedule/groups/{group}/lessons [get]
+// @Param       group  path  string  true  "group" example(344)
+// @Param       from  query  string  false  "first date, today by default" example(2026-02-09)
+// @Param       to  query  string  false  "last date, two weeks after from by default" example(2026-06-30)
+// @Param       cursor  query  string  false  "next_cursor of the previous page"
+// @Param       limit  query  int  false  "page size" default(100) maximum(500)
+// @Success     200  {object}  models.LessonsPage
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getGroupLessons(c echo.Context) error {
+	group := c.Param("group")
+	if group == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "group path param not found")
+	}
+
+	query, err := lessonsQuery(c)
+	if err != nil {
+		return err
+	}
+	if sh.notModified(c, query.From == "") {
+		return notModifiedResponse(c)
+	}
+
+	resp, err := sh.s.GetGroupLessons(c.Request().Context(), group, query)
+	return lessonsResponse(c, resp, err)
+}
+
+// getTeacherLessons
+// @Summary     Get teacher lessons for a date range
+// @Description Плоский список занятий преподавателя за период до семестра, отсортированный по времени начала
+// @Tags        Teachers
+// @Router      /api/v1/schedule/teachers/{teacher_id}/lessons [get]
+// @Param       teacher_id  path  int  true  "teacher_id" example(1)
+// @Param       from  query  string  false  "first date, today by default" example(2026-02-09)
+// @Param       to  query  string  false  "last date, two weeks after from by default" example(2026-06-30)
+// @Param       cursor  query  string  false  "next_cursor of the previous page"
+// @Param       limit  query  int  false  "page size" default(100) maximum(500)
+// @Success     200  {object}  models.LessonsPage
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getTeacherLessons(c echo.Context) error {
+	teacherID, err := strconv.Atoi(c.Param("teacher_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "teacher_id path param must be integer")
+	}
+
+	query, err := lessonsQuery(c)
+	if err != nil {
+		return err
+	}
+	if sh.notModified(c, query.From == "") {
+		return notModifiedResponse(c)
+	}
+
+	resp, err := sh.s.GetTeacherLessons(c.Request().Context(), teacherID, query)
+	return lessonsResponse(c, resp, err)
+}
+
+// getAuditoriumLessons
+// @Summary     Get auditorium lessons for a date range
+// @Description Плоский список занятий в аудитории за период до семестра, отсортированный по времени начала
+// @Tags        Auditoriums
+// @Router      /api/v1/schedule/auditoriums/{auditorium_id}/lessons [get]
+// @Param       auditorium_id  path  int  true  "auditorium_id" example(12)
+// @Param       from  query  string  false  "first date, today by default" example(2026-02-09)
+// @Param       to  query  string  false  "last date, two weeks after from by default" example(2026-06-30)
+// @Param       cursor  query  string  false  "next_cursor of the previous page"
+// @Param       limit  query  int  false  "page size" default(100) maximum(500)
+// @Success     200  {object}  models.LessonsPage
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAuditoriumLessons(c echo.Context) error {
+	auditoriumID, err := strconv.Atoi(c.Param("auditorium_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "auditorium_id path param must be integer")
+	}
+
+	query, err := lessonsQuery(c)
+	if err != nil {
+		return err
+	}
+	if sh.notModified(c, query.From == "") {
+		return notModifiedResponse(c)
+	}
+
+	resp, err := sh.s.GetAuditoriumLessons(c.Request().Context(), auditoriumID, query)
+	return lessonsResponse(c, resp, err)
+}
+
+// getGroupNow
+// @Summary     Get the current and the next group lesson
+// @Description Текущее и следующее занятие группы по московскому времени сервера, сколько минут до их конца и начала,
+// @Description перерыв и конец учебного дня
+// @Tags        Groups
+// @Router      /api/v1/schedule/groups/{group}/now [get]
+// @Param       group  path  string  true  "group" example(344)
+// @Success     200  {object}  models.LessonsNow
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getGroupNow(c echo.Context) error {
+	group := c.Param("group")
+	if group == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "group path param not found")
+	}
+
+	resp, err := sh.s.GetGroupNow(c.Request().Context(), group)
+	return lessonsResponse(c, resp, err)
+}
+
+// getTeacherNow
+// @Summary     Get the current and the next teacher lesson
+// @Description Текущее и следующее занятие преподавателя по московскому времени сервера, сколько минут до их конца и начала,
+// @Description перерыв и конец учебного дня
+// @Tags        Teachers
+// @Router      /api/v1/schedule/teachers/{teacher_id}/now [get]
+// @Param       teacher_id  path  int  true  "teacher_id" example(1)
+// @Success     200  {object}  models.LessonsNow
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getTeacherNow(c echo.Context) error {
+	teacherID, err := strconv.Atoi(c.Param("teacher_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "teacher_id path param must be integer")
+	}
+
+	resp, err := sh.s.GetTeacherNow(c.Request().Context(), teacherID)
+	return lessonsResponse(c, resp, err)
+}
+
+// getAuditoriumNow
+// @Summary     Get the current and the next auditorium lesson
+// @Description Текущее и следующее занятие в аудитории по московскому времени сервера, сколько минут до их конца и начала,
+// @Description перерыв и конец учебного дня
+// @Tags        Auditoriums
+// @Router      /api/v1/schedule/auditoriums/{auditorium_id}/now [get]
+// @Param       auditorium_id  path  int  true  "auditorium_id" example(12)
+// @Success     200  {object}  models.LessonsNow
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAuditoriumNow(c echo.Context) error {
+	auditoriumID, err := strconv.Atoi(c.Param("auditorium_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "auditorium_id path param must be integer")
+	}
+
+	resp, err := sh.s.GetAuditoriumNow(c.Request().Context(), auditoriumID)
+	return lessonsResponse(c, resp, err)
+}
+
+func lessonsQuery(c echo.Context) (services.LessonsQuery, error) {
+	query := services.LessonsQuery{
+		From:   c.QueryParam("from"),
+		To:     c.QueryParam("to"),
+		Cursor: c.QueryParam("cursor"),
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		var err error
+		if query.Limit, err = strconv.Atoi(limit); err != nil || query.Limit <= 0 {
+			return query, echo.NewHTTPError(http.StatusBadRequest, "limit query param must be positive integer")
+		}
+	}
+	return query, nil
+}
+
+func lessonsResponse[T models.LessonsPage | models.LessonsNow](c echo.Context, resp *T, err error) error {
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	if foundNotModified(c) {
+		return notModifiedResponse(c)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
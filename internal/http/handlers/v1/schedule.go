@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/schedule-rsreu/schedule-api/pkg/logger"
@@ -13,22 +14,28 @@ import (
 	"github.com/schedule-rsreu/schedule-api/internal/services"
 
 	"github.com/labstack/echo/v4"
-	_ "github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/dwh"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
 )
 
 type ScheduleHandler struct {
-	s *services.ScheduleService
+	s   *services.ScheduleService
+	hub *services.ScheduleHub
 }
 
 func NewRouter(g *echo.Group,
 	scheduleService *services.ScheduleService,
-
+	scheduleHub *services.ScheduleHub,
 ) {
 	sh := &ScheduleHandler{
-		s: scheduleService,
+		s:   scheduleService,
+		hub: scheduleHub,
 	}
 
-	scheduleGroup := g.Group("/schedule")
+	g.GET("/search", sh.search, auth.Allow(models.APIClientScopeRead)) // /search?q=Конюхов+А
+
+	scheduleGroup := g.Group("/schedule", auth.Allow(models.APIClientScopeRead))
 
 	scheduleGroup.GET("/day", sh.getDay) // /day
 
@@ -36,6 +43,9 @@ func NewRouter(g *echo.Group,
 
 	scheduleGroup.GET("/groups/:group", sh.getScheduleByGroup) // /groups/344
 	scheduleGroup.GET("/groups/:group/calendar.ics", sh.getGroupCalendar)
+	scheduleGroup.GET("/groups/:group/lessons", sh.getGroupLessons)
+	scheduleGroup.GET("/groups/:group/changes", sh.getGroupChanges)
+	scheduleGroup.GET("/groups/:group/now", sh.getGroupNow)
 	scheduleGroup.POST("/groups/sample", sh.schedulesByGroups) // groups/sample
 	scheduleGroup.GET("/groups", sh.getCourseFacultyGroups)    // /groups?faculty=фвт&course=3
 
@@ -43,20 +53,72 @@ func NewRouter(g *echo.Group,
 	scheduleGroup.GET("/faculties/course", sh.getCourseFaculties)   // /faculties/course?course=1
 	scheduleGroup.GET("/faculties/courses", sh.getFacultiesCourses) // /faculties/course?course=1
 
-	scheduleGroup.GET("/teachers", sh.getTeacherSchedule)                 // /teachers?teacher=Конюхов+Алексей+Николаевич
+	scheduleGroup.GET("/teachers", sh.getTeacherSchedule)                 // /teachers?teacher_id=1
 	scheduleGroup.GET("/teachers/all", sh.getTeachers)                    // /teachers/all
 	scheduleGroup.GET("/teachers/list", sh.getTeachersList)               // /teachers/list?faculty=фаиту&department=ВМ
 	scheduleGroup.GET("/teachers/departments", sh.getTeachersDepartments) // /teachers/departments?faculty=фаиту
 	scheduleGroup.GET("/teachers/faculties", sh.getTeachersFaculties)     // /teachers/faculties?department=ВМ
+	scheduleGroup.GET("/teachers/:teacher_id/calendar.ics", sh.getTeacherCalendar)
+	scheduleGroup.GET("/teachers/:teacher_id/lessons", sh.getTeacherLessons)
+	scheduleGroup.GET("/teachers/:teacher_id/now", sh.getTeacherNow)
 
 	scheduleGroup.GET("/auditoriums", sh.getAuditoriumSchedule) // /auditoriums
 	scheduleGroup.GET("/auditoriums/list", sh.getAuditoriumList)
+	scheduleGroup.GET("/auditoriums/free", sh.getFreeAuditoriums) // /auditoriums/free?building_id=3&time=11:50
 	scheduleGroup.GET("/auditoriums/:auditorium_id", sh.getAuditorium)
+	scheduleGroup.GET("/auditoriums/:auditorium_id/calendar.ics", sh.getAuditoriumCalendar)
+	scheduleGroup.GET("/auditoriums/:auditorium_id/lessons", sh.getAuditoriumLessons)
+	scheduleGroup.GET("/auditoriums/:auditorium_id/now", sh.getAuditoriumNow)
 
 	scheduleGroup.GET("/buildings", sh.getBuildings)
 	scheduleGroup.GET("/buildings/:id", sh.getBuilding)
 
 	scheduleGroup.GET("/lesson/types", sh.getLessonTypes) // /auditoriums
+	scheduleGroup.GET("/bells", sh.getBells)
+
+	scheduleGroup.GET("/free-slots", sh.getCommonFreeSlots)  // /free-slots?teachers=1,2&groups=344&auditorium=true
+	scheduleGroup.GET("/conflicts", sh.getScheduleConflicts) // /conflicts?from=2026-03-02&faculty_id=1
+
+	scheduleGroup.GET("/stream", sh.getScheduleStream) // /stream?groups=344,345
+
+	calendarGroup := g.Group("/calendar", auth.Allow(models.APIClientScopeRead))
+
+	// Изменение и удаление защищены секретным token ленты, создание — только для клиентов API.
+	calendarGroup.POST("/feeds", sh.createCalendarFeed, auth.Require(models.APIClientScopeRead))
+	calendarGroup.GET("/feeds/:token", sh.getCalendarFeed) // /feeds/{token}.ics
+	calendarGroup.PUT("/feeds/:token", sh.updateCalendarFeed)
+	calendarGroup.DELETE("/feeds/:token", sh.deleteCalendarFeed)
+
+	registerCalDAV(g, sh.s)
+
+	adminGroup := g.Group("/admin", auth.Require(models.APIClientScopeAdmin))
+
+	adminGroup.GET("/academic-periods", sh.getAcademicPeriods)
+	adminGroup.POST("/academic-periods", sh.createAcademicPeriod)
+	adminGroup.GET("/academic-periods/:id", sh.getAcademicPeriod)
+	adminGroup.PUT("/academic-periods/:id", sh.updateAcademicPeriod)
+	adminGroup.DELETE("/academic-periods/:id", sh.deleteAcademicPeriod)
+
+	adminGroup.GET("/holidays", sh.getHolidays)
+	adminGroup.POST("/holidays/import", sh.importHolidays)
+	adminGroup.PUT("/holidays/:date", sh.saveHoliday)
+	adminGroup.DELETE("/holidays/:date", sh.deleteHoliday)
+
+	adminGroup.GET("/clients", sh.getAPIClients)
+	adminGroup.POST("/clients", sh.createAPIClient)
+	adminGroup.GET("/clients/:id", sh.getAPIClient)
+	adminGroup.PUT("/clients/:id", sh.updateAPIClient)
+	adminGroup.POST("/clients/:id/key", sh.rotateAPIClientKey)
+	adminGroup.DELETE("/clients/:id", sh.deleteAPIClient)
+
+	webhooksGroup := g.Group("/webhooks", auth.Require(models.APIClientScopeWebhooks))
+
+	webhooksGroup.POST("", sh.createWebhook)
+	webhooksGroup.GET("", sh.getWebhooks)
+	webhooksGroup.GET("/:id", sh.getWebhook)
+	webhooksGroup.PATCH("/:id", sh.setWebhookActive)
+	webhooksGroup.DELETE("/:id", sh.deleteWebhook)
+	webhooksGroup.GET("/:id/deliveries", sh.getWebhookDeliveries)
 }
 
 // @Summary     Subscribe to a group calendar.
@@ -64,8 +126,13 @@ func NewRouter(g *echo.Group,
 // @Tags        Groups
 // @Router      /api/v1/schedule/groups/{group}/calendar.ics [get]
 // @Param       group  path  string  true  "group" example(344)
+// @Param       alarm  query  int  false  "reminder in minutes before the lesson, 0 disables reminders" default(30)
+// @Param       emoji  query  bool  false  "emoji in event titles" default(true)
+// @Param       lang  query  string  false  "calendar language" Enums(ru, en) default(ru)
+// @Param       color  query  string  false  "calendar color" example(#5288c1)
 // @Produce     text/calendar
 // @Success     200  {string}  string
+// @Success     304  {string}  string  "Not Modified"
 // @Failure     404  {object}  echo.HTTPError
 // @Failure     500  {object}  echo.HTTPError.
 func (sh *ScheduleHandler) getGroupCalendar(c echo.Context) error {
@@ -74,8 +141,98 @@ func (sh *ScheduleHandler) getGroupCalendar(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "group path param not found")
 	}
 
-	source := fmt.Sprintf("%s://%s%s", c.Scheme(), c.Request().Host, c.Request().URL.Path)
-	calendar, err := sh.s.GetGroupCalendar(c.Request().Context(), group, source)
+	options, err := calendarOptions(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.calendarNotModified(c) {
+		return notModifiedResponse(c)
+	}
+
+	calendar, err := sh.s.GetGroupCalendar(c.Request().Context(), group, calendarSource(c), options)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+
+	return writeCalendar(c, "schedule-"+safeFilename(group)+".ics", calendar)
+}
+
+// @Summary     Subscribe to a teacher calendar.
+// @Description Returns an iCalendar feed with teacher lessons, updates and cancellations
+// @Tags        Teachers
+// @Router      /api/v1/schedule/teachers/{teacher_id}/calendar.ics [get]
+// @Param       teacher_id  path  int  true  "teacher_id" example(1)
+// @Param       alarm  query  int  false  "reminder in minutes before the lesson, 0 disables reminders" default(30)
+// @Param       emoji  query  bool  false  "emoji in event titles" default(true)
+// @Param       lang  query  string  false  "calendar language" Enums(ru, en) default(ru)
+// @Param       color  query  string  false  "calendar color" example(#5288c1)
+// @Produce     text/calendar
+// @Success     200  {string}  string
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getTeacherCalendar(c echo.Context) error {
+	teacherID, err := strconv.Atoi(c.Param("teacher_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "teacher_id path param must be integer")
+	}
+
+	options, err := calendarOptions(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.calendarNotModified(c) {
+		return notModifiedResponse(c)
+	}
+
+	calendar, err := sh.s.GetTeacherCalendar(c.Request().Context(), teacherID, calendarSource(c), options)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+
+	return writeCalendar(c, "schedule-teacher-"+strconv.Itoa(teacherID)+".ics", calendar)
+}
+
+// @Summary     Subscribe to an auditorium calendar.
+// @Description Returns an iCalendar feed with auditorium lessons, updates and cancellations
+// @Tags        Auditoriums
+// @Router      /api/v1/schedule/auditoriums/{auditorium_id}/calendar.ics [get]
+// @Param       auditorium_id  path  int  true  "auditorium_id" example(12)
+// @Param       alarm  query  int  false  "reminder in minutes before the lesson, 0 disables reminders" default(30)
+// @Param       emoji  query  bool  false  "emoji in event titles" default(true)
+// @Param       lang  query  string  false  "calendar language" Enums(ru, en) default(ru)
+// @Param       color  query  string  false  "calendar color" example(#5288c1)
+// @Produce     text/calendar
+// @Success     200  {string}  string
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getAuditoriumCalendar(c echo.Context) error {
+	auditoriumID, err := strconv.Atoi(c.Param("auditorium_id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "auditorium_id path param must be integer")
+	}
+
+	options, err := calendarOptions(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.calendarNotModified(c) {
+		return notModifiedResponse(c)
+	}
+
+	calendar, err := sh.s.GetAuditoriumCalendar(c.Request().Context(), auditoriumID, calendarSource(c), options)
 	if err != nil {
 		if errors.As(err, &services.NotFoundError{}) {
 			return echo.NewHTTPError(http.StatusNotFound, err)
@@ -83,12 +240,67 @@ func (sh *ScheduleHandler) getGroupCalendar(c echo.Context) error {
 		return err
 	}
 
-	c.Response().Header().Set(echo.HeaderContentDisposition,
-		fmt.Sprintf(`inline; filename="schedule-%s.ics"`, safeFilename(group)))
-	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+	return writeCalendar(c, "schedule-auditorium-"+strconv.Itoa(auditoriumID)+".ics", calendar)
+}
+
+func calendarSource(c echo.Context) string {
+	return fmt.Sprintf("%s://%s%s", c.Scheme(), c.Request().Host, c.Request().URL.Path)
+}
+
+type calendarOptionsRequest struct {
+	Alarm *int   `query:"alarm" validate:"omitempty,min=0,max=1440"`
+	Emoji *bool  `query:"emoji"`
+	Lang  string `query:"lang"  validate:"omitempty,oneof=ru en"`
+	Color string `query:"color" validate:"omitempty,hexcolor"`
+}
+
+// calendarOptions reads presentation options of the calendar from the query, missing ones keep the defaults.
+func calendarOptions(c echo.Context) (services.CalendarOptions, error) {
+	options := services.DefaultCalendarOptions()
+
+	var req calendarOptionsRequest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &req); err != nil {
+		return options, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return options, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	if req.Alarm != nil {
+		options.Alarm = time.Duration(*req.Alarm) * time.Minute
+	}
+	if req.Emoji != nil {
+		options.Emoji = *req.Emoji
+	}
+	if req.Lang != "" {
+		options.Lang = services.CalendarLang(req.Lang)
+	}
+	if req.Color != "" {
+		options.Color = req.Color
+	}
+	return options, nil
+}
+
+func writeCalendar(c echo.Context, filename string, calendar []byte) error {
+	if foundNotModified(c) {
+		return notModifiedResponse(c)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="%s"`, filename))
 	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", calendar)
 }
 
+// scheduleLayout returns the layout query param of the week schedules, week by default.
+func scheduleLayout(c echo.Context) (string, error) {
+	switch layout := c.QueryParam("layout"); layout {
+	case "", services.LayoutWeek:
+		return services.LayoutWeek, nil
+	case services.LayoutGrid:
+		return layout, nil
+	default:
+		return "", echo.NewHTTPError(http.StatusBadRequest, "layout query param must be week or grid")
+	}
+}
+
 func safeFilename(value string) string {
 	return strings.Map(func(character rune) rune {
 		if unicode.IsLetter(character) || unicode.IsDigit(character) || character == '-' || character == '_' {
@@ -100,16 +312,19 @@ func safeFilename(value string) string {
 
 // getScheduleByGroup
 // @Summary     Get schedule by group
-// @Description Get schedule by group
+// @Description Расписание группы, layout=grid отдаёт матрицу дней и пар вместо add_empty_lessons
 // @Tags        Groups
 // @Router      /api/v1/schedule/groups/{group} [get]
 // @Param       group  path  string  true  "group" example(344)
-// @Param       add_empty_lessons  query  bool  false  "add empty lessons"
+// @Param       add_empty_lessons  query  bool  false  "add empty lessons, ignored for the grid layout"
+// @Param       layout  query  string  false  "week days or a day × pair grid" Enums(week, grid)
 // @Param       date  query  string  false  "date" example(2025-07-13)
 // @Success     200  {object}  models.StudentSchedule
 // @Response    200  {object}  models.StudentSchedule
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
 // @Failure     500  {object}  echo.HTTPError.
-// @Failure     404  {object}  echo.HTTPError.
+// @Failure     404  {object}  models.NotFoundResponse.
 func (sh *ScheduleHandler) getScheduleByGroup(c echo.Context) error {
 	ctx := c.Request().Context()
 	group := c.Param("group")
@@ -120,7 +335,16 @@ func (sh *ScheduleHandler) getScheduleByGroup(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "group query param not found")
 	}
 
-	resp, err := sh.s.GetScheduleByGroup(ctx, group, addEmptyLessons, date)
+	layout, err := scheduleLayout(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.notModified(c, date == "") {
+		return notModifiedResponse(c)
+	}
+
+	resp, err := sh.s.GetScheduleByGroup(ctx, group, addEmptyLessons && layout != services.LayoutGrid, date)
 	if err != nil {
 		if errors.As(err, &services.NotFoundError{}) {
 			return echo.NewHTTPError(http.StatusNotFound, err)
@@ -129,6 +353,45 @@ func (sh *ScheduleHandler) getScheduleByGroup(c echo.Context) error {
 	}
 	resp.LessonsTimes = nil
 
+	if layout == services.LayoutGrid {
+		services.StudentScheduleGrid(resp)
+	}
+
+	if foundNotModified(c) {
+		return notModifiedResponse(c)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// getGroupChanges
+// @Summary     Get group schedule changes
+// @Description Добавленные, удалённые и изменённые занятия группы: перенос в другую аудиторию, замена преподавателя, сдвиг по времени.
+// @Description Изменения отдаются страницами по id: для следующей страницы или следующего опроса передайте cursor в after
+// @Tags        Groups
+// @Router      /api/v1/schedule/groups/{group}/changes [get]
+// @Param       group  path  string  true  "group" example(344)
+// @Param       since  query  string  false  "RFC 3339 timestamp or date, exclusive, for the first page; a week ago by default" example(2026-02-10T09:00:00.123456+03:00)
+// @Param       after  query  int  false  "cursor of the previous response, exclusive; since is ignored with it" example(1)
+// @Success     200  {object}  models.LessonChanges
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getGroupChanges(c echo.Context) error {
+	group := c.Param("group")
+	if group == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "group path param not found")
+	}
+
+	resp, err := sh.s.GetGroupChanges(c.Request().Context(), group, c.QueryParam("since"), c.QueryParam("after"))
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
 	return c.JSON(http.StatusOK, resp)
 }
 
@@ -137,12 +400,15 @@ func (sh *ScheduleHandler) getScheduleByGroup(c echo.Context) error {
 // @Description Расписание преподавателя
 // @Tags        Teachers
 // @Router      /api/v1/schedule/teachers [get]
-// @Param       teacher_id  query  int  true  "teacher" example("Конюхов Алексей Николаевич")
+// @Param       teacher_id  query  int  true  "teacher id, /api/v1/search finds it by name" example(1)
+// @Param       layout  query  string  false  "week days or a day × pair grid" Enums(week, grid)
 // @Param       date  query  string  false  "date" example(2025-07-13)
 // @Success     200  {object}  models.TeacherSchedule
 // @Response    200  {object}  models.TeacherSchedule
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
 // @Failure     500  {object}  echo.HTTPError.
-// @Failure     404  {object}  echo.HTTPError.
+// @Failure     404  {object}  models.NotFoundResponse.
 func (sh *ScheduleHandler) getTeacherSchedule(c echo.Context) error {
 	teacherID := c.QueryParam("teacher_id")
 	date := c.QueryParam("date")
@@ -155,6 +421,16 @@ func (sh *ScheduleHandler) getTeacherSchedule(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "teacher_id query param must be integer")
 	}
+
+	layout, err := scheduleLayout(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.notModified(c, date == "") {
+		return notModifiedResponse(c)
+	}
+
 	ctx := c.Request().Context()
 	resp, err := sh.s.GetTeacherSchedule(ctx, teacherIdInt, date)
 
@@ -167,6 +443,13 @@ func (sh *ScheduleHandler) getTeacherSchedule(c echo.Context) error {
 
 	resp.LessonsTimes = nil
 
+	if layout == services.LayoutGrid {
+		services.TeacherScheduleGrid(resp)
+	}
+
+	if foundNotModified(c) {
+		return notModifiedResponse(c)
+	}
 	return c.JSON(http.StatusOK, resp)
 }
 
@@ -341,6 +624,7 @@ func (sh *ScheduleHandler) schedulesByGroups(c echo.Context) error {
 	if err := c.Validate(req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	dwh.SetGroups(c, req.Groups)
 
 	ctx := c.Request().Context()
 
@@ -507,11 +791,14 @@ func (sh *ScheduleHandler) getTeachersDepartments(c echo.Context) error {
 // @Tags        Auditoriums
 // @Router      /api/v1/schedule/auditoriums [get]
 // @Param       auditorium_id  query  int  true  "auditorium_id" example(12)
+// @Param       layout  query  string  false  "week days or a day × pair grid" Enums(week, grid)
 // @Param       date  query  string  false  "date" example(2025-06-13)
 // @Success     200  {object}  models.AuditoriumSchedule
 // @Response    200  {object}  models.AuditoriumSchedule
+// @Success     304  {string}  string  "Not Modified"
+// @Failure     400  {object}  echo.HTTPError
 // @Failure     500  {object}  echo.HTTPError.
-// @Failure     404  {object}  echo.HTTPError.
+// @Failure     404  {object}  models.NotFoundResponse.
 func (sh *ScheduleHandler) getAuditoriumSchedule(c echo.Context) error {
 	auditoriumIdStr := c.QueryParam("auditorium_id")
 
@@ -526,6 +813,15 @@ func (sh *ScheduleHandler) getAuditoriumSchedule(c echo.Context) error {
 
 	date := c.QueryParam("date")
 
+	layout, err := scheduleLayout(c)
+	if err != nil {
+		return err
+	}
+
+	if sh.notModified(c, date == "") {
+		return notModifiedResponse(c)
+	}
+
 	ctx := c.Request().Context()
 
 	resp, err := sh.s.GetAuditoriumSchedule(ctx, auditoriumIdInt, date)
@@ -536,6 +832,13 @@ func (sh *ScheduleHandler) getAuditoriumSchedule(c echo.Context) error {
 		return err
 	}
 
+	if layout == services.LayoutGrid {
+		services.AuditoriumScheduleGrid(resp)
+	}
+
+	if foundNotModified(c) {
+		return notModifiedResponse(c)
+	}
 	return c.JSON(http.StatusOK, resp)
 }
 
@@ -565,6 +868,190 @@ func (sh *ScheduleHandler) getAuditoriumList(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
+type commonFreeSlotsRequest struct {
+	Groups     string `query:"groups"      validate:"omitempty,max=1000"`
+	Teachers   string `query:"teachers"    validate:"omitempty,max=1000"`
+	From       string `query:"from"        validate:"omitempty,datetime=2006-01-02"`
+	To         string `query:"to"          validate:"omitempty,datetime=2006-01-02"`
+	Auditorium bool   `query:"auditorium"`
+	BuildingID int    `query:"building_id" validate:"omitempty,min=1"`
+}
+
+// getCommonFreeSlots
+// @Summary     Find common free slots
+// @Description Пары из сетки lessons_times, в которые свободны все перечисленные группы и преподаватели.
+// @Description Воскресенья и праздники пропускаются. С auditorium=true к каждой паре подбирается свободная аудитория
+// @Tags        Teachers
+// @Router      /api/v1/schedule/free-slots [get]
+// @Param       teachers  query  string  false  "comma separated teacher ids" example(1,2)
+// @Param       groups  query  string  false  "comma separated group numbers" example(344,345)
+// @Param       from  query  string  false  "first date, today by default" example(2026-03-02)
+// @Param       to  query  string  false  "last date, a week after from by default, at most 31 days after from" example(2026-03-08)
+// @Param       auditorium  query  bool  false  "add a free auditorium to every slot" default(false)
+// @Param       building_id  query  int  false  "building of the auditoriums" example(3)
+// @Success     200  {object}  models.CommonFreeSlots
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getCommonFreeSlots(c echo.Context) error {
+	var req commonFreeSlotsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := sh.s.GetCommonFreeSlots(c.Request().Context(), services.CommonFreeSlotsQuery{
+		Groups:         req.Groups,
+		Teachers:       req.Teachers,
+		From:           req.From,
+		To:             req.To,
+		WithAuditorium: req.Auditorium,
+		BuildingID:     req.BuildingID,
+	})
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// getBells
+// @Summary     Get the bell schedule
+// @Description Расписание звонков: номера пар, их начало и конец, перерыв в середине пары и перемены между парами.
+// @Description pair_number занятий — номер пары, с которой занятие начинается, 0 для занятий вне расписания звонков
+// @Tags        Schedule
+// @Router      /api/v1/schedule/bells [get]
+// @Success     200  {object}  models.BellSchedule
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getBells(c echo.Context) error {
+	return c.JSON(http.StatusOK, sh.s.GetBells())
+}
+
+type searchRequest struct {
+	Query string `query:"q"     validate:"required,max=100"`
+	Type  string `query:"type"  validate:"omitempty,oneof=group teacher auditorium building subject"`
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// search
+// @Summary     Search groups, teachers, auditoriums and subjects
+// @Description Нечёткий поиск по группам, преподавателям, аудиториям, корпусам и названиям занятий.
+// @Description Находит по части фамилии и инициалам, с опечатками, в английской раскладке и с латинскими буквами в номере группы
+// @Tags        Search
+// @Router      /api/v1/search [get]
+// @Param       q  query  string  true  "query" example(Конюхов А)
+// @Param       type  query  string  false  "only hits of the type" Enums(group, teacher, auditorium, building, subject)
+// @Param       limit  query  int  false  "hits count" default(20) maximum(50)
+// @Success     200  {object}  models.SearchResults
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) search(c echo.Context) error {
+	var req searchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := sh.s.Search(c.Request().Context(), req.Query, models.SearchHitType(req.Type), req.Limit)
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+type scheduleConflictsRequest struct {
+	From      string `query:"from"       validate:"omitempty,datetime=2006-01-02"`
+	To        string `query:"to"         validate:"omitempty,datetime=2006-01-02"`
+	FacultyID int    `query:"faculty_id" validate:"omitempty,min=1"`
+}
+
+// getScheduleConflicts
+// @Summary     Find schedule conflicts
+// @Description Накладки в расписании: преподаватель в двух аудиториях одновременно, аудитория, занятая несвязанными занятиями,
+// @Description и группа с двумя занятиями в одно время. Занятия потока не считаются накладкой
+// @Tags        Schedule
+// @Router      /api/v1/schedule/conflicts [get]
+// @Param       from  query  string  false  "first date, today by default" example(2026-03-02)
+// @Param       to  query  string  false  "last date, two weeks after from by default, at most 31 days after from" example(2026-03-15)
+// @Param       faculty_id  query  int  false  "only conflicts involving groups of the faculty" example(1)
+// @Success     200  {object}  models.ScheduleConflicts
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getScheduleConflicts(c echo.Context) error {
+	var req scheduleConflictsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := sh.s.GetScheduleConflicts(c.Request().Context(), req.From, req.To, req.FacultyID)
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+type freeAuditoriumsRequest struct {
+	Date       string `query:"date"        validate:"omitempty,datetime=2006-01-02"`
+	Time       string `query:"time"        validate:"omitempty,max=16"`
+	BuildingID int    `query:"building_id" validate:"omitempty,min=1"`
+	MinSlots   int    `query:"min_slots"   validate:"omitempty,min=1,max=8"`
+}
+
+// getFreeAuditoriums
+// @Summary     Find free auditoriums
+// @Description Аудитории без занятий в min_slots пар подряд, начиная с пары, которая идёт в time, или следующей.
+// @Description Пары берутся из сетки lessons_times двухнедельного окна даты, free_slots — сколько пар подряд аудитория свободна.
+// @Description Если до конца дня осталось меньше min_slots пар, аудиторий нет. В праздник свободны все аудитории
+// @Tags        Auditoriums
+// @Router      /api/v1/schedule/auditoriums/free [get]
+// @Param       date  query  string  false  "date, today by default" example(2025-06-18)
+// @Param       time  query  string  false  "clock time or a lessons_times value, now by default" example(11:50)
+// @Param       building_id  query  int  false  "building_id, all buildings by default" example(3)
+// @Param       min_slots  query  int  false  "free pairs in a row" default(1) maximum(8)
+// @Success     200  {object}  models.FreeAuditoriums
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getFreeAuditoriums(c echo.Context) error {
+	var req freeAuditoriumsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := sh.s.GetFreeAuditoriums(c.Request().Context(), req.Date, req.Time, req.BuildingID, req.MinSlots)
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
 // @Failure     404  {object}  echo.HTTPError.
 func (sh *ScheduleHandler) getAuditorium(c echo.Context) error {
 	auditoriumIdStr := c.Param("auditorium_id")
@@ -0,0 +1,144 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+const (
+	streamHeartbeat  = 25 * time.Second
+	streamRetry      = 5 * time.Second
+	streamEventName  = "schedule.changed"
+	streamResyncName = "schedule.resync"
+	lastEventIDField = "Last-Event-ID"
+)
+
+// getScheduleStream
+// @Summary     Stream schedule updates
+// @Description Server-Sent Events: событие schedule.changed с ревизией и затронутыми датами при изменении занятий
+// @Description групп, преподавателей или аудиторий. id события — номер последнего изменения, при переподключении
+// @Description браузер передаёт его в Last-Event-ID и получает пропущенные события. Если их слишком много, приходит
+// @Description событие schedule.resync: клиент заново загружает расписания, поток продолжается с его id.
+// @Description Раз в 25 секунд приходит комментарий heartbeat
+// @Tags        Schedule
+// @Router      /api/v1/schedule/stream [get]
+// @Param       groups  query  string  false  "comma separated group numbers" example(344,345)
+// @Param       teachers  query  string  false  "comma separated teacher ids" example(12)
+// @Param       auditoriums  query  string  false  "comma separated auditorium ids" example(7)
+// @Param       Last-Event-ID  header  int  false  "last received event id"
+// @Produce     text/event-stream
+// @Success     200  {object}  models.ScheduleStreamEvent
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     503  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getScheduleStream(c echo.Context) error {
+	var lastEventID int64
+	if header := c.Request().Header.Get(lastEventIDField); header != "" {
+		var err error
+		if lastEventID, err = strconv.ParseInt(header, 10, 64); err != nil || lastEventID < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Last-Event-ID header must be a non-negative integer")
+		}
+	}
+
+	ctx := c.Request().Context()
+	subscription, err := sh.s.GetScheduleStreamSubscription(ctx,
+		c.QueryParam("groups"), c.QueryParam("teachers"), c.QueryParam("auditoriums"))
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+
+	// Подписка раньше чтения пропущенных событий, чтобы между ними ничего не потерялось.
+	subscriber, err := sh.hub.Subscribe(subscription)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, err)
+	}
+	defer sh.hub.Unsubscribe(subscriber)
+
+	var (
+		missed []models.ScheduleStreamEvent
+		resync services.ScheduleStreamResyncError
+	)
+	if lastEventID > 0 {
+		missed, err = sh.s.GetScheduleStreamReplay(ctx, subscription, lastEventID)
+		if err != nil && !errors.As(err, &resync) {
+			return err
+		}
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set(echo.HeaderConnection, "keep-alive")
+	// nginx не должен буферизовать поток.
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(response, "retry: %d\n\n", streamRetry.Milliseconds()); err != nil {
+		return nil
+	}
+	sent := lastEventID
+	if resync.LastID > 0 {
+		if _, err := fmt.Fprintf(response, "id: %d\nevent: %s\ndata: {}\n\n", resync.LastID, streamResyncName); err != nil {
+			return nil
+		}
+		sent = resync.LastID
+	}
+	for _, event := range missed {
+		if err := writeStreamEvent(response, &event); err != nil {
+			return nil
+		}
+		sent = event.Id
+	}
+	response.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscriber.Events():
+			if !ok {
+				return nil
+			}
+			if event.Id <= sent {
+				continue
+			}
+			if err := writeStreamEvent(response, &event); err != nil {
+				return nil
+			}
+			sent = event.Id
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(response, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		}
+		response.Flush()
+	}
+}
+
+func writeStreamEvent(response *echo.Response, event *models.ScheduleStreamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(response, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, streamEventName, data)
+	return err
+}
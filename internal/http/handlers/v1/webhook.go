@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+type webhookRequest struct {
+	URL     string `json:"url"      validate:"required,url,max=2000"                    example:"https://bot.example.com/schedule-webhook"`
+	Scope   string `json:"scope"    validate:"required,oneof=group teacher auditorium" example:"group"`
+	ScopeID string `json:"scope_id" validate:"required,max=20"                         example:"344"`
+}
+
+type webhookActiveRequest struct {
+	Active bool `json:"active" example:"false"`
+}
+
+func webhookID(c echo.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "id path param must be integer")
+	}
+	return id, nil
+}
+
+// createWebhook
+// @Summary     Subscribe to schedule changes
+// @Description При каждой новой ревизии расписания с изменениями в группе, у преподавателя или в аудитории
+// @Description на url отправляется POST с событием schedule.changed. Тело подписано HMAC-SHA256 секретом подписки:
+// @Description заголовок X-Schedule-Signature: t={unix},v1={hex(hmac(secret, t + "." + body))}.
+// @Description Неуспешные доставки повторяются с экспоненциальной задержкой. Секрет возвращается только при создании
+// @Tags        Webhooks
+// @Security    BearerAuth
+// @Router      /api/v1/webhooks [post]
+// @Param       webhook  body  webhookRequest  true  "webhook"
+// @Success     201  {object}  models.Webhook
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) createWebhook(c echo.Context) error {
+	var req webhookRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := c.Validate(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	webhook := &models.Webhook{URL: req.URL, Scope: models.WebhookScope(req.Scope), ScopeID: req.ScopeID}
+	resp, err := sh.s.CreateWebhook(c.Request().Context(), auth.ClientName(c), webhook)
+	if err != nil {
+		if errors.As(err, &services.BadRequestError{}) {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// getWebhooks
+// @Summary     List webhooks
+// @Description Подписки текущего клиента
+// @Tags        Webhooks
+// @Security    BearerAuth
+// @Router      /api/v1/webhooks [get]
+// @Success     200  {array}  models.Webhook
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getWebhooks(c echo.Context) error {
+	resp, err := sh.s.GetWebhooks(c.Request().Context(), auth.ClientName(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// getWebhook
+// @Summary     Get a webhook
+// @Tags        Webhooks
+// @Security    BearerAuth
+// @Router      /api/v1/webhooks/{id} [get]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     200  {object}  models.Webhook
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getWebhook(c echo.Context) error {
+	id, err := webhookID(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sh.s.GetWebhook(c.Request().Context(), auth.ClientName(c), id)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// setWebhookActive
+// @Summary     Pause or resume a webhook
+// @Description Возобновлённая подписка не получает изменения, сделанные во время паузы
+// @Tags        Webhooks
+// @Security    BearerAuth
+// @Router      /api/v1/webhooks/{id} [patch]
+// @Param       id  path  int  true  "id" example(1)
+// @Param       webhook  body  webhookActiveRequest  true  "webhook"
+// @Success     200  {object}  models.Webhook
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) setWebhookActive(c echo.Context) error {
+	id, err := webhookID(c)
+	if err != nil {
+		return err
+	}
+
+	var req webhookActiveRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := sh.s.SetWebhookActive(c.Request().Context(), auth.ClientName(c), id, req.Active)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// deleteWebhook
+// @Summary     Delete a webhook
+// @Tags        Webhooks
+// @Security    BearerAuth
+// @Router      /api/v1/webhooks/{id} [delete]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     204
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) deleteWebhook(c echo.Context) error {
+	id, err := webhookID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := sh.s.DeleteWebhook(c.Request().Context(), auth.ClientName(c), id); err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getWebhookDeliveries
+// @Summary     Webhook delivery log
+// @Description Последние 50 попыток доставки, новые первыми. status_code равен null, если получатель не ответил
+// @Tags        Webhooks
+// @Security    BearerAuth
+// @Router      /api/v1/webhooks/{id}/deliveries [get]
+// @Param       id  path  int  true  "id" example(1)
+// @Success     200  {array}  models.WebhookDelivery
+// @Failure     400  {object}  echo.HTTPError
+// @Failure     401  {object}  echo.HTTPError
+// @Failure     403  {object}  echo.HTTPError
+// @Failure     404  {object}  echo.HTTPError
+// @Failure     500  {object}  echo.HTTPError.
+func (sh *ScheduleHandler) getWebhookDeliveries(c echo.Context) error {
+	id, err := webhookID(c)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sh.s.GetWebhookDeliveries(c.Request().Context(), auth.ClientName(c), id)
+	if err != nil {
+		if errors.As(err, &services.NotFoundError{}) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+	"github.com/schedule-rsreu/schedule-api/pkg/auth/jwt"
+	logger2 "github.com/schedule-rsreu/schedule-api/pkg/logger"
+)
+
+const ClientCtxKey = "client"
+
+// Clients finds active API clients, services.NotFoundError means the credentials are not valid.
+type Clients interface {
+	GetAPIClientByName(ctx context.Context, name string) (*models.APIClient, error)
+	GetAPIClientByKey(ctx context.Context, key string) (*models.APIClient, error)
+}
+
+// New identifies the client by a Bearer API key or a JWT signed with jwtSecret whose app_name is the client name.
+// Requests without a Bearer token stay anonymous, an empty jwtSecret disables JWTs.
+// The client name is added to the request logger and span.
+func New(clients Clients, jwtSecret string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scheme, token, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			// CalDAV клиенты присылают Basic, такие запросы остаются анонимными.
+			if scheme != "Bearer" {
+				return next(c)
+			}
+
+			client, err := authenticate(c.Request().Context(), clients, jwtSecret, strings.TrimSpace(token))
+			if err != nil {
+				if errors.As(err, &services.NotFoundError{}) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "invalid bearer token")
+				}
+				return err
+			}
+
+			c.Set(ClientCtxKey, client)
+			logger := logger2.GetLoggerFromCtx(c).With().Str("client", client.Name).Logger()
+			c.Set(logger2.LoggerCtxKey, &logger)
+			trace.SpanFromContext(c.Request().Context()).SetAttributes(attribute.String("client.name", client.Name))
+			return next(c)
+		}
+	}
+}
+
+func authenticate(ctx context.Context, clients Clients, jwtSecret, token string) (*models.APIClient, error) {
+	// Ключи API — base64url без точек, у JWT три части через точку.
+	if strings.Count(token, ".") != 2 {
+		return clients.GetAPIClientByKey(ctx, token)
+	}
+	if jwtSecret == "" {
+		return nil, services.NotFoundError{}
+	}
+	claims, err := jwt.ParseJWT(token, []byte(jwtSecret))
+	if err != nil {
+		return nil, services.NotFoundError{}
+	}
+	return clients.GetAPIClientByName(ctx, claims.AppName)
+}
+
+// Require refuses anonymous requests and clients without the scope.
+func Require(scope models.APIClientScope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			client := Client(c)
+			if client == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "bearer token required")
+			}
+			if !client.HasScope(scope) {
+				return echo.NewHTTPError(http.StatusForbidden, "client has no "+string(scope)+" scope")
+			}
+			return next(c)
+		}
+	}
+}
+
+// Allow lets anonymous requests through, but refuses identified clients without the scope.
+func Allow(scope models.APIClientScope) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if client := Client(c); client != nil && !client.HasScope(scope) {
+				return echo.NewHTTPError(http.StatusForbidden, "client has no "+string(scope)+" scope")
+			}
+			return next(c)
+		}
+	}
+}
+
+// Client returns the identified client, nil for anonymous requests.
+func Client(c echo.Context) *models.APIClient {
+	client, _ := c.Get(ClientCtxKey).(*models.APIClient)
+	return client
+}
+
+// ClientName returns the name of the identified client, empty for anonymous requests.
+func ClientName(c echo.Context) string {
+	if client := Client(c); client != nil {
+		return client.Name
+	}
+	return ""
+}
@@ -0,0 +1,115 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+	"github.com/schedule-rsreu/schedule-api/pkg/auth/jwt"
+)
+
+const jwtSecret = "test-secret"
+
+type fakeClients map[string]*models.APIClient
+
+func (f fakeClients) GetAPIClientByName(_ context.Context, name string) (*models.APIClient, error) {
+	if client, ok := f[name]; ok {
+		return client, nil
+	}
+	return nil, services.NotFoundError{}
+}
+
+func (f fakeClients) GetAPIClientByKey(_ context.Context, key string) (*models.APIClient, error) {
+	return f.GetAPIClientByName(context.Background(), "key:"+key)
+}
+
+func signJWT(t *testing.T, appName, secret string) string {
+	t.Helper()
+	return signJWTExpiring(t, appName, secret, gojwt.NewNumericDate(time.Now().Add(time.Hour)))
+}
+
+func signJWTExpiring(t *testing.T, appName, secret string, expiresAt *gojwt.NumericDate) string {
+	t.Helper()
+	claims := jwt.Claims{AppName: appName, RegisteredClaims: gojwt.RegisteredClaims{ExpiresAt: expiresAt}}
+	token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestAuth(t *testing.T) {
+	bot := &models.APIClient{Name: "bot", Scopes: []models.APIClientScope{models.APIClientScopeRead}}
+	admin := &models.APIClient{Name: "admin", Scopes: []models.APIClientScope{models.APIClientScopeAdmin}}
+	clients := fakeClients{"bot": bot, "key:admin-key": admin}
+
+	e := echo.New()
+	e.Use(auth.New(clients, jwtSecret))
+	whoami := func(c echo.Context) error { return c.String(http.StatusOK, auth.ClientName(c)) }
+	e.GET("/schedule", whoami, auth.Allow(models.APIClientScopeRead))
+	e.GET("/admin", whoami, auth.Require(models.APIClientScopeAdmin))
+
+	for _, test := range []struct {
+		name          string
+		path          string
+		authorization string
+		status        int
+		client        string
+	}{
+		{"anonymous read", "/schedule", "", http.StatusOK, ""},
+		{"basic auth stays anonymous", "/schedule", "Basic dXNlcjpwYXNz", http.StatusOK, ""},
+		{"jwt client", "/schedule", "Bearer " + signJWT(t, "bot", jwtSecret), http.StatusOK, "bot"},
+		{"api key client", "/admin", "Bearer admin-key", http.StatusOK, "admin"},
+		{"anonymous admin", "/admin", "", http.StatusUnauthorized, ""},
+		{"wrong jwt secret", "/schedule", "Bearer " + signJWT(t, "bot", "other"), http.StatusUnauthorized, ""},
+		{"unknown jwt client", "/schedule", "Bearer " + signJWT(t, "unknown", jwtSecret), http.StatusUnauthorized, ""},
+		{"jwt without exp", "/schedule", "Bearer " + signJWTExpiring(t, "bot", jwtSecret, nil), http.StatusUnauthorized, ""},
+		{
+			"expired jwt", "/schedule",
+			"Bearer " + signJWTExpiring(t, "bot", jwtSecret, gojwt.NewNumericDate(time.Now().Add(-time.Minute))),
+			http.StatusUnauthorized, "",
+		},
+		{"unknown key", "/schedule", "Bearer wrong-key", http.StatusUnauthorized, ""},
+		{"missing admin scope", "/admin", "Bearer " + signJWT(t, "bot", jwtSecret), http.StatusForbidden, ""},
+		{"missing read scope", "/schedule", "Bearer admin-key", http.StatusForbidden, ""},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.authorization != "" {
+				request.Header.Set(echo.HeaderAuthorization, test.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Fatalf("status %v, expected %v: %s", recorder.Code, test.status, recorder.Body)
+			}
+			if test.status == http.StatusOK && recorder.Body.String() != test.client {
+				t.Errorf("client %q, expected %q", recorder.Body, test.client)
+			}
+		})
+	}
+}
+
+func TestAuthWithoutJWTSecret(t *testing.T) {
+	e := echo.New()
+	e.Use(auth.New(fakeClients{"bot": {Name: "bot"}}, ""))
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	// Без секрета токен, подписанный пустым ключом, не должен проходить.
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+signJWT(t, "bot", ""))
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("status %v, expected 401", recorder.Code)
+	}
+}
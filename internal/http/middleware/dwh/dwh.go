@@ -1,46 +1,84 @@
 package dwh
 
 import (
+	"errors"
+	"net/http"
 	"strings"
-
-	logger2 "github.com/schedule-rsreu/schedule-api/pkg/logger"
+	"time"
 
 	"github.com/labstack/echo/v4"
-	"github.com/schedule-rsreu/schedule-api/pkg/auth/jwt"
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
 )
 
-func getBearerClaims(c echo.Context, bearerSecret string) (*jwt.Claims, bool) {
-	authHeader := c.Request().Header.Get("authorization")
-
-	if authHeader == "" {
-		return nil, false
-	}
-
-	authParts := strings.Split(authHeader, " ")
-
-	if len(authParts) != 2 || authParts[0] != "Bearer" {
-		return nil, false
-	}
-
-	claims, err := jwt.ParseJWT(authParts[1], []byte(bearerSecret))
+// Event is the analytics record of a request.
+type Event struct {
+	Time         time.Time `json:"time"`
+	RequestID    string    `json:"request_id"`
+	Method       string    `json:"method"`
+	Route        string    `json:"route"`
+	Status       int       `json:"status"`
+	LatencyMs    float64   `json:"latency_ms"`
+	Client       string    `json:"client,omitempty"`
+	Group        string    `json:"group,omitempty"`
+	Groups       []string  `json:"groups,omitempty"`
+	TeacherID    string    `json:"teacher_id,omitempty"`
+	AuditoriumID string    `json:"auditorium_id,omitempty"`
+}
 
-	if err != nil {
-		return nil, false
-	}
+// GroupsCtxKey holds the groups requested in the body, the middleware does not read request bodies.
+const GroupsCtxKey = "dwh_groups"
 
-	return claims, true
+// SetGroups records the groups of a request with several groups.
+func SetGroups(c echo.Context, groups []string) {
+	c.Set(GroupsCtxKey, groups)
 }
 
-func New(dwhURL, dwhToken, bearerSecret string) echo.MiddlewareFunc {
+// New records every request to the exporter: the route pattern, the requested group, teacher or auditorium,
+// the client identified by the auth middleware, latency and status.
+func New(exporter *Exporter) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			if strings.Contains(c.Request().RequestURI, "/ping") || strings.Contains(c.Request().RequestURI, "/metrics") {
 				return next(c)
 			}
-			logger := logger2.GetLoggerFromCtx(c)
-			claims, ok := getBearerClaims(c, bearerSecret)
-			logger.Info().Any("claims", claims).Bool("ok", ok).Msg("dwh middleware")
-			return next(c)
+
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				var httpError *echo.HTTPError
+				if errors.As(err, &httpError) {
+					status = httpError.Code
+				} else {
+					status = http.StatusInternalServerError
+				}
+			}
+
+			groups, _ := c.Get(GroupsCtxKey).([]string)
+			exporter.Enqueue(Event{
+				Time:         start,
+				RequestID:    c.Response().Header().Get(echo.HeaderXRequestID),
+				Method:       c.Request().Method,
+				Route:        c.Path(),
+				Status:       status,
+				LatencyMs:    float64(time.Since(start).Microseconds()) / 1000,
+				Client:       auth.ClientName(c),
+				Group:        firstNonEmpty(c.Param("group"), c.QueryParam("group")),
+				Groups:       groups,
+				TeacherID:    firstNonEmpty(c.Param("teacher_id"), c.QueryParam("teacher_id")),
+				AuditoriumID: firstNonEmpty(c.Param("auditorium_id"), c.QueryParam("auditorium_id")),
+			})
+			return err
+		}
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
 		}
 	}
+	return ""
 }
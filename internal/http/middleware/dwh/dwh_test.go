@@ -0,0 +1,253 @@
+package dwh_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/dwh"
+)
+
+var errUnavailable = errors.New("unavailable")
+
+type recordingSink struct {
+	mu       sync.Mutex
+	batches  [][]dwh.Event
+	failures int
+	block    chan struct{}
+}
+
+func (s *recordingSink) Send(ctx context.Context, events []dwh.Event) error {
+	if s.block != nil {
+		select {
+		case <-s.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errUnavailable
+	}
+	s.batches = append(s.batches, append([]dwh.Event(nil), events...))
+	return nil
+}
+
+func (s *recordingSink) events() []dwh.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var events []dwh.Event
+	for _, batch := range s.batches {
+		events = append(events, batch...)
+	}
+	return events
+}
+
+func newExporter(sink dwh.Sink, options dwh.Options) *dwh.Exporter {
+	logger := zerolog.Nop()
+	return dwh.NewExporter(sink, options, &logger, prometheus.NewRegistry())
+}
+
+func TestExporterBatchesAndFlushesOnClose(t *testing.T) {
+	sink := &recordingSink{}
+	exporter := newExporter(sink, dwh.Options{BatchSize: 2, FlushInterval: time.Hour})
+
+	for _, route := range []string{"/a", "/b", "/c"} {
+		if !exporter.Enqueue(dwh.Event{Route: route}) {
+			t.Fatalf("event %v dropped", route)
+		}
+	}
+	if err := exporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sink.batches) != 2 || len(sink.batches[0]) != 2 || len(sink.batches[1]) != 1 {
+		t.Errorf("unexpected batches %+v", sink.batches)
+	}
+	if exporter.Enqueue(dwh.Event{Route: "/late"}) {
+		t.Error("closed exporter accepted an event")
+	}
+}
+
+func TestExporterRetries(t *testing.T) {
+	sink := &recordingSink{failures: 2}
+	exporter := newExporter(sink, dwh.Options{RetryDelay: time.Millisecond})
+
+	exporter.Enqueue(dwh.Event{Route: "/a"})
+	if err := exporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if events := sink.events(); len(events) != 1 {
+		t.Errorf("expected the event after retries, got %+v", events)
+	}
+}
+
+func TestExporterDropsWhenFull(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	logger := zerolog.Nop()
+	sink := &recordingSink{block: make(chan struct{})}
+	exporter := dwh.NewExporter(sink, dwh.Options{QueueSize: 2, BatchSize: 1}, &logger, registry)
+
+	accepted := 0
+	for range 10 {
+		if exporter.Enqueue(dwh.Event{}) {
+			accepted++
+		}
+	}
+	// Один батч у заблокированного sink и два события в очереди.
+	if accepted > 3 {
+		t.Errorf("bounded queue accepted %v events", accepted)
+	}
+	if dropped := counterValue(t, registry, "dwh_events_dropped_total"); dropped != float64(10-accepted) {
+		t.Errorf("dropped %v, expected %v", dropped, 10-accepted)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := exporter.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the shutdown deadline, got %v", err)
+	}
+}
+
+func counterValue(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	t.Fatalf("metric %v not found", name)
+	return 0
+}
+
+func TestMiddlewareRecordsRequest(t *testing.T) {
+	sink := &recordingSink{}
+	exporter := newExporter(sink, dwh.Options{})
+
+	e := echo.New()
+	e.Use(dwh.New(exporter))
+	e.GET("/api/v1/schedule/groups/:group", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/api/v1/schedule/auditoriums/:auditorium_id", func(echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound)
+	})
+	e.GET("/ping", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	for _, path := range []string{"/api/v1/schedule/groups/344", "/api/v1/schedule/auditoriums/7", "/ping"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	if err := exporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	events := sink.events()
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if events[0].Route != "/api/v1/schedule/groups/:group" || events[0].Group != "344" || events[0].Status != http.StatusOK {
+		t.Errorf("unexpected group event %+v", events[0])
+	}
+	if events[1].AuditoriumID != "7" || events[1].Status != http.StatusNotFound {
+		t.Errorf("unexpected auditorium event %+v", events[1])
+	}
+}
+
+func TestMiddlewareRecordsQueryParams(t *testing.T) {
+	sink := &recordingSink{}
+	exporter := newExporter(sink, dwh.Options{})
+
+	e := echo.New()
+	e.Use(dwh.New(exporter))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/api/v1/schedule/teachers", ok)
+	e.GET("/api/v1/schedule/auditoriums", ok)
+	e.POST("/api/v1/schedule/groups/sample", func(c echo.Context) error {
+		dwh.SetGroups(c, []string{"344", "345"})
+		return c.NoContent(http.StatusOK)
+	})
+
+	for _, request := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/schedule/teachers?teacher_id=12", nil),
+		httptest.NewRequest(http.MethodGet, "/api/v1/schedule/auditoriums?auditorium_id=7", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/schedule/groups/sample", strings.NewReader(`{"groups":["344","345"]}`)),
+	} {
+		e.ServeHTTP(httptest.NewRecorder(), request)
+	}
+	if err := exporter.Close(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	events := sink.events()
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %+v", events)
+	}
+	if events[0].TeacherID != "12" {
+		t.Errorf("unexpected teacher event %+v", events[0])
+	}
+	if events[1].AuditoriumID != "7" {
+		t.Errorf("unexpected auditorium event %+v", events[1])
+	}
+	if strings.Join(events[2].Groups, ",") != "344,345" {
+		t.Errorf("unexpected groups event %+v", events[2])
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buffer bytes.Buffer
+	sink := dwh.NewWriterSink(&buffer)
+	if err := sink.Send(context.Background(), []dwh.Event{{Route: "/a"}, {Route: "/b"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected JSON lines, got %q", buffer.String())
+	}
+	var event dwh.Event
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil || event.Route != "/b" {
+		t.Errorf("unexpected line %q: %v", lines[1], err)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var received []dwh.Event
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	sink, _, err := dwh.NewSink(server.URL, "dwh-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Send(context.Background(), []dwh.Event{{Route: "/a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 || authorization != "Bearer dwh-token" {
+		t.Errorf("unexpected request %q %+v", authorization, received)
+	}
+
+	if _, _, err := dwh.NewSink("ftp://dwh", ""); err == nil {
+		t.Error("expected an unsupported DWH_URL error")
+	}
+}
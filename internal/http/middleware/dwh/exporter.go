@@ -0,0 +1,182 @@
+package dwh
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+// Options tune the exporter, zero values take the defaults.
+type Options struct {
+	QueueSize     int
+	BatchSize     int
+	FlushInterval time.Duration
+	Retries       int
+	RetryDelay    time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.QueueSize <= 0 {
+		o.QueueSize = 10000
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 500
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 5 * time.Second
+	}
+	if o.Retries <= 0 {
+		o.Retries = 3
+	}
+	if o.RetryDelay <= 0 {
+		o.RetryDelay = time.Second
+	}
+	return o
+}
+
+// Exporter sends request events to the sink in batches from a bounded queue.
+// Events are dropped when the queue is full, so a slow DWH never slows requests down.
+type Exporter struct {
+	sink    Sink
+	options Options
+	logger  *zerolog.Logger
+	queue   chan Event
+
+	mu     sync.RWMutex
+	closed bool
+
+	ctx    context.Context //nolint:containedctx // cancels sending when the shutdown deadline passes
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	dropped prometheus.Counter
+	sent    prometheus.Counter
+	failed  prometheus.Counter
+}
+
+func NewExporter(sink Sink, options Options, logger *zerolog.Logger, registerer prometheus.Registerer) *Exporter {
+	options = options.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &Exporter{
+		sink:    sink,
+		options: options,
+		logger:  logger,
+		queue:   make(chan Event, options.QueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dwh_events_dropped_total",
+			Help: "Request events dropped because the DWH queue was full or closed.",
+		}),
+		sent: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dwh_events_sent_total",
+			Help: "Request events stored in the DWH.",
+		}),
+		failed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "dwh_events_failed_total",
+			Help: "Request events lost after all retries.",
+		}),
+	}
+	registerer.MustRegister(e.dropped, e.sent, e.failed, prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "dwh_queue_length",
+		Help: "Request events waiting to be sent to the DWH.",
+	}, func() float64 { return float64(len(e.queue)) }))
+
+	go e.run()
+	return e
+}
+
+// Enqueue never blocks, it returns false when the event was dropped.
+func (e *Exporter) Enqueue(event Event) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		e.dropped.Inc()
+		return false
+	}
+	select {
+	case e.queue <- event:
+		return true
+	default:
+		e.dropped.Inc()
+		return false
+	}
+}
+
+// Close stops accepting events and flushes the queue, sending is canceled when ctx is done.
+func (e *Exporter) Close(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.queue)
+	}
+	e.mu.Unlock()
+
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		e.cancel()
+		<-e.done
+		return ctx.Err()
+	}
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+	defer e.cancel()
+
+	ticker := time.NewTicker(e.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, e.options.BatchSize)
+	for {
+		select {
+		case event, ok := <-e.queue:
+			if !ok {
+				e.flush(batch)
+				return
+			}
+			batch = append(batch, event)
+			if len(batch) >= e.options.BatchSize {
+				e.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			e.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (e *Exporter) flush(batch []Event) {
+	if len(batch) == 0 {
+		return
+	}
+
+	delay := e.options.RetryDelay
+	for attempt := 1; ; attempt++ {
+		err := e.sink.Send(e.ctx, batch)
+		if err == nil {
+			e.sent.Add(float64(len(batch)))
+			return
+		}
+		if attempt >= e.options.Retries || e.ctx.Err() != nil {
+			e.failed.Add(float64(len(batch)))
+			e.logger.Error().Err(err).Int("events", len(batch)).Int("attempts", attempt).Msg("dwh - send")
+			return
+		}
+		e.logger.Warn().Err(err).Int("attempt", attempt).Msg("dwh - send, retrying")
+
+		select {
+		case <-e.ctx.Done():
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
@@ -0,0 +1,106 @@
+package dwh
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	sinkStdout      = "stdout"
+	sinkFilePrefix  = "file://"
+	httpSinkTimeout = 10 * time.Second
+)
+
+var ErrSinkStatus = errors.New("dwh responded with non-2xx status")
+
+// Sink stores a batch of request events.
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+// NewSink returns the sink for dwhURL: an http(s) DWH endpoint, "stdout" or file:///path for local runs.
+// Files are appended as JSON lines and closed by the returned closer.
+func NewSink(dwhURL, dwhToken string) (Sink, io.Closer, error) {
+	switch {
+	case dwhURL == sinkStdout:
+		return NewWriterSink(os.Stdout), io.NopCloser(nil), nil
+	case strings.HasPrefix(dwhURL, sinkFilePrefix):
+		file, err := os.OpenFile(strings.TrimPrefix(dwhURL, sinkFilePrefix), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open dwh file: %w", err)
+		}
+		return NewWriterSink(file), file, nil
+	case strings.HasPrefix(dwhURL, "http://"), strings.HasPrefix(dwhURL, "https://"):
+		return &HTTPSink{URL: dwhURL, Token: dwhToken, Client: &http.Client{Timeout: httpSinkTimeout}}, io.NopCloser(nil), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported DWH_URL %q, expected http(s) URL, stdout or file://", dwhURL)
+	}
+}
+
+// HTTPSink POSTs batches as a JSON array.
+type HTTPSink struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+func (s *HTTPSink) Send(ctx context.Context, events []Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if s.Token != "" {
+		request.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+
+	response, err := s.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("%w: %v", ErrSinkStatus, response.StatusCode)
+	}
+	return nil
+}
+
+// WriterSink writes events as JSON lines.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Send(_ context.Context, events []Event) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for index := range events {
+		if err := encoder.Encode(&events[index]); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.w.Write(buffer.Bytes())
+	return err
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
 )
 
 const (
@@ -16,6 +18,7 @@ const (
 	latencyHighrName = "http_request_duration_highr_seconds"
 	latencyLowrName  = "http_request_duration_seconds"
 	serviceLabel     = "service"
+	anonymousClient  = "anonymous"
 )
 
 // Middleware is a handler that exposes prometheus metrics for the number of requests,
@@ -37,10 +40,10 @@ func NewPatternMiddleware(name string) echo.MiddlewareFunc {
 	m.reqs = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name:        reqsName,
-			Help:        "How many HTTP requests processed, partitioned by status code, method and HTTP path (with patterns).",
+			Help:        "How many HTTP requests processed, partitioned by status code, method, HTTP path (with patterns) and API client.",
 			ConstLabels: prometheus.Labels{serviceLabel: name},
 		},
-		[]string{"handler", "method", "status"},
+		[]string{"handler", "method", "status", "client"},
 	)
 	prometheus.MustRegister(m.reqs)
 
@@ -95,8 +98,13 @@ func (m Middleware) patternHandler(next echo.HandlerFunc) echo.HandlerFunc {
 
 		duration := time.Since(start).Seconds()
 
+		client := auth.ClientName(c)
+		if client == "" {
+			client = anonymousClient
+		}
+
 		// Update Prometheus metrics
-		m.reqs.WithLabelValues(routePattern, c.Request().Method, status).Inc()
+		m.reqs.WithLabelValues(routePattern, c.Request().Method, status, client).Inc()
 		m.latencyHighr.WithLabelValues().Observe(duration)
 		m.latencyLowr.WithLabelValues(routePattern, c.Request().Method, status).Observe(duration)
 
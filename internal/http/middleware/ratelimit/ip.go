@@ -0,0 +1,34 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// IPExtractor returns the client IP extractor for the CIDRs of the proxies separated by ",".
+// X-Forwarded-For is read only from the trusted proxies, the client cannot pick its IP and so its bucket.
+// Without proxies the remote address is used.
+func IPExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	var ranges []echo.TrustOption
+	for item := range strings.SplitSeq(trustedProxies, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		_, network, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", item, err)
+		}
+		ranges = append(ranges, echo.TrustIPRange(network))
+	}
+	if len(ranges) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := append([]echo.TrustOption{
+		echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false),
+	}, ranges...)
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
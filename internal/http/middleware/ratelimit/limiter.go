@@ -0,0 +1,212 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	anyRoute      = "*"
+	sweepInterval = time.Minute
+	maxBuckets    = 100_000
+)
+
+var ErrInvalidRule = errors.New("invalid rate limit rule")
+
+// Rule limits requests to a route pattern, an empty Method matches any method and the "*" route matches
+// the routes without their own rule. Requests refill evenly over Period, up to Requests at once.
+type Rule struct {
+	Method   string
+	Route    string
+	Requests int
+	Period   time.Duration
+}
+
+// ParseRules parses rules separated by ";" in the form "[METHOD ]route=requests/period",
+// for example "*=600/1m;POST /api/v1/schedule/groups/sample=20/1m".
+func ParseRules(spec string) ([]Rule, error) {
+	var rules []Rule
+	for item := range strings.SplitSeq(spec, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route, limit, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w %q: expected route=requests/period", ErrInvalidRule, item)
+		}
+
+		var rule Rule
+		route = strings.TrimSpace(route)
+		if method, path, ok := strings.Cut(route, " "); ok {
+			rule.Method, route = strings.ToUpper(method), strings.TrimSpace(path)
+		}
+		rule.Route = route
+
+		requests, period, _ := strings.Cut(strings.TrimSpace(limit), "/")
+		var err error
+		if rule.Requests, err = strconv.Atoi(requests); err != nil || rule.Requests <= 0 {
+			return nil, fmt.Errorf("%w %q: requests must be a positive integer", ErrInvalidRule, item)
+		}
+		if period != "" && (period[0] < '0' || period[0] > '9') {
+			period = "1" + period
+		}
+		if rule.Period, err = time.ParseDuration(period); err != nil || rule.Period <= 0 {
+			return nil, fmt.Errorf("%w %q: period must be a duration like 1m", ErrInvalidRule, item)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Result is the state of the bucket after a request.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is when the bucket is full again, RetryAfter is when the next request is allowed.
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+type bucketKey struct {
+	rule     *Rule
+	identity string
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// Limiter keeps a token bucket per rule and client, at most MaxBuckets of them.
+type Limiter struct {
+	rules      []Rule
+	Now        func() time.Time
+	MaxBuckets int
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(rules []Rule) *Limiter {
+	return &Limiter{
+		rules:      rules,
+		Now:        time.Now,
+		MaxBuckets: maxBuckets,
+		buckets:    map[bucketKey]*bucket{},
+	}
+}
+
+// Rule returns the rule of the route, nil when the route is not limited.
+func (l *Limiter) Rule(method, route string) *Rule {
+	var routeRule, defaultRule *Rule
+	for index := range l.rules {
+		rule := &l.rules[index]
+		switch {
+		case rule.Route == route && rule.Method == method:
+			return rule
+		case rule.Route == route && rule.Method == "" && routeRule == nil:
+			routeRule = rule
+		case rule.Route == anyRoute && defaultRule == nil:
+			defaultRule = rule
+		}
+	}
+	if routeRule != nil {
+		return routeRule
+	}
+	return defaultRule
+}
+
+// Allow takes a token from the bucket of the identity.
+func (l *Limiter) Allow(rule *Rule, identity string) Result {
+	return l.take(rule, identity, true)
+}
+
+// Peek returns what Allow would without taking a token.
+func (l *Limiter) Peek(rule *Rule, identity string) Result {
+	return l.take(rule, identity, false)
+}
+
+func (l *Limiter) take(rule *Rule, identity string, consume bool) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.Now()
+	l.sweep(now)
+
+	capacity := float64(rule.Requests)
+	perSecond := capacity / rule.Period.Seconds()
+
+	key := bucketKey{rule: rule, identity: identity}
+	b, ok := l.buckets[key]
+	if !ok && !consume {
+		return Result{Allowed: true, Limit: rule.Requests, Remaining: rule.Requests}
+	}
+	if !ok {
+		l.makeRoom(now)
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+
+	result := Result{Limit: rule.Requests}
+	if b.tokens >= 1 {
+		if consume {
+			b.tokens--
+		}
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.tokens) / perSecond)
+	}
+	result.Remaining = int(b.tokens)
+	result.Reset = seconds((capacity - b.tokens) / perSecond)
+	return result
+}
+
+// sweep forgets the buckets that are full again, they are the same as new ones.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= key.rule.Period {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// makeRoom keeps the number of buckets under MaxBuckets: the full buckets are forgotten first, then arbitrary ones.
+// A forgotten client gets a full bucket, which is better than growing without bound on many identities.
+func (l *Limiter) makeRoom(now time.Time) {
+	if len(l.buckets) < l.MaxBuckets {
+		return
+	}
+	l.lastSweep = time.Time{}
+	l.sweep(now)
+	for key := range l.buckets {
+		if len(l.buckets) < l.MaxBuckets {
+			break
+		}
+		delete(l.buckets, key)
+	}
+}
+
+// Len returns the number of buckets.
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
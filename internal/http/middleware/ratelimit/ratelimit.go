@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
+)
+
+const (
+	serviceLabel    = "service"
+	anonymousClient = "anonymous"
+)
+
+// New limits API requests by the client identified by the auth middleware or by the remote IP for anonymous requests.
+// Limited routes get RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers, rejected requests get 429
+// with Retry-After and are counted in http_requests_rate_limited_total.
+func New(limiter *Limiter, name string, registerer prometheus.Registerer) echo.MiddlewareFunc {
+	limited := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name:        "http_requests_rate_limited_total",
+		Help:        "How many HTTP requests were rejected by the rate limiter, partitioned by HTTP path (with patterns), method and API client.", //nolint:lll // .
+		ConstLabels: prometheus.Labels{serviceLabel: name},
+	}, []string{"handler", "method", "client"})
+	registerer.MustRegister(limited)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// Документация, метрики и /ping не ограничиваются.
+			if !strings.HasPrefix(c.Path(), "/api/") {
+				return next(c)
+			}
+			method := c.Request().Method
+			rule := limiter.Rule(method, c.Path())
+			if rule == nil {
+				return next(c)
+			}
+
+			client := auth.ClientName(c)
+			identity := "client:" + client
+			if client == "" {
+				identity = "ip:" + c.RealIP()
+			}
+			result := limiter.Allow(rule, identity)
+
+			header := c.Response().Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", ceilSeconds(result.Reset))
+			if result.Allowed {
+				return next(c)
+			}
+
+			if client == "" {
+				client = anonymousClient
+			}
+			limited.WithLabelValues(c.Path(), method, client).Inc()
+			header.Set("Retry-After", ceilSeconds(result.RetryAfter))
+			return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+		}
+	}
+}
+
+// Authentication runs before the auth middleware and limits invalid Bearer tokens by the remote IP: a rejected
+// token takes a token from the IP bucket of the route, and Bearer requests from an IP with an empty bucket get 429
+// before they are authenticated, so tokens cannot be guessed at the speed of the auth middleware.
+func Authentication(limiter *Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scheme, _, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			if scheme != "Bearer" || !strings.HasPrefix(c.Path(), "/api/") {
+				return next(c)
+			}
+			rule := limiter.Rule(c.Request().Method, c.Path())
+			if rule == nil {
+				return next(c)
+			}
+
+			identity := "ip:" + c.RealIP()
+			if result := limiter.Peek(rule, identity); !result.Allowed {
+				c.Response().Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+			}
+
+			err := next(c)
+			var httpErr *echo.HTTPError
+			if auth.Client(c) == nil && errors.As(err, &httpErr) && httpErr.Code == http.StatusUnauthorized {
+				limiter.Allow(rule, identity)
+			}
+			return err
+		}
+	}
+}
+
+func ceilSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
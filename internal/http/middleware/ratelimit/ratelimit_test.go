@@ -0,0 +1,229 @@
+package ratelimit_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/auth"
+	"github.com/schedule-rsreu/schedule-api/internal/http/middleware/ratelimit"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ratelimit.ParseRules(" *=600/1m; POST /api/v1/schedule/groups/sample=20/m ;/api/v1/schedule/auditoriums=5/10s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []ratelimit.Rule{
+		{Route: "*", Requests: 600, Period: time.Minute},
+		{Method: http.MethodPost, Route: "/api/v1/schedule/groups/sample", Requests: 20, Period: time.Minute},
+		{Route: "/api/v1/schedule/auditoriums", Requests: 5, Period: 10 * time.Second},
+	}
+	if len(rules) != len(expected) {
+		t.Fatalf("unexpected rules %+v", rules)
+	}
+	for index := range expected {
+		if rules[index] != expected[index] {
+			t.Errorf("rule %v is %+v, expected %+v", index, rules[index], expected[index])
+		}
+	}
+
+	for _, spec := range []string{"*", "*=0/1m", "*=ten/1m", "*=10/never", "*=10/0s"} {
+		if _, err := ratelimit.ParseRules(spec); !errors.Is(err, ratelimit.ErrInvalidRule) {
+			t.Errorf("%q: expected invalid rule, got %v", spec, err)
+		}
+	}
+}
+
+func TestLimiterRule(t *testing.T) {
+	limiter := ratelimit.NewLimiter([]ratelimit.Rule{
+		{Route: "*", Requests: 100, Period: time.Minute},
+		{Route: "/sample", Requests: 10, Period: time.Minute},
+		{Method: http.MethodPost, Route: "/sample", Requests: 1, Period: time.Minute},
+	})
+	if rule := limiter.Rule(http.MethodPost, "/sample"); rule == nil || rule.Requests != 1 {
+		t.Errorf("unexpected POST rule %+v", rule)
+	}
+	if rule := limiter.Rule(http.MethodGet, "/sample"); rule == nil || rule.Requests != 10 {
+		t.Errorf("unexpected GET rule %+v", rule)
+	}
+	if rule := limiter.Rule(http.MethodGet, "/other"); rule == nil || rule.Requests != 100 {
+		t.Errorf("unexpected default rule %+v", rule)
+	}
+	if rule := ratelimit.NewLimiter(nil).Rule(http.MethodGet, "/other"); rule != nil {
+		t.Errorf("expected no rule, got %+v", rule)
+	}
+}
+
+func TestLimiterRefills(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	rule := &ratelimit.Rule{Route: "*", Requests: 2, Period: 10 * time.Second}
+	limiter := ratelimit.NewLimiter(nil)
+	limiter.Now = func() time.Time { return now }
+
+	for range 2 {
+		if result := limiter.Allow(rule, "ip:1"); !result.Allowed {
+			t.Fatalf("burst request rejected: %+v", result)
+		}
+	}
+	result := limiter.Allow(rule, "ip:1")
+	if result.Allowed || result.Remaining != 0 || result.RetryAfter != 5*time.Second || result.Reset != 10*time.Second {
+		t.Errorf("unexpected rejection %+v", result)
+	}
+	if result := limiter.Allow(rule, "ip:2"); !result.Allowed {
+		t.Error("another identity shares the bucket")
+	}
+
+	now = now.Add(5 * time.Second)
+	if result := limiter.Allow(rule, "ip:1"); !result.Allowed {
+		t.Errorf("request rejected after refill: %+v", result)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	limiter := ratelimit.NewLimiter([]ratelimit.Rule{
+		{Method: http.MethodPost, Route: "/api/v1/schedule/groups/sample", Requests: 1, Period: time.Minute},
+	})
+
+	e := echo.New()
+	e.Use(ratelimit.New(limiter, "test", registry))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/api/v1/schedule/groups/sample", ok)
+	e.GET("/api/v1/schedule/groups", ok)
+
+	send := func(method, path, ip string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, nil)
+		request.RemoteAddr = ip + ":1234"
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	if recorder := send(http.MethodPost, "/api/v1/schedule/groups/sample", "10.0.0.1"); recorder.Code != http.StatusOK ||
+		recorder.Header().Get("RateLimit-Limit") != "1" || recorder.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("unexpected first response %v %v", recorder.Code, recorder.Header())
+	}
+	recorder := send(http.MethodPost, "/api/v1/schedule/groups/sample", "10.0.0.1")
+	if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != "60" ||
+		recorder.Header().Get("RateLimit-Reset") != "60" {
+		t.Errorf("unexpected limited response %v %v", recorder.Code, recorder.Header())
+	}
+	if recorder := send(http.MethodPost, "/api/v1/schedule/groups/sample", "10.0.0.2"); recorder.Code != http.StatusOK {
+		t.Errorf("another IP is limited: %v", recorder.Code)
+	}
+	if recorder := send(http.MethodGet, "/api/v1/schedule/groups", "10.0.0.1"); recorder.Code != http.StatusOK ||
+		recorder.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("route without a rule is limited: %v %v", recorder.Code, recorder.Header())
+	}
+
+	if count := testutil.CollectAndCount(registry, "http_requests_rate_limited_total"); count != 1 {
+		t.Errorf("expected one limited series, got %v", count)
+	}
+}
+
+func TestAuthenticationLimitsInvalidTokens(t *testing.T) {
+	limiter := ratelimit.NewLimiter([]ratelimit.Rule{{Route: "*", Requests: 2, Period: time.Minute}})
+
+	e := echo.New()
+	e.Use(ratelimit.Authentication(limiter))
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Request().Header.Get(echo.HeaderAuthorization) != "Bearer valid" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid bearer token")
+			}
+			c.Set(auth.ClientCtxKey, &models.APIClient{Name: "bot"})
+			return next(c)
+		}
+	})
+	e.Use(ratelimit.New(limiter, "test", prometheus.NewRegistry()))
+	e.GET("/api/v1/schedule/groups", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	send := func(ip, token string) int {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/schedule/groups", nil)
+		request.RemoteAddr = ip + ":1234"
+		request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	for range 2 {
+		if code := send("10.0.0.1", "guess"); code != http.StatusUnauthorized {
+			t.Fatalf("expected 401 for an invalid token, got %v", code)
+		}
+	}
+	if code := send("10.0.0.1", "guess"); code != http.StatusTooManyRequests {
+		t.Errorf("expected invalid tokens to exhaust the IP bucket, got %v", code)
+	}
+	if code := send("10.0.0.1", "valid"); code != http.StatusTooManyRequests {
+		t.Errorf("expected the IP to be limited before authentication, got %v", code)
+	}
+	for range 2 {
+		if code := send("10.0.0.2", "valid"); code != http.StatusOK {
+			t.Errorf("a valid client from another IP is limited: %v", code)
+		}
+	}
+}
+
+func TestMiddlewareIgnoresSpoofedForwardedFor(t *testing.T) {
+	limiter := ratelimit.NewLimiter([]ratelimit.Rule{{Route: "*", Requests: 1, Period: time.Minute}})
+	extractor, err := ratelimit.IPExtractor("10.0.0.0/8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	e.IPExtractor = extractor
+	e.Use(ratelimit.New(limiter, "test", prometheus.NewRegistry()))
+	e.GET("/api/v1/schedule/groups", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	send := func(remoteAddr, forwardedFor string) int {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/schedule/groups", nil)
+		request.RemoteAddr = remoteAddr + ":1234"
+		request.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		request.Header.Set(echo.HeaderXRealIP, forwardedFor)
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	if code := send("203.0.113.5", "198.51.100.1"); code != http.StatusOK {
+		t.Fatalf("unexpected first response %v", code)
+	}
+	if code := send("203.0.113.5", "198.51.100.2"); code != http.StatusTooManyRequests {
+		t.Errorf("a direct client got a new bucket by rotating X-Forwarded-For: %v", code)
+	}
+
+	if code := send("10.0.0.1", "198.51.100.3, 192.0.2.7"); code != http.StatusOK {
+		t.Fatalf("unexpected response through the proxy %v", code)
+	}
+	if code := send("10.0.0.1", "198.51.100.4, 192.0.2.7"); code != http.StatusTooManyRequests {
+		t.Errorf("a client behind the proxy got a new bucket by prepending X-Forwarded-For: %v", code)
+	}
+
+	if _, err := ratelimit.IPExtractor("10.0.0.0/33"); err == nil {
+		t.Error("expected an invalid CIDR error")
+	}
+}
+
+func TestLimiterCapsBuckets(t *testing.T) {
+	rule := &ratelimit.Rule{Route: "*", Requests: 1, Period: time.Minute}
+	limiter := ratelimit.NewLimiter(nil)
+	limiter.MaxBuckets = 10
+
+	for index := range 100 {
+		limiter.Allow(rule, fmt.Sprintf("ip:%d", index))
+	}
+	if count := limiter.Len(); count > 10 {
+		t.Errorf("expected at most 10 buckets, got %v", count)
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+type AcademicPeriodKind string
+
+const (
+	AcademicPeriodSemester    AcademicPeriodKind = "semester"
+	AcademicPeriodHolidays    AcademicPeriodKind = "holidays"
+	AcademicPeriodExamSession AcademicPeriodKind = "exam_session"
+	AcademicPeriodPractice    AcademicPeriodKind = "practice"
+)
+
+// AcademicPeriod is a semester, holidays, an exam session or a practice, dates are inclusive.
+// Semesters have the numerator anchor: Monday of a numerator week.
+type AcademicPeriod struct {
+	Kind           AcademicPeriodKind `json:"kind"                      enums:"semester,holidays,exam_session,practice" example:"semester"`                   //nolint:lll // there is no way to fix it
+	Title          string             `json:"title"                                                                     example:"Весенний семестр 2025/2026"` //nolint:lll // there is no way to fix it
+	StartDate      string             `json:"start_date"                                                                example:"2026-02-09"`
+	EndDate        string             `json:"end_date"                                                                  example:"2026-06-30"`
+	NumeratorStart string             `json:"numerator_start,omitempty"                                                 example:"2026-02-09"`
+	Id             int                `json:"id"                                                                        example:"1"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+type APIClientScope string
+
+const (
+	APIClientScopeRead     APIClientScope = "read"
+	APIClientScopeAdmin    APIClientScope = "admin"
+	APIClientScopeWebhooks APIClientScope = "webhooks"
+)
+
+// APIClient is an issued client, it authenticates with its API key or a JWT with the name as app_name.
+// Key is returned only when the client is created or the key is rotated.
+type APIClient struct {
+	Name      string           `json:"name"                                      example:"telegram-bot"`
+	Scopes    []APIClientScope `json:"scopes"        enums:"read,admin,webhooks" example:"read,webhooks"`
+	Active    bool             `json:"active"                                    example:"true"`
+	Key       string           `json:"key,omitempty"                             example:"Yk3mJ0ZVbqzQ1o7b5b6k9pZ0JqgC2pXc3q2-7wEjRQ2"`
+	CreatedAt time.Time        `json:"created_at"`
+	Id        int              `json:"id"                                        example:"1"`
+}
+
+func (c *APIClient) HasScope(scope APIClientScope) bool {
+	return slices.Contains(c.Scopes, scope)
+}
@@ -8,15 +8,17 @@ type Auditorium struct {
 }
 
 type AuditoriumLesson struct {
-	Time      string               `json:"time"      bson:"time"      example:"08.10-09.45"`
-	Date      string               `json:"date"      bson:"date"      example:"2025-06-18"`
-	Type      string               `json:"type"      bson:"type"      example:"lab,practice"`
-	Lesson    string               `json:"lesson"    bson:"lesson"    example:"Лек. Высшая математика\nКонюхов А.Н. 333 С"` //nolint:lll // there is no way to fix it
-	Title     string               `json:"title"     bson:"title"     example:"Высшая математика"`                          //nolint:lll // there is no way to fix it
-	Faculties []string             `json:"faculties" bson:"faculties" example:"фаиту,фвт"`
-	Groups    []string             `json:"groups"    bson:"groups"    example:"344,345"`
-	Courses   []int                `json:"courses"   bson:"courses"   example:"1"`
-	Teachers  []StudentTeacherInfo `json:"teachers"  bson:"teachers"`
+	Time       string               `json:"time"        bson:"time"      example:"08.10-09.45"`
+	PairNumber int                  `json:"pair_number"                  example:"1"`
+	Date       string               `json:"date"        bson:"date"      example:"2025-06-18"`
+	Type       string               `json:"type"        bson:"type"      example:"lab,practice"`
+	Lesson     string               `json:"lesson"      bson:"lesson"    example:"Лек. Высшая математика\nКонюхов А.Н. 333 С"` //nolint:lll // there is no way to fix it
+	Title      string               `json:"title"       bson:"title"     example:"Высшая математика"`                          //nolint:lll // there is no way to fix it
+	Faculties  []string             `json:"faculties"   bson:"faculties" example:"фаиту,фвт"`
+	Groups     []string             `json:"groups"      bson:"groups"    example:"344,345"`
+	Courses    []int                `json:"courses"     bson:"courses"   example:"1"`
+	Teachers   []StudentTeacherInfo `json:"teachers"    bson:"teachers"`
+	IsHoliday  bool                 `json:"is_holiday"                   example:"false"`
 }
 
 type AuditoriumWeek Week[AuditoriumLesson]
@@ -25,8 +27,36 @@ type AuditoriumSchedule struct {
 	NumeratorPeriod   string                               `json:"numerator_period"   bson:"numerator_period"   example:"16.06-22.06"`
 	DenominatorPeriod string                               `json:"denominator_period" bson:"denominator_period" example:"09.06-15.06"`
 	InputWeekType     string                               `json:"input_week_type"                              example:"numerator"`
-	Schedule          NumeratorDenominator[AuditoriumWeek] `json:"schedule"           bson:"schedule"`
+	Schedule          NumeratorDenominator[AuditoriumWeek] `json:"schedule,omitzero"  bson:"schedule"`
+	Grid              *ScheduleGrid[AuditoriumLesson]      `json:"grid,omitempty"`
 	Auditorium        Auditorium                           `json:"auditorium"         bson:"auditorium"`
+	Holidays          []Holiday                            `json:"holidays"`
 }
 
 type AuditoriumsList []Auditorium
+
+// LessonSlot is a pair of the lesson time grid, the same times as in lessons_times.
+type LessonSlot struct {
+	Time       string `json:"time"        example:"08.10-09.45"`
+	Start      string `json:"start"       example:"08:10"`
+	End        string `json:"end"         example:"09:45"`
+	PairNumber int    `json:"pair_number" example:"1"`
+}
+
+// AuditoriumOccupancy is an auditorium with the lessons held in it on a date.
+type AuditoriumOccupancy struct {
+	Auditorium Auditorium   `json:"auditorium"`
+	Busy       []LessonSlot `json:"busy"`
+}
+
+type FreeAuditorium struct {
+	Auditorium
+	FreeSlots int `json:"free_slots" example:"2"`
+}
+
+type FreeAuditoriums struct {
+	Date        string           `json:"date"        example:"2025-06-18"`
+	WeekType    string           `json:"week_type"   example:"numerator"`
+	Slots       []LessonSlot     `json:"slots"`
+	Auditoriums []FreeAuditorium `json:"auditoriums"`
+}
@@ -14,6 +14,24 @@ type NumeratorDenominator[TWeek StudentWeek | TeacherWeek | AuditoriumWeek] stru
 	Denominator TWeek `json:"denominator" bson:"denominator"`
 }
 
+// GridCell is a pair of the bell schedule on a day, without lessons when it is free and with several for subgroups.
+type GridCell[TLesson StudentLesson | TeacherLesson | AuditoriumLesson] struct {
+	Bell
+	Lessons []TLesson `json:"lessons"`
+}
+
+// GridDay is a day of the week with a cell for every pair of the bell schedule.
+type GridDay[TLesson StudentLesson | TeacherLesson | AuditoriumLesson] struct {
+	Day   string              `json:"day"   enums:"monday,tuesday,wednesday,thursday,friday,saturday" example:"monday"`
+	Cells []GridCell[TLesson] `json:"cells"`
+}
+
+// ScheduleGrid is a week schedule laid out as a day × pair matrix.
+type ScheduleGrid[TLesson StudentLesson | TeacherLesson | AuditoriumLesson] struct {
+	Numerator   []GridDay[TLesson] `json:"numerator"`
+	Denominator []GridDay[TLesson] `json:"denominator"`
+}
+
 type LessonType struct {
 	Type        string `json:"type"        example:"lab"`
 	Description string `json:"description" example:"лабораторная"`
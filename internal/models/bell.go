@@ -0,0 +1,25 @@
+package models
+
+// Bell is a pair of the bell schedule with the five minute break in its middle.
+// Lessons starting off the schedule have pair_number 0.
+type Bell struct {
+	Time       string `json:"time"        example:"08.10-09.45"`
+	Start      string `json:"start"       example:"08:10"`
+	End        string `json:"end"         example:"09:45"`
+	BreakStart string `json:"break_start" example:"08:55"`
+	BreakEnd   string `json:"break_end"   example:"09:00"`
+	Number     int    `json:"number"      example:"1"`
+}
+
+// BellBreak is the break between a pair and the next one.
+type BellBreak struct {
+	Start   string `json:"start"   example:"09:45"`
+	End     string `json:"end"     example:"09:55"`
+	After   int    `json:"after"   example:"1"`
+	Minutes int    `json:"minutes" example:"10"`
+}
+
+type BellSchedule struct {
+	Bells  []Bell      `json:"bells"`
+	Breaks []BellBreak `json:"breaks"`
+}
@@ -1,7 +1,10 @@
 package models
 
 type Building struct {
-	Title  string `json:"title"  example:"Центральный корпус"`
-	Letter string `json:"letter" example:"C"`
-	Id     int    `json:"id"     example:"1"`
+	Title     string   `json:"title"               example:"Центральный корпус"`
+	Letter    string   `json:"letter"              example:"C"`
+	Address   string   `json:"address,omitempty"   example:"ул. Гагарина, 59/1"`
+	Latitude  *float64 `json:"latitude,omitempty"  example:"54.6132708"`
+	Longitude *float64 `json:"longitude,omitempty" example:"39.7236472"`
+	Id        int      `json:"id"                  example:"1"`
 }
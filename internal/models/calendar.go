@@ -2,25 +2,60 @@ package models
 
 import "time"
 
+// CalendarOwner is the kind of entity a calendar feed is built for.
+type CalendarOwner string
+
+const (
+	CalendarOwnerGroup      CalendarOwner = "group"
+	CalendarOwnerTeacher    CalendarOwner = "teacher"
+	CalendarOwnerAuditorium CalendarOwner = "auditorium"
+)
+
 type CalendarTeacherAuditorium struct {
 	Teacher    string `json:"teacher"`
 	Auditorium string `json:"auditorium"`
 }
 
+type CalendarBuilding struct {
+	Letter    string   `json:"letter"`
+	Title     string   `json:"title"`
+	Address   string   `json:"address"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+}
+
 type CalendarEvent struct {
 	UID                string                      `json:"uid"`
 	StartTime          time.Time                   `json:"start_time"`
 	EndTime            time.Time                   `json:"end_time"`
 	Title              string                      `json:"title"`
 	LessonType         string                      `json:"lesson_type"`
+	Groups             []string                    `json:"groups"`
 	TeacherAuditoriums []CalendarTeacherAuditorium `json:"teacher_auditoriums"`
+	Buildings          []CalendarBuilding          `json:"buildings"`
 	Sequence           int64                       `json:"sequence"`
 	Cancelled          bool                        `json:"cancelled"`
 }
 
-type GroupCalendar struct {
-	Group     string          `json:"group"`
+type Calendar struct {
+	Owner     CalendarOwner   `json:"owner"`
+	Name      string          `json:"name"`
 	Source    string          `json:"-"`
 	UpdatedAt time.Time       `json:"updated_at"`
 	Events    []CalendarEvent `json:"events"`
+	// Holidays within the calendar events, emitted as all-day events.
+	Holidays []Holiday `json:"-"`
+}
+
+// CalendarRevision is the version of the imported schedule, bumped by the parser after every update.
+type CalendarRevision struct {
+	Revision  int64     `json:"revision"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CalendarChanges are the events of a calendar changed after a revision, by uid.
+type CalendarChanges struct {
+	UIDs []string `json:"uids"`
+	// Resync is set when the calendar changed as a whole, e.g. with holidays, and the uids are incomplete.
+	Resync bool `json:"resync"`
 }
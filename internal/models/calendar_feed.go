@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// CalendarFeedRule matches a calendar event when every non-empty field matches.
+type CalendarFeedRule struct {
+	Title      string `json:"title,omitempty"       example:"Физическая культура"`
+	LessonType string `json:"lesson_type,omitempty" example:"lab"`
+	Teacher    string `json:"teacher,omitempty"     example:"Конюхов"`
+	Auditorium string `json:"auditorium,omitempty"  example:"106а C"`
+}
+
+// CalendarFeed is a saved group calendar filter available by a secret token.
+type CalendarFeed struct {
+	Token     string             `json:"token,omitempty" example:"3q2-7wEjRQ2m5xqJ0ZVbqzQ1o7b5b6k9pZ0JqgC2pXc"`
+	Group     string             `json:"group"           example:"344"`
+	Include   []CalendarFeedRule `json:"include"`
+	Exclude   []CalendarFeedRule `json:"exclude"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
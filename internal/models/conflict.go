@@ -0,0 +1,52 @@
+package models
+
+type ScheduleConflictKind string
+
+const (
+	ScheduleConflictTeacher    ScheduleConflictKind = "teacher"
+	ScheduleConflictAuditorium ScheduleConflictKind = "auditorium"
+	ScheduleConflictGroup      ScheduleConflictKind = "group"
+)
+
+// ScheduledLesson is a lesson of one group with its teachers and auditoriums, conflicts are searched among them.
+type ScheduledLesson struct {
+	Date               string                     `json:"date"                example:"2026-03-02"`
+	Time               string                     `json:"time"                example:"08.10-09.45"`
+	Start              string                     `json:"start"               example:"08:10"`
+	End                string                     `json:"end"                 example:"09:45"`
+	Title              string                     `json:"title"               example:"Высшая математика"`
+	Type               string                     `json:"type"                example:"lecture"`
+	Group              string                     `json:"group"               example:"344"`
+	TeacherAuditoriums []StudentTeacherAuditorium `json:"teacher_auditoriums"`
+	FacultyID          int                        `json:"faculty_id"          example:"1"`
+	Id                 int                        `json:"id"                  example:"1"`
+}
+
+// ConflictLesson is a lesson taking part in a conflict, lessons of a stream are merged into one.
+type ConflictLesson struct {
+	Time               string                     `json:"time"                example:"08.10-09.45"`
+	PairNumber         int                        `json:"pair_number"         example:"1"`
+	Title              string                     `json:"title"               example:"Высшая математика"`
+	Type               string                     `json:"type"                example:"lecture"`
+	Groups             []string                   `json:"groups"              example:"344,345"`
+	TeacherAuditoriums []StudentTeacherAuditorium `json:"teacher_auditoriums"`
+	Ids                []int                      `json:"ids"                 example:"1,2"`
+}
+
+// ScheduleConflict is a pair of overlapping lessons of one teacher, auditorium or group,
+// only the field of its kind is set.
+type ScheduleConflict struct {
+	Kind       ScheduleConflictKind `json:"kind"                 enums:"teacher,auditorium,group" example:"teacher"`
+	Date       string               `json:"date"                                                  example:"2026-03-02"`
+	Teacher    *StudentTeacherInfo  `json:"teacher,omitempty"`
+	Auditorium *Auditorium          `json:"auditorium,omitempty"`
+	Group      string               `json:"group,omitempty"                                       example:"344"`
+	Lessons    []ConflictLesson     `json:"lessons"`
+}
+
+type ScheduleConflicts struct {
+	From      string             `json:"from"                 example:"2026-03-02"`
+	To        string             `json:"to"                   example:"2026-03-15"`
+	FacultyID int                `json:"faculty_id,omitempty" example:"1"`
+	Conflicts []ScheduleConflict `json:"conflicts"`
+}
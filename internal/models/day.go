@@ -6,4 +6,10 @@ type Day struct {
 	Day         string `json:"day"           enums:"Monday,Tuesday,Wednesday,Thursday,Friday,Saturday,Sunday" example:"Monday"`      //nolint:lll // there is no way to fix it
 	DayRu       string `json:"day_ru"        enums:"Пн,Вт,Ср,Чт,Пт,Сб,Вс"                                     example:"Пн"`          //nolint:lll // there is no way to fix it
 	Time        string `json:"time"                                                                           example:"08.10"`       //nolint:lll // there is no way to fix it
+	// Period is the academic period of today, null outside of known periods.
+	Period *AcademicPeriod `json:"period"`
+	// IsHoliday is true on public holidays, days off and university closures.
+	IsHoliday bool `json:"is_holiday" example:"false"`
+	// Holiday is the holiday of today, null on working days.
+	Holiday *Holiday `json:"holiday"`
 }
@@ -0,0 +1,22 @@
+package models
+
+// LessonInterval is the time a lesson takes on a date.
+type LessonInterval struct {
+	Date  string `json:"date"  example:"2026-03-02"`
+	Start string `json:"start" example:"08:10"`
+	End   string `json:"end"   example:"09:45"`
+}
+
+type CommonFreeSlot struct {
+	Date string `json:"date" example:"2026-03-02"`
+	LessonSlot
+	Auditorium *Auditorium `json:"auditorium,omitempty"`
+}
+
+type CommonFreeSlots struct {
+	From     string           `json:"from"     example:"2026-03-02"`
+	To       string           `json:"to"       example:"2026-03-08"`
+	Groups   []string         `json:"groups"   example:"344,345"`
+	Teachers []int            `json:"teachers" example:"1,2"`
+	Slots    []CommonFreeSlot `json:"slots"`
+}
@@ -0,0 +1,28 @@
+package models
+
+type HolidayKind string
+
+const (
+	HolidayKindHoliday    HolidayKind = "holiday"
+	HolidayKindNonWorking HolidayKind = "non_working"
+	HolidayKindClosure    HolidayKind = "closure"
+)
+
+type HolidaySource string
+
+const (
+	HolidaySourceProductionCalendar HolidaySource = "production_calendar"
+	HolidaySourceManual             HolidaySource = "manual"
+)
+
+// Holiday is a day without lessons: a public holiday, a transferred day off or a university closure.
+type Holiday struct {
+	Date   string        `json:"date"                                       example:"2026-02-23"`
+	Title  string        `json:"title"                                      example:"День защитника Отечества"`
+	Kind   HolidayKind   `json:"kind"   enums:"holiday,non_working,closure" example:"holiday"`
+	Source HolidaySource `json:"source" enums:"production_calendar,manual"  example:"production_calendar"`
+}
+
+type HolidaysImport struct {
+	Imported int `json:"imported" example:"14"`
+}
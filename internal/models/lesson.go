@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Lesson is a single lesson of a date-range schedule, lessons of a stream are merged into one.
+type Lesson struct {
+	Date               string                     `json:"date"                example:"2025-06-18"`
+	Time               string                     `json:"time"                example:"08.10-09.45"`
+	PairNumber         int                        `json:"pair_number"         example:"1"`
+	Title              string                     `json:"title"               example:"Высшая математика"`
+	Type               string                     `json:"type"                example:"lecture"`
+	WeekType           string                     `json:"week_type"           example:"numerator"`
+	StartTime          string                     `json:"start_time"          example:"2025-06-18T08:10:00"`
+	EndTime            string                     `json:"end_time"            example:"2025-06-18T09:45:00"`
+	Groups             []string                   `json:"groups"              example:"344,345"`
+	TeacherAuditoriums []StudentTeacherAuditorium `json:"teacher_auditoriums"`
+	Id                 int                        `json:"id"                  example:"1"`
+}
+
+type LessonsPage struct {
+	From       string   `json:"from"                  example:"2026-02-09"`
+	To         string   `json:"to"                    example:"2026-06-30"`
+	Lessons    []Lesson `json:"lessons"`
+	NextCursor string   `json:"next_cursor,omitempty" example:"MjAyNi0wMi0wOVQwODoxMDowMHwxMg"`
+}
+
+// LessonsFilter selects lessons between From and To inclusive, after the (AfterStart, AfterID) key if it is set.
+type LessonsFilter struct {
+	From       time.Time
+	To         time.Time
+	AfterStart *time.Time
+	AfterID    int
+	Limit      int
+}
+
+// NowLesson is the current or the next lesson, Minutes is how long until it ends or starts.
+type NowLesson struct {
+	Lesson
+	Minutes int `json:"minutes" example:"35"`
+}
+
+// LessonBreak is the break in the middle of the current pair or between two lessons of the day.
+type LessonBreak struct {
+	Start  string `json:"start"  example:"08:55"`
+	End    string `json:"end"    example:"09:00"`
+	Active bool   `json:"active" example:"false"`
+}
+
+type LessonsNow struct {
+	Now     string       `json:"now"               example:"2026-03-02T08:45:00+03:00"`
+	Current *NowLesson   `json:"current"`
+	Next    *NowLesson   `json:"next"`
+	Break   *LessonBreak `json:"break"`
+	DayEnd  string       `json:"day_end,omitempty" example:"15:10"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+type LessonChangeKind string
+
+const (
+	LessonChangeAdded    LessonChangeKind = "added"
+	LessonChangeRemoved  LessonChangeKind = "removed"
+	LessonChangeModified LessonChangeKind = "modified"
+)
+
+// LessonVersion is a lesson as it was before or after a change.
+type LessonVersion struct {
+	UID                string                      `json:"uid"                 example:"schedule-rsreu-0d9f6c1e@rsreu-schedule.ru"`
+	StartTime          string                      `json:"start_time"          example:"2026-02-09T08:10:00"`
+	EndTime            string                      `json:"end_time"            example:"2026-02-09T09:45:00"`
+	PairNumber         int                         `json:"pair_number"         example:"1"`
+	Title              string                      `json:"title"               example:"Высшая математика"`
+	LessonType         string                      `json:"lesson_type"         example:"lecture"`
+	TeacherAuditoriums []CalendarTeacherAuditorium `json:"teacher_auditoriums"`
+}
+
+// LessonChange is an added, removed or modified lesson. A moved lesson changes its uid,
+// uid is the one after the change and Before.UID is the previous one.
+type LessonChange struct {
+	Kind      LessonChangeKind `json:"kind"             enums:"added,removed,modified"  example:"modified"`
+	Group     string           `json:"group,omitempty"                                  example:"344"`
+	UID       string           `json:"uid"                                              example:"schedule-rsreu-0d9f6c1e@rsreu-schedule.ru"` //nolint:lll // there is no way to fix it
+	Fields    []string         `json:"fields,omitempty" enums:"time,teacher,auditorium" example:"auditorium"`
+	Before    *LessonVersion   `json:"before"`
+	After     *LessonVersion   `json:"after"`
+	ChangedAt time.Time        `json:"changed_at"                                       example:"2026-02-10T09:00:00.123456+03:00"`
+	Id        int64            `json:"id"                                               example:"1"`
+}
+
+type LessonChanges struct {
+	Group   string         `json:"group"    example:"344"`
+	Since   time.Time      `json:"since"    example:"2026-02-03T00:00:00+03:00"`
+	Changes []LessonChange `json:"changes"`
+	// Cursor is the id of the last change, passed as after for the next page or poll.
+	Cursor  int64 `json:"cursor"   example:"1"`
+	HasMore bool  `json:"has_more" example:"false"`
+}
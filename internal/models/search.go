@@ -0,0 +1,53 @@
+package models
+
+type SearchHitType string
+
+const (
+	SearchHitGroup      SearchHitType = "group"
+	SearchHitTeacher    SearchHitType = "teacher"
+	SearchHitAuditorium SearchHitType = "auditorium"
+	SearchHitBuilding   SearchHitType = "building"
+	SearchHitSubject    SearchHitType = "subject"
+)
+
+// SearchEntry is a group, teacher, auditorium, building or subject with the names it can be found by.
+type SearchEntry struct {
+	Type     SearchHitType `json:"type"`
+	Title    string        `json:"title"`
+	Subtitle string        `json:"subtitle"`
+	Names    []string      `json:"names"`
+	Id       int           `json:"id"`
+}
+
+// SearchHit is a search result, Id is the group, teacher, auditorium or building id and is not set for subjects.
+type SearchHit struct {
+	Type     SearchHitType `json:"type"               enums:"group,teacher,auditorium,building,subject" example:"teacher"`
+	Title    string        `json:"title"                                                                example:"Конюхов Алексей Николаевич"`
+	Subtitle string        `json:"subtitle,omitempty"                                                   example:"Конюхов А.Н."`
+	Score    float64       `json:"score"                                                                example:"0.95"`
+	Id       int           `json:"id,omitempty"                                                         example:"1"`
+}
+
+type SearchResults struct {
+	Query string      `json:"query" example:"Конюхов А"`
+	Hits  []SearchHit `json:"hits"`
+}
+
+type SuggestionReason string
+
+const (
+	SuggestionNoLessons   SuggestionReason = "no_lessons"
+	SuggestionSimilarName SuggestionReason = "similar_name"
+)
+
+// Suggestion is an existing entity the client may have meant, no_lessons means it exists
+// but has no lessons in the requested period.
+type Suggestion struct {
+	SearchHit
+	Reason SuggestionReason `json:"reason" enums:"no_lessons,similar_name" example:"similar_name"`
+}
+
+type NotFoundResponse struct {
+	Message     string       `json:"message"     example:"schedule for group 34 not found"`
+	Suggestions []Suggestion `json:"suggestions"`
+}
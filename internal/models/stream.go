@@ -0,0 +1,34 @@
+package models
+
+// ScheduleStreamChange is a logged lesson change with the teachers and auditoriums of both lesson versions.
+type ScheduleStreamChange struct {
+	Group       string   `json:"group"`
+	Date        string   `json:"date"`
+	Teachers    []string `json:"teachers"`
+	Auditoriums []string `json:"auditoriums"`
+	Id          int64    `json:"id"`
+}
+
+// ScheduleStreamBatch is the changes after a change id at the schedule revision.
+type ScheduleStreamBatch struct {
+	Revision int64                  `json:"revision"`
+	Changes  []ScheduleStreamChange `json:"changes"`
+}
+
+// ScheduleStreamSubscription is what a stream client follows, teachers and auditoriums are matched by name.
+type ScheduleStreamSubscription struct {
+	Groups      []string       `json:"groups"`
+	Teachers    map[int]string `json:"teachers"`
+	Auditoriums map[int]string `json:"auditoriums"`
+}
+
+// ScheduleStreamEvent is pushed to a stream client when the schedule it follows changes.
+// Id is the last lesson change id, clients resume from it with Last-Event-ID.
+type ScheduleStreamEvent struct {
+	Revision    int64    `json:"revision"    example:"43"`
+	Groups      []string `json:"groups"      example:"344"`
+	Teachers    []int    `json:"teachers"`
+	Auditoriums []int    `json:"auditoriums"`
+	Dates       []string `json:"dates"       example:"2026-02-20"`
+	Id          int64    `json:"-"`
+}
@@ -2,6 +2,7 @@ package models
 
 type StudentLesson struct {
 	Time               string                     `json:"time"                bson:"time"                example:"08.10-09.45"`
+	PairNumber         int                        `json:"pair_number"                                    example:"1"`
 	Lesson             string                     `json:"lesson"              bson:"lesson"              example:"Лек. Высшая математика\nКонюхов А.Н. 333 С"` //nolint:lll // there is no way to fix it
 	Title              string                     `json:"title"               bson:"title"               example:"Высшая математика"`                          //nolint:lll // there is no way to fix it
 	Date               string                     `json:"date"                bson:"date"                example:"2025-06-18"`
@@ -9,6 +10,7 @@ type StudentLesson struct {
 	StartTime          string                     `json:"start_time"          bson:"start_time"          example:"2025-06-18T15:20:00"`
 	EndTime            string                     `json:"end_time"            bson:"end_time"            example:"2025-06-18T16:55:00"`
 	TeacherAuditoriums []StudentTeacherAuditorium `json:"teacher_auditoriums" bson:"teacher_auditoriums"`
+	IsHoliday          bool                       `json:"is_holiday"                                     example:"false"`
 }
 
 type StudentWeek Week[StudentLesson]
@@ -30,7 +32,9 @@ type StudentSchedule struct {
 	NumeratorPeriod   string                            `json:"numerator_period"        bson:"numerator_period"                      example:"16.06-22.06"`
 	DenominatorPeriod string                            `json:"denominator_period"      bson:"denominator_period"                    example:"09.06-15.06"`
 	InputWeekType     string                            `json:"input_week_type"                                                      example:"numerator"`
-	Schedule          NumeratorDenominator[StudentWeek] `json:"schedule"                bson:"schedule"`
+	Schedule          NumeratorDenominator[StudentWeek] `json:"schedule,omitzero"       bson:"schedule"`
+	Grid              *ScheduleGrid[StudentLesson]      `json:"grid,omitempty"`
 	LessonsTimes      []string                          `json:"lessons_times,omitempty"                           db:"lessons_times"`
+	Holidays          []Holiday                         `json:"holidays"`
 	Course            int                               `json:"course"                  bson:"course"                                example:"1"`
 }
@@ -1,15 +1,17 @@
 package models
 
 type TeacherLesson struct {
-	Time       string     `json:"time"       bson:"time"       example:"08.10-09.45"`
-	Lesson     string     `json:"lesson"     bson:"lesson"     example:"Лек. Высшая математика\nКонюхов А.Н. 333 С"` //nolint:lll // there is no way to fix it
-	Title      string     `json:"title"      bson:"title"      example:"Высшая математика"`                          //nolint:lll // there is no way to fix it
-	Type       string     `json:"type"       bson:"type"       example:"lab,practice"`
-	Date       string     `json:"date"       bson:"date"       example:"2025-06-18"`
-	Faculties  []string   `json:"faculties"  bson:"faculties"  example:"фаиту,фвт"`
-	Groups     []string   `json:"groups"     bson:"groups"     example:"344,345"`
-	Courses    []int      `json:"courses"    bson:"courses"    example:"1"`
-	Auditorium Auditorium `json:"auditorium" bson:"auditorium"`
+	Time       string     `json:"time"        bson:"time"       example:"08.10-09.45"`
+	PairNumber int        `json:"pair_number"                   example:"1"`
+	Lesson     string     `json:"lesson"      bson:"lesson"     example:"Лек. Высшая математика\nКонюхов А.Н. 333 С"` //nolint:lll // there is no way to fix it
+	Title      string     `json:"title"       bson:"title"      example:"Высшая математика"`                          //nolint:lll // there is no way to fix it
+	Type       string     `json:"type"        bson:"type"       example:"lab,practice"`
+	Date       string     `json:"date"        bson:"date"       example:"2025-06-18"`
+	Faculties  []string   `json:"faculties"   bson:"faculties"  example:"фаиту,фвт"`
+	Groups     []string   `json:"groups"      bson:"groups"     example:"344,345"`
+	Courses    []int      `json:"courses"     bson:"courses"    example:"1"`
+	Auditorium Auditorium `json:"auditorium"  bson:"auditorium"`
+	IsHoliday  bool       `json:"is_holiday"                    example:"false"`
 }
 
 type TeacherWeek Week[TeacherLesson]
@@ -21,9 +23,11 @@ type TeacherSchedule struct {
 	NumeratorPeriod   string                            `json:"numerator_period"        bson:"numerator_period"                      example:"16.06-22.06"`
 	DenominatorPeriod string                            `json:"denominator_period"      bson:"denominator_period"                    example:"09.06-15.06"`
 	InputWeekType     string                            `json:"input_week_type"                                                      example:"numerator"`
-	Schedule          NumeratorDenominator[TeacherWeek] `json:"schedule"                bson:"schedule"`
+	Schedule          NumeratorDenominator[TeacherWeek] `json:"schedule,omitzero"       bson:"schedule"`
+	Grid              *ScheduleGrid[TeacherLesson]      `json:"grid,omitempty"`
 	Departments       []Department                      `json:"departments"             bson:"departments"`
 	LessonsTimes      []string                          `json:"lessons_times,omitempty"                           db:"lessons_times"`
+	Holidays          []Holiday                         `json:"holidays"`
 	Id                int                               `json:"id"                      bson:"id"                                    example:"1"`
 }
 
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookScope string
+
+const (
+	WebhookScopeGroup      WebhookScope = "group"
+	WebhookScopeTeacher    WebhookScope = "teacher"
+	WebhookScopeAuditorium WebhookScope = "auditorium"
+)
+
+// WebhookEventScheduleChanged is sent when the schedule revision advances with changes in the webhook scope.
+const WebhookEventScheduleChanged = "schedule.changed"
+
+// Webhook is a subscription to schedule changes of a group, teacher or auditorium.
+// ScopeID is the group number or the teacher or auditorium id.
+type Webhook struct {
+	URL          string       `json:"url"                                               example:"https://bot.example.com/schedule-webhook"`
+	Scope        WebhookScope `json:"scope"            enums:"group,teacher,auditorium" example:"group"`
+	ScopeID      string       `json:"scope_id"                                          example:"344"`
+	Secret       string       `json:"secret,omitempty"                                  example:"Yk3mJ0ZVbqzQ1o7b5b6k9pZ0JqgC2pXc3q2-7wEjRQ2"`
+	Active       bool         `json:"active"                                            example:"true"`
+	LastRevision int64        `json:"last_revision"                                     example:"42"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Id           int          `json:"id"                                                example:"1"`
+}
+
+// WebhookPayload is the body of a webhook delivery.
+type WebhookPayload struct {
+	Event    string         `json:"event"                                     example:"schedule.changed"`
+	Revision int64          `json:"revision"                                  example:"43"`
+	Scope    WebhookScope   `json:"scope"    enums:"group,teacher,auditorium" example:"group"`
+	ScopeID  string         `json:"scope_id"                                  example:"344"`
+	Changes  []LessonChange `json:"changes"`
+}
+
+// WebhookBatch is a webhook behind the current revision with the changes in its scope since the last notification.
+type WebhookBatch struct {
+	Webhook      Webhook        `json:"webhook"`
+	Revision     int64          `json:"revision"`
+	LastChangeID int64          `json:"last_change_id"`
+	Changes      []LessonChange `json:"changes"`
+}
+
+// WebhookEvent is an outbox entry claimed for delivery.
+type WebhookEvent struct {
+	URL       string          `json:"url"`
+	Secret    string          `json:"secret"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempt   int             `json:"attempt"`
+	WebhookID int             `json:"webhook_id"`
+	Id        int64           `json:"id"`
+}
+
+// WebhookDelivery is a logged delivery attempt, StatusCode is null when the request failed.
+type WebhookDelivery struct {
+	OutboxID   int64     `json:"outbox_id"   example:"10"`
+	Attempt    int       `json:"attempt"     example:"1"`
+	StatusCode *int      `json:"status_code" example:"200"`
+	Error      string    `json:"error"       example:""`
+	DurationMs int       `json:"duration_ms" example:"120"`
+	CreatedAt  time.Time `json:"created_at"`
+	Id         int64     `json:"id"          example:"1"`
+}
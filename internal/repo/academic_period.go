@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const academicPeriodJSON = `json_build_object(
+            'id', id,
+            'kind', kind,
+            'title', title,
+            'start_date', to_char(start_date, 'YYYY-MM-DD'),
+            'end_date', to_char(end_date, 'YYYY-MM-DD'),
+            'numerator_start', to_char(numerator_start, 'YYYY-MM-DD')
+        )`
+
+// finalWeekTypes is the CTE choosing the week types of the two-week window. The numerator anchor of the semester
+// decides when it is set, as in services.WeekType, otherwise the week types are found from the lessons.
+const finalWeekTypes = `final_week_types AS (
+  SELECT
+    wc.*,
+    COALESCE(anchor.first_week_type, wc.first_week_actual_type, wc.predicted_first_week_type) AS final_first_week_type,
+    COALESCE(
+      anchor.second_week_type,
+      wc.second_week_actual_type,
+      CASE WHEN COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) = 'numerator'
+        THEN 'denominator' ELSE 'numerator' END
+    ) AS final_second_week_type
+  FROM week_classification wc
+  LEFT JOIN LATERAL (
+    SELECT
+      CASE WHEN mod((wc.first_week_monday::date - wc.numerator_start) / 7, 2) = 0
+        THEN 'numerator' ELSE 'denominator' END AS first_week_type,
+      CASE WHEN mod((wc.first_week_monday::date - wc.numerator_start) / 7, 2) = 0
+        THEN 'denominator' ELSE 'numerator' END AS second_week_type
+    WHERE wc.numerator_start IS NOT NULL
+  ) anchor ON true
+)`
+
+func (sr *ScheduleRepo) GetAcademicPeriods(ctx context.Context) ([]models.AcademicPeriod, error) {
+	const query = `
+        SELECT coalesce(json_agg(` + academicPeriodJSON + ` ORDER BY start_date, id), '[]'::json) AS periods_json
+        FROM academic_period
+    `
+	periods, err := findOneJsonContext[[]models.AcademicPeriod](ctx, sr.pg.DB, query)
+	if err != nil {
+		return nil, err
+	}
+	return *periods, nil
+}
+
+func (sr *ScheduleRepo) GetAcademicPeriod(ctx context.Context, id int) (*models.AcademicPeriod, error) {
+	const query = `
+        SELECT ` + academicPeriodJSON + ` AS period_json
+        FROM academic_period
+        WHERE id = $1
+    `
+	return findOneJsonContext[models.AcademicPeriod](ctx, sr.pg.DB, query, id)
+}
+
+func (sr *ScheduleRepo) CreateAcademicPeriod(ctx context.Context, period *models.AcademicPeriod) error {
+	const query = `
+        WITH changed AS (
+            INSERT INTO academic_period (kind, title, start_date, end_date, numerator_start)
+            VALUES ($1, $2, $3::date, $4::date, $5::date)
+            RETURNING id
+        ),` + bumpRevision + `
+        SELECT id FROM changed
+    `
+	return sr.pg.DB.QueryRowContext(ctx, query,
+		period.Kind, period.Title, period.StartDate, period.EndDate, nullString(period.NumeratorStart),
+	).Scan(&period.Id)
+}
+
+func (sr *ScheduleRepo) UpdateAcademicPeriod(ctx context.Context, period *models.AcademicPeriod) error {
+	const query = `
+        WITH changed AS (
+            UPDATE academic_period
+            SET kind = $2,
+                title = $3,
+                start_date = $4::date,
+                end_date = $5::date,
+                numerator_start = $6::date,
+                updated_at = now()
+            WHERE id = $1
+            RETURNING id
+        ),` + bumpRevision + `
+        SELECT id FROM changed
+    `
+	err := sr.pg.DB.QueryRowContext(ctx, query,
+		period.Id, period.Kind, period.Title, period.StartDate, period.EndDate, nullString(period.NumeratorStart),
+	).Scan(&period.Id)
+	return noRowsToNoResults(err)
+}
+
+func (sr *ScheduleRepo) DeleteAcademicPeriod(ctx context.Context, id int) error {
+	const query = `
+        WITH changed AS (
+            DELETE FROM academic_period WHERE id = $1 RETURNING id
+        ),` + bumpRevision + `
+        SELECT count(*) FROM changed
+    `
+	var deleted int
+	if err := sr.pg.DB.QueryRowContext(ctx, query, id).Scan(&deleted); err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
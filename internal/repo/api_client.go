@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const apiClientJSON = `json_build_object(
+            'id', api_client.id,
+            'name', api_client.name,
+            'scopes', api_client.scopes,
+            'active', api_client.active,
+            'created_at', api_client.created_at
+        )`
+
+// CreateAPIClient returns ErrAlreadyExists when a client with the name exists.
+func (sr *ScheduleRepo) CreateAPIClient(ctx context.Context, client *models.APIClient, keyHash string) error {
+	const query = `
+        INSERT INTO api_client (name, key_hash, scopes, active)
+        VALUES ($1, $2, $3::text[], $4)
+        ON CONFLICT (name) DO NOTHING
+        RETURNING id, created_at
+    `
+	err := sr.pg.DB.QueryRowContext(ctx, query, client.Name, keyHash, apiClientScopes(client), client.Active).
+		Scan(&client.Id, &client.CreatedAt)
+	if err = noRowsToNoResults(err); errors.Is(err, ErrNoResults) {
+		return ErrAlreadyExists
+	}
+	return err
+}
+
+func (sr *ScheduleRepo) GetAPIClients(ctx context.Context) ([]models.APIClient, error) {
+	const query = `
+        SELECT coalesce(json_agg(` + apiClientJSON + ` ORDER BY api_client.id), '[]'::json) AS clients_json
+        FROM api_client
+    `
+	clients, err := findOneJsonContext[[]models.APIClient](ctx, sr.pg.DB, query)
+	if err != nil {
+		return nil, err
+	}
+	return *clients, nil
+}
+
+func (sr *ScheduleRepo) GetAPIClient(ctx context.Context, id int) (*models.APIClient, error) {
+	const query = `
+        SELECT ` + apiClientJSON + ` AS client_json
+        FROM api_client
+        WHERE id = $1
+    `
+	return findOneJsonContext[models.APIClient](ctx, sr.pg.DB, query, id)
+}
+
+// GetActiveAPIClient finds an active client by the name or, when name is empty, by the key hash.
+func (sr *ScheduleRepo) GetActiveAPIClient(ctx context.Context, name, keyHash string) (*models.APIClient, error) {
+	const query = `
+        SELECT ` + apiClientJSON + ` AS client_json
+        FROM api_client
+        WHERE active
+          AND CASE WHEN $1 = '' THEN key_hash = $2 ELSE name = $1 END
+    `
+	return findOneJsonContext[models.APIClient](ctx, sr.pg.DB, query, name, keyHash)
+}
+
+func (sr *ScheduleRepo) UpdateAPIClient(ctx context.Context, client *models.APIClient) error {
+	const query = `
+        UPDATE api_client
+        SET scopes = $2::text[],
+            active = $3,
+            updated_at = now()
+        WHERE id = $1
+        RETURNING name, created_at
+    `
+	err := sr.pg.DB.QueryRowContext(ctx, query, client.Id, apiClientScopes(client), client.Active).
+		Scan(&client.Name, &client.CreatedAt)
+	return noRowsToNoResults(err)
+}
+
+func (sr *ScheduleRepo) RotateAPIClientKey(ctx context.Context, id int, keyHash string) error {
+	result, err := sr.pg.DB.ExecContext(ctx,
+		`UPDATE api_client SET key_hash = $2, updated_at = now() WHERE id = $1`, id, keyHash)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+func (sr *ScheduleRepo) DeleteAPIClient(ctx context.Context, id int) error {
+	result, err := sr.pg.DB.ExecContext(ctx, `DELETE FROM api_client WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+func apiClientScopes(client *models.APIClient) []string {
+	scopes := make([]string, 0, len(client.Scopes))
+	for _, scope := range client.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return scopes
+}
@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+// GetCalendarChanges returns uids of the calendar events changed after the revision. Changes are logged per group,
+// teacher and auditorium calendars pick them by the names in the lesson before and after the change.
+func (sr *ScheduleRepo) GetCalendarChanges(
+	ctx context.Context,
+	owner models.CalendarOwner,
+	id string,
+	since int64,
+) (*models.CalendarChanges, error) {
+	const query = `
+WITH calendar_owner AS (
+  SELECT
+    CASE $1 WHEN 'group' THEN $2 ELSE $1 || '-' || $2 END AS uid_key,
+    teacher.id AS teacher_id,
+    teacher.full_name AS teacher_name,
+    auditorium.id AS auditorium_id,
+    CASE WHEN auditorium.id IS NOT NULL
+      THEN concat_ws(' ', auditorium.number, building.letter)
+    END AS auditorium_name
+  FROM (SELECT 1) single
+  LEFT JOIN teacher ON $1 = 'teacher' AND teacher.id::text = $2
+  LEFT JOIN auditorium ON $1 = 'auditorium' AND auditorium.id::text = $2
+  LEFT JOIN building ON building.id = auditorium.building_id
+),
+changed_states AS (
+  SELECT change.group_number, state.lesson
+  FROM lesson_change change
+  CROSS JOIN LATERAL (VALUES (change.before), (change.after)) AS state(lesson)
+  WHERE change.revision > $3
+    AND state.lesson IS NOT NULL
+),
+changed_uids AS (
+  SELECT calendar_event_uid(
+    calendar_owner.uid_key,
+    (state.lesson->>'start_time')::timestamp::date,
+    (state.lesson->>'start_time')::timestamp,
+    state.lesson->>'title',
+    state.lesson->>'lesson_type'
+  ) AS uid
+  FROM calendar_owner
+  JOIN changed_states state ON CASE $1
+    WHEN 'group' THEN state.group_number = $2
+    ELSE EXISTS (
+      SELECT 1
+      FROM jsonb_array_elements(state.lesson->'teacher_auditoriums') pair
+      WHERE pair->>'teacher' = calendar_owner.teacher_name
+        OR pair->>'auditorium' = calendar_owner.auditorium_name
+    )
+  END
+
+  UNION
+
+  SELECT calendar_event_uid(
+    calendar_owner.uid_key,
+    deleted.start_time::date,
+    deleted.start_time,
+    deleted.title,
+    deleted.lesson_type
+  )
+  FROM calendar_owner
+  JOIN calendar_deleted_event deleted ON deleted.sequence > $3 AND CASE $1
+    WHEN 'group' THEN deleted.group_number = $2
+    WHEN 'teacher' THEN calendar_owner.teacher_id = ANY(deleted.teacher_ids)
+    ELSE calendar_owner.auditorium_id = ANY(deleted.auditorium_ids)
+  END
+)
+SELECT json_build_object(
+  'uids', coalesce((SELECT json_agg(uid ORDER BY uid) FROM changed_uids), '[]'::json),
+  'resync', EXISTS (SELECT 1 FROM calendar_resync WHERE revision > $3)
+)
+`
+	return findOneJsonContext[models.CalendarChanges](ctx, sr.pg.DB, query, string(owner), id, since)
+}
@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+func (sr *ScheduleRepo) CreateCalendarFeed(ctx context.Context, tokenHash string, feed *models.CalendarFeed) error {
+	exists, err := sr.groupExists(ctx, feed.Group)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NoScheduleGroupError{ParamName: feed.Group}
+	}
+
+	include, exclude, err := marshalCalendarFeedRules(feed)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+        INSERT INTO calendar_feed (token_hash, group_number, include_rules, exclude_rules)
+        VALUES ($1, $2, $3, $4)
+        RETURNING updated_at
+    `
+	return sr.pg.DB.QueryRowContext(ctx, query, tokenHash, feed.Group, include, exclude).Scan(&feed.UpdatedAt)
+}
+
+func (sr *ScheduleRepo) GetCalendarFeed(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	const query = `
+        SELECT json_build_object(
+            'group', group_number,
+            'include', include_rules,
+            'exclude', exclude_rules,
+            'updated_at', to_char(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+        ) AS feed_json
+        FROM calendar_feed
+        WHERE token_hash = $1
+    `
+	return findOneJsonContext[models.CalendarFeed](ctx, sr.pg.DB, query, tokenHash)
+}
+
+func (sr *ScheduleRepo) UpdateCalendarFeed(ctx context.Context, tokenHash string, feed *models.CalendarFeed) error {
+	exists, err := sr.groupExists(ctx, feed.Group)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return NoScheduleGroupError{ParamName: feed.Group}
+	}
+
+	include, exclude, err := marshalCalendarFeedRules(feed)
+	if err != nil {
+		return err
+	}
+
+	const query = `
+        UPDATE calendar_feed
+        SET group_number = $2, include_rules = $3, exclude_rules = $4, updated_at = now()
+        WHERE token_hash = $1
+        RETURNING updated_at
+    `
+	err = sr.pg.DB.QueryRowContext(ctx, query, tokenHash, feed.Group, include, exclude).Scan(&feed.UpdatedAt)
+	return noRowsToNoResults(err)
+}
+
+func (sr *ScheduleRepo) DeleteCalendarFeed(ctx context.Context, tokenHash string) error {
+	result, err := sr.pg.DB.ExecContext(ctx, `DELETE FROM calendar_feed WHERE token_hash = $1`, tokenHash)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+func marshalCalendarFeedRules(feed *models.CalendarFeed) (include, exclude []byte, err error) {
+	include, err = json.Marshal(nonNilRules(feed.Include))
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal include rules: %w", err)
+	}
+	exclude, err = json.Marshal(nonNilRules(feed.Exclude))
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal exclude rules: %w", err)
+	}
+	return include, exclude, nil
+}
+
+func nonNilRules(rules []models.CalendarFeedRule) []models.CalendarFeedRule {
+	if rules == nil {
+		return []models.CalendarFeedRule{}
+	}
+	return rules
+}
@@ -1,11 +1,13 @@
 package repo
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 )
 
 var ErrNoResults = errors.New("no results")
+var ErrAlreadyExists = errors.New("already exists")
 
 type NoScheduleGroupError struct {
 	ParamName string
@@ -15,3 +17,10 @@ type NoScheduleGroupError struct {
 func (e NoScheduleGroupError) Error() string {
 	return fmt.Sprintf("schedule for group %v not found", e.ParamName)
 }
+
+func noRowsToNoResults(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNoResults
+	}
+	return err
+}
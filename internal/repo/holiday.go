@@ -0,0 +1,103 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+// bumpRevision is a CTE moving the schedule revision when the preceding CTE changed has rows. Holidays and
+// academic periods change the served schedules, so cached responses and their validators are reset with it.
+const bumpRevision = `
+        bumped AS (
+            UPDATE calendar_revision
+            SET revision = revision + 1,
+                updated_at = now()
+            WHERE id = 1 AND EXISTS (SELECT 1 FROM changed)
+        )`
+
+// GetHolidays returns holidays between from and to inclusive ordered by date.
+func (sr *ScheduleRepo) GetHolidays(ctx context.Context, from, to time.Time) ([]models.Holiday, error) {
+	const query = `
+        SELECT coalesce(json_agg(json_build_object(
+            'date', to_char(date, 'YYYY-MM-DD'),
+            'title', title,
+            'kind', kind,
+            'source', source
+        ) ORDER BY date), '[]'::json) AS holidays_json
+        FROM holiday
+        WHERE date BETWEEN $1::date AND $2::date
+    `
+	holidays, err := findOneJsonContext[[]models.Holiday](ctx, sr.pg.DB, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return *holidays, nil
+}
+
+// SaveHoliday creates or replaces a holiday of the date, it is never overwritten by an import afterwards.
+func (sr *ScheduleRepo) SaveHoliday(ctx context.Context, holiday *models.Holiday) error {
+	const query = `
+        WITH changed AS (
+            INSERT INTO holiday (date, title, kind, source)
+            VALUES ($1::date, $2, $3, 'manual')
+            ON CONFLICT (date) DO UPDATE
+            SET title = excluded.title,
+                kind = excluded.kind,
+                source = excluded.source,
+                updated_at = now()
+            RETURNING date
+        ),` + bumpRevision + `
+        SELECT count(*) FROM changed
+    `
+	_, err := sr.pg.DB.ExecContext(ctx, query, holiday.Date, holiday.Title, holiday.Kind)
+	return err
+}
+
+func (sr *ScheduleRepo) DeleteHoliday(ctx context.Context, date string) error {
+	const query = `
+        WITH changed AS (
+            DELETE FROM holiday WHERE date = $1::date RETURNING date
+        ),` + bumpRevision + `
+        SELECT count(*) FROM changed
+    `
+	var deleted int
+	if err := sr.pg.DB.QueryRowContext(ctx, query, date).Scan(&deleted); err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+// ImportHolidays upserts production calendar days keeping the days added manually,
+// returns the number of created or updated days.
+func (sr *ScheduleRepo) ImportHolidays(ctx context.Context, holidays []models.Holiday) (int, error) {
+	const query = `
+        WITH changed AS (
+            INSERT INTO holiday (date, title, kind, source)
+            SELECT day.date, day.title, day.kind, 'production_calendar'
+            FROM json_to_recordset($1::json) AS day(date date, title text, kind text)
+            ON CONFLICT (date) DO UPDATE
+            SET title = excluded.title,
+                kind = excluded.kind,
+                updated_at = now()
+            WHERE holiday.source = 'production_calendar'
+              AND (holiday.title, holiday.kind) IS DISTINCT FROM (excluded.title, excluded.kind)
+            RETURNING date
+        ),` + bumpRevision + `
+        SELECT count(*) FROM changed
+    `
+	days, err := json.Marshal(holidays)
+	if err != nil {
+		return 0, err
+	}
+	var imported int
+	if err := sr.pg.DB.QueryRowContext(ctx, query, string(days)).Scan(&imported); err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+// GetGroupChanges returns up to limit lesson changes of the group after the change id, oldest first. Without the id
+// the first page starts with the changes logged after since.
+func (sr *ScheduleRepo) GetGroupChanges(
+	ctx context.Context,
+	group string,
+	since time.Time,
+	afterID int64,
+	limit int,
+) (*models.LessonChanges, error) {
+	const query = `
+SELECT json_build_object(
+  'group', g.number,
+  'since', $2::timestamptz,
+  'changes', coalesce((
+    SELECT json_agg(json_build_object(
+      'id', change.id,
+      'kind', change.kind,
+      'uid', change.uid,
+      'before', change.before,
+      'after', change.after,
+      'changed_at', change.changed_at
+    ) ORDER BY change.id)
+    FROM (
+      SELECT *
+      FROM lesson_change
+      WHERE group_number = g.number
+        AND id > $3
+        AND ($3 > 0 OR changed_at > $2::timestamptz)
+      ORDER BY id
+      LIMIT $4
+    ) change
+  ), '[]'::json)
+)
+FROM "group" g
+WHERE g.number = $1
+`
+	return findOneJsonContext[models.LessonChanges](ctx, sr.pg.DB, query, group, since, afterID, limit)
+}
@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+// lessonsPageQuery expects the owner and owner_lessons CTEs: занятия потока сливаются в одно,
+// страница выбирается по ключу (start_time, id).
+const lessonsPageQuery = `
+merged_lessons AS (
+  SELECT
+    min(ol.id) AS id,
+    ol.date,
+    ol.time,
+    ol.title,
+    ol.type,
+    min(ol.week_type) AS week_type,
+    ol.start_time,
+    ol.end_time,
+    array_agg(DISTINCT ol.group_number ORDER BY ol.group_number) AS groups,
+    array_agg(ol.id) AS lesson_ids
+  FROM owner_lessons ol
+  GROUP BY ol.date, ol.time, ol.title, ol.type, ol.start_time, ol.end_time
+),
+page AS (
+  SELECT *
+  FROM merged_lessons ml
+  WHERE $4::timestamp IS NULL OR (ml.start_time, ml.id) > ($4::timestamp, $5::int)
+  ORDER BY ml.start_time, ml.id
+  LIMIT $6
+)
+SELECT coalesce((
+  SELECT json_agg(json_build_object(
+    'id', p.id,
+    'date', to_char(p.date, 'YYYY-MM-DD'),
+    'time', p.time,
+    'title', p.title,
+    'type', p.type,
+    'week_type', p.week_type,
+    'start_time', p.start_time,
+    'end_time', p.end_time,
+    'groups', p.groups,
+    'teacher_auditoriums', coalesce(teacher_auditoriums.items, '[]'::jsonb)
+  ) ORDER BY p.start_time, p.id)
+  FROM page p
+  LEFT JOIN LATERAL (
+    SELECT jsonb_agg(pair.item ORDER BY pair.item) AS items
+    FROM (
+      SELECT DISTINCT jsonb_build_object(
+        'teacher', CASE
+          WHEN t.id IS NULL THEN NULL
+          ELSE jsonb_build_object(
+            'id', t.id,
+            'short_name', t.short_name,
+            'full_name', t.full_name
+          )
+        END,
+        'auditorium', CASE
+          WHEN a.id IS NULL THEN NULL
+          ELSE jsonb_build_object(
+            'id', a.id,
+            'number', a.number,
+            'display_name', a.number || ' ' || b.letter,
+            'building', ` + buildingJSON + `
+          )
+        END
+      ) AS item
+      FROM lesson_auditorium_teacher lat
+      LEFT JOIN teacher t ON t.id = lat.teacher_id
+      LEFT JOIN auditorium a ON a.id = lat.auditorium_id
+      LEFT JOIN building b ON b.id = a.building_id
+      WHERE lat.lesson_id = ANY(p.lesson_ids)
+        AND (lat.teacher_id IS NOT NULL OR lat.auditorium_id IS NOT NULL)
+    ) pair
+  ) teacher_auditoriums ON true
+), '[]'::json) AS lessons_json
+FROM owner;
+`
+
+func (sr *ScheduleRepo) GetGroupLessons(ctx context.Context, group string, filter models.LessonsFilter) ([]models.Lesson, error) {
+	const query = `
+WITH owner AS (
+  SELECT g.id FROM "group" g WHERE g.number = $1
+),
+owner_lessons AS (
+  SELECT l.id, l.date, l.time, l.title, l.type, l.week_type, l.start_time, l.end_time, g.number AS group_number
+  FROM lesson l
+  JOIN "group" g ON g.id = l.group_id
+  WHERE g.id = (SELECT id FROM owner)
+    AND l.date BETWEEN $2::date AND $3::date
+),
+` + lessonsPageQuery
+
+	return sr.getLessons(ctx, query, group, filter)
+}
+
+func (sr *ScheduleRepo) GetTeacherLessons(ctx context.Context, teacherID int, filter models.LessonsFilter) ([]models.Lesson, error) {
+	const query = `
+WITH owner AS (
+  SELECT t.id FROM teacher t WHERE t.id = $1
+),
+owner_lessons AS (
+  SELECT l.id, l.date, l.time, l.title, l.type, l.week_type, l.start_time, l.end_time, g.number AS group_number
+  FROM lesson l
+  JOIN "group" g ON g.id = l.group_id
+  WHERE l.date BETWEEN $2::date AND $3::date
+    AND EXISTS (
+      SELECT 1
+      FROM lesson_auditorium_teacher lat
+      WHERE lat.lesson_id = l.id AND lat.teacher_id = $1
+    )
+),
+` + lessonsPageQuery
+
+	return sr.getLessons(ctx, query, teacherID, filter)
+}
+
+func (sr *ScheduleRepo) GetAuditoriumLessons(ctx context.Context, auditoriumID int, filter models.LessonsFilter) ([]models.Lesson, error) {
+	const query = `
+WITH owner AS (
+  SELECT a.id FROM auditorium a WHERE a.id = $1
+),
+owner_lessons AS (
+  SELECT l.id, l.date, l.time, l.title, l.type, l.week_type, l.start_time, l.end_time, g.number AS group_number
+  FROM lesson l
+  JOIN "group" g ON g.id = l.group_id
+  WHERE l.date BETWEEN $2::date AND $3::date
+    AND EXISTS (
+      SELECT 1
+      FROM lesson_auditorium_teacher lat
+      WHERE lat.lesson_id = l.id AND lat.auditorium_id = $1
+    )
+),
+` + lessonsPageQuery
+
+	return sr.getLessons(ctx, query, auditoriumID, filter)
+}
+
+func (sr *ScheduleRepo) getLessons(ctx context.Context, query string, owner any, filter models.LessonsFilter) ([]models.Lesson, error) {
+	lessons, err := findOneJsonContext[[]models.Lesson](
+		ctx, sr.pg.DB, query,
+		owner, filter.From, filter.To, filter.AfterStart, filter.AfterID, filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return *lessons, nil
+}
@@ -43,7 +43,26 @@ func findOneJsonContext[T any](ctx context.Context, pg *sqlx.DB, query string, a
 	return result, err
 }
 
-func (sr *ScheduleRepo) groupExists(ctx context.Context, group string) (bool, error) { //nolint:unused // use in future
+// buildingJSON is a models.Building of the building aliased b.
+const buildingJSON = `json_build_object(
+            'id', b.id,
+            'letter', b.letter,
+            'title', b.title,
+            'address', b.address,
+            'latitude', b.latitude,
+            'longitude', b.longitude
+        )`
+
+// calendarBuildingJSON is a models.CalendarBuilding of the building table, where calendar events are held.
+const calendarBuildingJSON = `json_build_object(
+      'letter', building.letter,
+      'title', building.title,
+      'address', coalesce(building.address, ''),
+      'latitude', building.latitude,
+      'longitude', building.longitude
+    )`
+
+func (sr *ScheduleRepo) groupExists(ctx context.Context, group string) (bool, error) {
 	var exists bool
 	err := sr.pg.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM "group" WHERE number = $1)`, group).Scan(&exists)
 	if err != nil {
@@ -52,18 +71,21 @@ func (sr *ScheduleRepo) groupExists(ctx context.Context, group string) (bool, er
 	return exists, nil
 }
 
-func (sr *ScheduleRepo) GetScheduleByGroup(ctx context.Context, group string, startDate, endDate time.Time) (*models.StudentSchedule, error) {
-	// TODO: remove
-	if startDate.Year() == 2026 && startDate.Month() == 2 && startDate.Day() == 2 {
-		endDate = time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
-	}
-
+// GetScheduleByGroup returns the two-week schedule from startDate, numeratorStart is the numerator anchor
+// of the semester, empty to find the week types from the lessons.
+func (sr *ScheduleRepo) GetScheduleByGroup(
+	ctx context.Context,
+	group string,
+	startDate, endDate time.Time,
+	numeratorStart string,
+) (*models.StudentSchedule, error) {
 	const query = `
 WITH params AS (
   SELECT
     $1::date AS start_date,
     $2::date AS end_date,
     $3::varchar AS group_number,
+    $4::date AS numerator_start,
     g.id AS group_id,
     f.title_short AS faculty,
     g.course AS course
@@ -244,17 +266,7 @@ week_classification AS (
   FROM reference_lesson rl
 ),
 
-final_week_types AS (
-  SELECT
-    wc.*,
-    COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) AS final_first_week_type,
-    COALESCE(
-      wc.second_week_actual_type, 
-      CASE WHEN COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) = 'numerator' 
-        THEN 'denominator' ELSE 'numerator' END
-    ) AS final_second_week_type
-  FROM week_classification wc
-),
+` + finalWeekTypes + `,
 
 -- Классификация занятий с unknown типами на основе их даты
 lessons_with_resolved_types AS (
@@ -414,21 +426,23 @@ FROM params p
 CROSS JOIN period_strings ps;
 `
 
-	return findOneJsonContext[models.StudentSchedule](ctx, sr.pg.DB, query, startDate, endDate, group)
+	return findOneJsonContext[models.StudentSchedule](ctx, sr.pg.DB, query, startDate, endDate, group, nullString(numeratorStart))
 }
 
-func (sr *ScheduleRepo) GetSchedulesByGroups(ctx context.Context, startDate, endDate time.Time, groups []string) ([]*models.StudentSchedule, error) {
-	// TODO: remove
-	if startDate.Year() == 2026 && startDate.Month() == 2 && startDate.Day() == 2 {
-		endDate = time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
-	}
+func (sr *ScheduleRepo) GetSchedulesByGroups(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	numeratorStart string,
+	groups []string,
+) ([]*models.StudentSchedule, error) {
 	const query = `
 -- Запрос для получения расписания групп из списка []*models.StudentSchedule
 WITH params AS (
   SELECT
     $1::date AS start_date,
     $2::date AS end_date,
-    $3::text[] AS group_numbers -- массив номеров групп
+    $3::text[] AS group_numbers, -- массив номеров групп
+    $4::date AS numerator_start
 ),
 
 selected_groups AS (
@@ -555,7 +569,8 @@ input_weeks AS (
     sg.faculty,
     sg.course,
     p.start_date AS first_week_monday,
-    p.start_date + INTERVAL '7 days' AS second_week_monday
+    p.start_date + INTERVAL '7 days' AS second_week_monday,
+    p.numerator_start
   FROM selected_groups sg
   CROSS JOIN params p
 ),
@@ -632,17 +647,7 @@ week_classification AS (
   FROM reference_lesson rl
 ),
 
-final_week_types AS (
-  SELECT
-    wc.*,
-    COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) AS final_first_week_type,
-    COALESCE(
-      wc.second_week_actual_type, 
-      CASE WHEN COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) = 'numerator' 
-        THEN 'denominator' ELSE 'numerator' END
-    ) AS final_second_week_type
-  FROM week_classification wc
-),
+` + finalWeekTypes + `,
 
 -- Классификация занятий с unknown типами на основе их даты
 lessons_with_resolved_types AS (
@@ -821,7 +826,7 @@ SELECT json_agg(
 FROM period_strings ps
 LEFT JOIN lessons_times lt ON ps.group_id = lt.group_id;`
 
-	res, err := findOneJsonContext[[]*models.StudentSchedule](ctx, sr.pg.DB, query, startDate, endDate, groups)
+	res, err := findOneJsonContext[[]*models.StudentSchedule](ctx, sr.pg.DB, query, startDate, endDate, groups, nullString(numeratorStart))
 	if err != nil {
 		return nil, err
 	}
@@ -961,7 +966,12 @@ func (sr *ScheduleRepo) GetCourseFaculties(ctx context.Context, course int, star
 	return findOneJsonContext[models.CourseFaculties](ctx, sr.pg.DB, query, course, startDate, endDate)
 }
 
-func (sr *ScheduleRepo) GetTeacherSchedule(ctx context.Context, teacherID int, startDate, endDate time.Time) (*models.TeacherSchedule, error) {
+func (sr *ScheduleRepo) GetTeacherSchedule(
+	ctx context.Context,
+	teacherID int,
+	startDate, endDate time.Time,
+	numeratorStart string,
+) (*models.TeacherSchedule, error) {
 	const query = `
 WITH teacher_info AS (
   SELECT
@@ -994,7 +1004,8 @@ params AS (
     $3::integer AS teacher_id,
     $1::date AS first_week_monday, 
     $1::date + INTERVAL '7 days' AS second_week_monday,  
-    $1::date AS reference_date
+    $1::date AS reference_date,
+    $4::date AS numerator_start
 ),
 
 lesson_rows AS (
@@ -1170,17 +1181,7 @@ week_classification AS (
   FROM reference_lesson rl
 ),
 
-final_week_types AS (
-  SELECT
-    wc.*,
-    COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) AS final_first_week_type,
-    COALESCE(
-      wc.second_week_actual_type,
-      CASE WHEN COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) = 'numerator'
-        THEN 'denominator' ELSE 'numerator' END
-    ) AS final_second_week_type
-  FROM week_classification wc
-),
+` + finalWeekTypes + `,
 
 period_strings AS (
   SELECT
@@ -1388,7 +1389,7 @@ SELECT json_build_object(
 FROM period_strings ps
 JOIN teacher_info ti ON ti.id = $3;`
 
-	return findOneJsonContext[models.TeacherSchedule](ctx, sr.pg.DB, query, startDate, endDate, teacherID)
+	return findOneJsonContext[models.TeacherSchedule](ctx, sr.pg.DB, query, startDate, endDate, teacherID, nullString(numeratorStart))
 }
 
 func (sr *ScheduleRepo) GetAllTeachers(ctx context.Context) (*models.TeachersList, error) {
@@ -1518,13 +1519,19 @@ FROM (
 	return findOneJsonContext[models.TeachersList](ctx, sr.pg.DB, query, facultyID, departmentID)
 }
 
-func (sr *ScheduleRepo) GetAuditoriumSchedule(ctx context.Context, startDate, endDate time.Time, auditoriumID int) (*models.AuditoriumSchedule, error) {
+func (sr *ScheduleRepo) GetAuditoriumSchedule(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	numeratorStart string,
+	auditoriumID int,
+) (*models.AuditoriumSchedule, error) {
 	const query = `
 WITH params AS (
   SELECT
     $1::date AS start_date,
     $2::date AS end_date,
-    $3::int AS auditorium_id
+    $3::int AS auditorium_id,
+    $4::date AS numerator_start
 ),
 
 weekdays AS (
@@ -1774,17 +1781,7 @@ week_classification AS (
   FROM reference_lesson rl
 ),
 
-final_week_types AS (
-  SELECT
-    wc.*,
-    COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) AS final_first_week_type,
-    COALESCE(
-      wc.second_week_actual_type, 
-      CASE WHEN COALESCE(wc.first_week_actual_type, wc.predicted_first_week_type) = 'numerator' 
-        THEN 'denominator' ELSE 'numerator' END
-    ) AS final_second_week_type
-  FROM week_classification wc
-),
+` + finalWeekTypes + `,
 
 period_strings AS (
   SELECT
@@ -1900,7 +1897,7 @@ SELECT json_build_object(
 FROM auditorium_info ai
 CROSS JOIN period_strings ps;
 `
-	return findOneJsonContext[models.AuditoriumSchedule](ctx, sr.pg.DB, query, startDate, endDate, auditoriumID)
+	return findOneJsonContext[models.AuditoriumSchedule](ctx, sr.pg.DB, query, startDate, endDate, auditoriumID, nullString(numeratorStart))
 }
 
 func (sr *ScheduleRepo) GetAuditorium(ctx context.Context, auditoriumID int) (*models.Auditorium, error) {
@@ -1909,11 +1906,7 @@ func (sr *ScheduleRepo) GetAuditorium(ctx context.Context, auditoriumID int) (*m
             'id', a.id,
             'number', a.number,
             'display_name', a.number || ' ' || b.letter,
-            'building', json_build_object(
-                'id', b.id,
-                'letter', b.letter,
-                'title', b.title
-            )
+            'building', ` + buildingJSON + `
         ) AS auditorium_json
         FROM auditorium a
         JOIN building b ON a.building_id = b.id
@@ -1929,11 +1922,7 @@ func (sr *ScheduleRepo) GetAuditoriumsList(ctx context.Context, buildingId int)
                 'id', a.id,
                 'number', a.number,
                 'display_name', a.number || ' ' || b.letter,
-                'building', json_build_object(
-                    'id', b.id,
-                    'letter', b.letter,
-                    'title', b.title
-                )
+                'building', ` + buildingJSON + `
             ) ORDER BY b.id, a.number
         ) AS auditoriums_json
         FROM auditorium a
@@ -1944,14 +1933,194 @@ func (sr *ScheduleRepo) GetAuditoriumsList(ctx context.Context, buildingId int)
 	return *res, err
 }
 
+// GetLessonSlots returns the lesson time grid of the date range, or of all lessons when there are none in it.
+func (sr *ScheduleRepo) GetLessonSlots(ctx context.Context, startDate, endDate time.Time) ([]models.LessonSlot, error) {
+	const query = `
+        WITH range_slots AS (
+            SELECT l.time, min(l.start_time::time) AS start_time, max(l.end_time::time) AS end_time
+            FROM lesson l
+            WHERE l.date BETWEEN $1::date AND $2::date
+            GROUP BY l.time
+        ),
+        slots AS (
+            SELECT * FROM range_slots
+            UNION ALL
+            SELECT l.time, min(l.start_time::time), max(l.end_time::time)
+            FROM lesson l
+            WHERE NOT EXISTS (SELECT 1 FROM range_slots)
+            GROUP BY l.time
+        )
+        SELECT coalesce(json_agg(
+            json_build_object(
+                'time', time,
+                'start', to_char(start_time, 'HH24:MI'),
+                'end', to_char(end_time, 'HH24:MI')
+            ) ORDER BY start_time, end_time
+        ), '[]'::json) AS slots_json
+        FROM slots
+    `
+	res, err := findOneJsonContext[[]models.LessonSlot](ctx, sr.pg.DB, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+// GetAuditoriumsOccupancy returns auditoriums of the building (all when buildingID is 0)
+// with the lessons held in them on date.
+func (sr *ScheduleRepo) GetAuditoriumsOccupancy(ctx context.Context, date time.Time, buildingID int) ([]models.AuditoriumOccupancy, error) {
+	const query = `
+        SELECT coalesce(json_agg(
+            json_build_object(
+                'auditorium', json_build_object(
+                    'id', a.id,
+                    'number', a.number,
+                    'display_name', a.number || ' ' || b.letter,
+                    'building', ` + buildingJSON + `
+                ),
+                'busy', coalesce((
+                    SELECT json_agg(DISTINCT jsonb_build_object(
+                        'time', l.time,
+                        'start', to_char(l.start_time, 'HH24:MI'),
+                        'end', to_char(l.end_time, 'HH24:MI')
+                    ))
+                    FROM lesson_auditorium_teacher lat
+                    JOIN lesson l ON l.id = lat.lesson_id
+                    WHERE lat.auditorium_id = a.id
+                      AND l.date = $1::date
+                ), '[]'::json)
+            ) ORDER BY b.id, a.number
+        ), '[]'::json) AS occupancy_json
+        FROM auditorium a
+        JOIN building b ON a.building_id = b.id
+        WHERE ($2 = 0 OR b.id = $2)
+    `
+	res, err := findOneJsonContext[[]models.AuditoriumOccupancy](ctx, sr.pg.DB, query, date, buildingID)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+// GetLessonIntervals returns the distinct lesson times of the groups and teachers in the date range.
+func (sr *ScheduleRepo) GetLessonIntervals(
+	ctx context.Context,
+	startDate, endDate time.Time,
+	groups []string,
+	teacherIDs []int,
+) ([]models.LessonInterval, error) {
+	const query = `
+        SELECT coalesce(json_agg(DISTINCT jsonb_build_object(
+            'date', to_char(l.date, 'YYYY-MM-DD'),
+            'start', to_char(l.start_time, 'HH24:MI'),
+            'end', to_char(l.end_time, 'HH24:MI')
+        )), '[]'::json) AS intervals_json
+        FROM lesson l
+        JOIN "group" g ON g.id = l.group_id
+        WHERE l.date BETWEEN $1::date AND $2::date
+          AND (
+            g.number = ANY($3::text[])
+            OR EXISTS (
+                SELECT 1
+                FROM lesson_auditorium_teacher lat
+                WHERE lat.lesson_id = l.id
+                  AND lat.teacher_id = ANY($4::integer[])
+            )
+          )
+    `
+	res, err := findOneJsonContext[[]models.LessonInterval](ctx, sr.pg.DB, query, startDate, endDate, groups, teacherIDs)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+// GetScheduledLessons returns every lesson in the date range ordered by date and start time.
+func (sr *ScheduleRepo) GetScheduledLessons(ctx context.Context, startDate, endDate time.Time) ([]models.ScheduledLesson, error) {
+	const query = `
+        SELECT coalesce(json_agg(
+            json_build_object(
+                'id', l.id,
+                'date', to_char(l.date, 'YYYY-MM-DD'),
+                'time', l.time,
+                'start', to_char(l.start_time, 'HH24:MI'),
+                'end', to_char(l.end_time, 'HH24:MI'),
+                'title', l.title,
+                'type', l.type,
+                'group', g.number,
+                'faculty_id', g.faculty_id,
+                'teacher_auditoriums', coalesce((
+                    SELECT json_agg(json_build_object(
+                        'teacher', CASE WHEN t.id IS NULL THEN NULL ELSE json_build_object(
+                            'id', t.id,
+                            'full_name', t.full_name,
+                            'short_name', t.short_name
+                        ) END,
+                        'auditorium', CASE WHEN a.id IS NULL THEN NULL ELSE json_build_object(
+                            'id', a.id,
+                            'number', a.number,
+                            'display_name', a.number || ' ' || b.letter,
+                            'building', ` + buildingJSON + `
+                        ) END
+                    ) ORDER BY lat.id)
+                    FROM lesson_auditorium_teacher lat
+                    LEFT JOIN teacher t ON t.id = lat.teacher_id
+                    LEFT JOIN auditorium a ON a.id = lat.auditorium_id
+                    LEFT JOIN building b ON b.id = a.building_id
+                    WHERE lat.lesson_id = l.id
+                ), '[]'::json)
+            ) ORDER BY l.date, l.start_time, l.id
+        ), '[]'::json) AS lessons_json
+        FROM lesson l
+        JOIN "group" g ON g.id = l.group_id
+        WHERE l.date BETWEEN $1::date AND $2::date
+    `
+	res, err := findOneJsonContext[[]models.ScheduledLesson](ctx, sr.pg.DB, query, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
+// GetSearchEntries returns every group, teacher, auditorium, building and lesson title with the names to search by.
+func (sr *ScheduleRepo) GetSearchEntries(ctx context.Context) ([]models.SearchEntry, error) {
+	const query = `
+        SELECT coalesce(json_agg(e ORDER BY e.type, e.title), '[]'::json) AS entries_json
+        FROM (
+            SELECT 'group'::text AS type, g.id, g.number::text AS title, f.title_short::text AS subtitle,
+                   json_build_array(g.number) AS names
+            FROM "group" g
+            JOIN faculty f ON f.id = g.faculty_id
+            UNION ALL
+            SELECT 'teacher', t.id, t.full_name::text, t.short_name::text,
+                   json_build_array(t.full_name, t.short_name)
+            FROM teacher t
+            UNION ALL
+            SELECT 'auditorium', a.id, (a.number || ' ' || b.letter)::text, b.title::text,
+                   json_build_array(a.number || ' ' || b.letter)
+            FROM auditorium a
+            JOIN building b ON b.id = a.building_id
+            UNION ALL
+            SELECT 'building', b.id, b.title::text, b.address::text,
+                   json_build_array(b.title, b.letter)
+            FROM building b
+            UNION ALL
+            SELECT 'subject', 0, l.title::text, NULL,
+                   json_build_array(l.title)
+            FROM (SELECT DISTINCT title FROM lesson) l
+        ) e
+    `
+	res, err := findOneJsonContext[[]models.SearchEntry](ctx, sr.pg.DB, query)
+	if err != nil {
+		return nil, err
+	}
+	return *res, nil
+}
+
 func (sr *ScheduleRepo) GetBuildingsList(ctx context.Context) ([]*models.Building, error) {
 	const query = `
         SELECT json_agg(
-            json_build_object(
-                'id', b.id,
-                'letter', b.letter,
-                'title', b.title
-            ) ORDER BY b.id
+            ` + buildingJSON + ` ORDER BY b.id
         ) AS buildings_json
         FROM building b
     `
@@ -1961,18 +2130,14 @@ func (sr *ScheduleRepo) GetBuildingsList(ctx context.Context) ([]*models.Buildin
 
 func (sr *ScheduleRepo) GetBuilding(ctx context.Context, buildingId int) (*models.Building, error) {
 	const query = `
-        SELECT json_build_object(
-            'id', b.id,
-            'letter', b.letter,
-            'title', b.title
-        ) AS building_json
+        SELECT ` + buildingJSON + ` AS building_json
         FROM building b
         WHERE b.id = $1
     `
 	return findOneJsonContext[models.Building](ctx, sr.pg.DB, query, buildingId)
 }
 
-func (sr *ScheduleRepo) GetGroupCalendar(ctx context.Context, group string) (*models.GroupCalendar, error) {
+func (sr *ScheduleRepo) GetGroupCalendar(ctx context.Context, group string) (*models.Calendar, error) {
 	const query = `
 WITH selected_group AS (
   SELECT id, number
@@ -1994,7 +2159,9 @@ active_events AS (
       'end_time', to_char(l.end_time, 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
       'title', l.title,
       'lesson_type', l.type,
+      'groups', json_build_array(g.number),
       'teacher_auditoriums', coalesce(teacher_auditoriums.items, '[]'::json),
+      'buildings', coalesce(buildings.items, '[]'::json),
       'sequence', revision.revision,
       'cancelled', false
     ) AS event
@@ -2020,6 +2187,16 @@ active_events AS (
         AND (teacher.id IS NOT NULL OR auditorium.id IS NOT NULL)
     ) distinct_pairs
   ) teacher_auditoriums ON true
+  LEFT JOIN LATERAL (
+    SELECT json_agg(` + calendarBuildingJSON + ` ORDER BY building.letter) AS items
+    FROM building
+    WHERE building.id IN (
+      SELECT auditorium.building_id
+      FROM lesson_auditorium_teacher link
+      JOIN auditorium ON auditorium.id = link.auditorium_id
+      WHERE link.lesson_id = l.id
+    )
+  ) buildings ON true
   WHERE l.date >= current_date - 180
 ),
 cancelled_events AS (
@@ -2032,7 +2209,17 @@ cancelled_events AS (
       'end_time', to_char(deleted.end_time, 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
       'title', deleted.title,
       'lesson_type', deleted.lesson_type,
+      'groups', json_build_array(deleted.group_number),
       'teacher_auditoriums', deleted.teacher_auditoriums,
+      'buildings', (
+        SELECT coalesce(json_agg(` + calendarBuildingJSON + ` ORDER BY building.letter), '[]'::json)
+        FROM building
+        WHERE building.id IN (
+          SELECT auditorium.building_id
+          FROM auditorium
+          WHERE auditorium.id = ANY(deleted.auditorium_ids)
+        )
+      ),
       'sequence', deleted.sequence,
       'cancelled', true
     ) AS event
@@ -2046,7 +2233,8 @@ events AS (
   SELECT * FROM cancelled_events
 )
 SELECT json_build_object(
-  'group', selected_group.number,
+  'owner', 'group',
+  'name', selected_group.number,
   'updated_at', to_char(
     current_revision.updated_at AT TIME ZONE 'UTC',
     'YYYY-MM-DD"T"HH24:MI:SS"Z"'
@@ -2059,5 +2247,220 @@ SELECT json_build_object(
 FROM selected_group
 CROSS JOIN current_revision
 `
-	return findOneJsonContext[models.GroupCalendar](ctx, sr.pg.DB, query, group)
+	return findOneJsonContext[models.Calendar](ctx, sr.pg.DB, query, group)
+}
+
+func (sr *ScheduleRepo) GetTeacherCalendar(ctx context.Context, teacherID int) (*models.Calendar, error) {
+	query := ownerCalendarQuery(models.CalendarOwnerTeacher, `
+  SELECT id, full_name AS name
+  FROM teacher
+  WHERE id = $1`)
+	return findOneJsonContext[models.Calendar](ctx, sr.pg.DB, query, teacherID)
+}
+
+func (sr *ScheduleRepo) GetAuditoriumCalendar(ctx context.Context, auditoriumID int) (*models.Calendar, error) {
+	query := ownerCalendarQuery(models.CalendarOwnerAuditorium, `
+  SELECT a.id, concat_ws(' ', a.number, b.letter) AS name
+  FROM auditorium a
+  JOIN building b ON b.id = a.building_id
+  WHERE a.id = $1`)
+	return findOneJsonContext[models.Calendar](ctx, sr.pg.DB, query, auditoriumID)
+}
+
+// ownerCalendarQuery builds the calendar of a teacher or an auditorium, selectedOwner selects its id and name.
+// The owner is the teacher_id or auditorium_id of lesson_auditorium_teacher, teacher_ids or auditorium_ids
+// of calendar_deleted_event and the teacher or auditorium in the lesson versions of lesson_change.
+func ownerCalendarQuery(owner models.CalendarOwner, selectedOwner string) string {
+	key := string(owner)
+	return `
+WITH selected_owner AS (` + selectedOwner + `
+),
+current_revision AS (
+  SELECT revision, updated_at
+  FROM calendar_revision
+  WHERE id = 1
+),
+-- Поточные занятия хранятся отдельной строкой для каждой группы,
+-- в календаре преподавателя или аудитории они объединяются в одно событие
+owner_lessons AS (
+  SELECT
+    calendar_event_uid('` + key + `-' || o.id, l.date, l.start_time, l.title, l.type) AS uid,
+    l.start_time,
+    max(l.end_time) AS end_time,
+    l.title,
+    l.type,
+    array_agg(DISTINCT l.id) AS lesson_ids,
+    json_agg(DISTINCT g.number ORDER BY g.number) AS groups
+  FROM selected_owner o
+  JOIN lesson_auditorium_teacher owner_link ON owner_link.` + key + `_id = o.id
+  JOIN lesson l ON l.id = owner_link.lesson_id
+  JOIN "group" g ON g.id = l.group_id
+  WHERE l.date >= current_date - 180
+  GROUP BY o.id, l.date, l.start_time, l.title, l.type
+),
+active_events AS (
+  SELECT
+    ol.start_time AS sort_time,
+    ol.uid,
+    json_build_object(
+      'uid', ol.uid,
+      'start_time', to_char(ol.start_time, 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+      'end_time', to_char(ol.end_time, 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+      'title', ol.title,
+      'lesson_type', ol.type,
+      'groups', ol.groups,
+      'teacher_auditoriums', coalesce(teacher_auditoriums.items, '[]'::json),
+      'buildings', coalesce(buildings.items, '[]'::json),
+      'sequence', revision.revision,
+      'cancelled', false
+    ) AS event
+  FROM owner_lessons ol
+  CROSS JOIN current_revision revision
+  LEFT JOIN LATERAL (
+    SELECT json_agg(
+      json_build_object('teacher', teacher_name, 'auditorium', auditorium_name)
+      ORDER BY teacher_name, auditorium_name
+    ) AS items
+    FROM (
+      SELECT DISTINCT
+        coalesce(teacher.full_name, '') AS teacher_name,
+        CASE WHEN auditorium.id IS NULL THEN ''
+          ELSE concat_ws(' ', auditorium.number, building.letter)
+        END AS auditorium_name
+      FROM lesson_auditorium_teacher link
+      LEFT JOIN teacher ON teacher.id = link.teacher_id
+      LEFT JOIN auditorium ON auditorium.id = link.auditorium_id
+      LEFT JOIN building ON building.id = auditorium.building_id
+      WHERE link.lesson_id = ANY(ol.lesson_ids)
+        AND (teacher.id IS NOT NULL OR auditorium.id IS NOT NULL)
+    ) distinct_pairs
+  ) teacher_auditoriums ON true
+  LEFT JOIN LATERAL (
+    SELECT json_agg(` + calendarBuildingJSON + ` ORDER BY building.letter) AS items
+    FROM building
+    WHERE building.id IN (
+      SELECT auditorium.building_id
+      FROM lesson_auditorium_teacher link
+      JOIN auditorium ON auditorium.id = link.auditorium_id
+      WHERE link.lesson_id = ANY(ol.lesson_ids)
+    )
+  ) buildings ON true
+),
+-- Удалённые занятия и занятия, у которых сменился преподаватель или аудитория: занятие осталось в расписании
+-- группы, поэтому парсер не отменяет его, а из календаря прежнего владельца оно пропадает.
+deleted_events AS (
+  SELECT
+    calendar_event_uid('` + key + `-' || o.id, deleted.start_time::date, deleted.start_time,
+      deleted.title, deleted.lesson_type) AS uid,
+    deleted.start_time,
+    deleted.end_time,
+    deleted.title,
+    deleted.lesson_type,
+    deleted.group_number,
+    deleted.teacher_auditoriums,
+    deleted.auditorium_ids,
+    deleted.sequence
+  FROM selected_owner o
+  JOIN calendar_deleted_event deleted ON o.id = ANY(deleted.` + key + `_ids)
+  WHERE deleted.start_time::date >= current_date - 180
+
+  UNION ALL
+
+  SELECT
+    calendar_event_uid('` + key + `-' || o.id, before.start_time::date, before.start_time,
+      before.title, before.lesson_type),
+    before.start_time,
+    before.end_time,
+    before.title,
+    before.lesson_type,
+    change.group_number,
+    change.before->'teacher_auditoriums',
+    ARRAY(
+      SELECT auditorium.id
+      FROM jsonb_array_elements(change.before->'teacher_auditoriums') pair
+      JOIN auditorium ON true
+      JOIN building ON building.id = auditorium.building_id
+      WHERE concat_ws(' ', auditorium.number, building.letter) = pair->>'auditorium'
+    ),
+    coalesce(nullif(change.revision, 0), revision.revision)
+  FROM selected_owner o
+  CROSS JOIN current_revision revision
+  JOIN lesson_change change ON change.before->'teacher_auditoriums'
+    @> jsonb_build_array(jsonb_build_object('` + key + `', o.name))
+  CROSS JOIN LATERAL (
+    SELECT
+      (change.before->>'start_time')::timestamp AS start_time,
+      (change.before->>'end_time')::timestamp AS end_time,
+      change.before->>'title' AS title,
+      nullif(change.before->>'lesson_type', '') AS lesson_type
+  ) before
+  WHERE before.start_time::date >= current_date - 180
+),
+-- Отмена одной из групп потока не отменяет событие, пока остались другие группы
+cancelled_events AS (
+  SELECT
+    min(deleted.start_time) AS sort_time,
+    deleted.uid,
+    json_build_object(
+      'uid', deleted.uid,
+      'start_time', to_char(min(deleted.start_time), 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+      'end_time', to_char(max(deleted.end_time), 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+      'title', min(deleted.title),
+      'lesson_type', min(deleted.lesson_type),
+      'groups', json_agg(DISTINCT deleted.group_number ORDER BY deleted.group_number),
+      'teacher_auditoriums', (
+        SELECT coalesce(jsonb_agg(DISTINCT item), '[]'::jsonb)
+        FROM deleted_events same_event
+        CROSS JOIN jsonb_array_elements(same_event.teacher_auditoriums) AS item
+        WHERE same_event.uid = deleted.uid
+      ),
+      'buildings', (
+        SELECT coalesce(json_agg(` + calendarBuildingJSON + ` ORDER BY building.letter), '[]'::json)
+        FROM building
+        WHERE building.id IN (
+          SELECT auditorium.building_id
+          FROM deleted_events same_event
+          JOIN auditorium ON auditorium.id = ANY(same_event.auditorium_ids)
+          WHERE same_event.uid = deleted.uid
+        )
+      ),
+      'sequence', max(deleted.sequence),
+      'cancelled', true
+    ) AS event
+  FROM deleted_events deleted
+  WHERE deleted.uid NOT IN (SELECT uid FROM active_events)
+  GROUP BY deleted.uid
+),
+events AS (
+  SELECT * FROM active_events
+  UNION ALL
+  SELECT * FROM cancelled_events
+)
+SELECT json_build_object(
+  'owner', '` + key + `',
+  'name', selected_owner.name,
+  'updated_at', to_char(
+    current_revision.updated_at AT TIME ZONE 'UTC',
+    'YYYY-MM-DD"T"HH24:MI:SS"Z"'
+  ),
+  'events', coalesce(
+    (SELECT json_agg(event ORDER BY sort_time, uid) FROM events),
+    '[]'::json
+  )
+)
+FROM selected_owner
+CROSS JOIN current_revision
+`
+}
+
+func (sr *ScheduleRepo) GetCalendarRevision(ctx context.Context) (*models.CalendarRevision, error) {
+	const query = `
+        SELECT json_build_object(
+            'revision', revision,
+            'updated_at', to_char(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"')
+        ) AS revision_json
+        FROM calendar_revision
+        WHERE id = 1
+    `
+	return findOneJsonContext[models.CalendarRevision](ctx, sr.pg.DB, query)
 }
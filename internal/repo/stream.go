@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const scheduleChangesChannel = "schedule_changes"
+
+// GetScheduleStreamSubscription keeps the existing groups and resolves teacher and auditorium ids to the names
+// used in lesson changes.
+func (sr *ScheduleRepo) GetScheduleStreamSubscription(
+	ctx context.Context,
+	groups []string,
+	teacherIDs, auditoriumIDs []int,
+) (*models.ScheduleStreamSubscription, error) {
+	const query = `
+        SELECT json_build_object(
+            'groups', coalesce((
+                SELECT json_agg(number ORDER BY number)
+                FROM "group"
+                WHERE number = ANY($1::text[])
+            ), '[]'::json),
+            'teachers', coalesce((
+                SELECT json_object_agg(id, full_name)
+                FROM teacher
+                WHERE id = ANY($2::integer[])
+            ), '{}'::json),
+            'auditoriums', coalesce((
+                SELECT json_object_agg(auditorium.id, concat_ws(' ', auditorium.number, building.letter))
+                FROM auditorium
+                JOIN building ON building.id = auditorium.building_id
+                WHERE auditorium.id = ANY($3::integer[])
+            ), '{}'::json)
+        ) AS subscription_json
+    `
+	return findOneJsonContext[models.ScheduleStreamSubscription](ctx, sr.pg.DB, query, groups, teacherIDs, auditoriumIDs)
+}
+
+func (sr *ScheduleRepo) GetLastLessonChangeID(ctx context.Context) (int64, error) {
+	var id int64
+	err := sr.pg.DB.QueryRowContext(ctx, `SELECT coalesce(max(id), 0) FROM lesson_change`).Scan(&id)
+	return id, err
+}
+
+// GetScheduleStreamChanges returns up to limit lesson changes after the change id, oldest first.
+func (sr *ScheduleRepo) GetScheduleStreamChanges(ctx context.Context, afterID int64, limit int,
+) (*models.ScheduleStreamBatch, error) {
+	const query = `
+SELECT json_build_object(
+  'revision', (SELECT revision FROM calendar_revision WHERE id = 1),
+  'changes', coalesce((
+    SELECT json_agg(json_build_object(
+      'id', change.id,
+      'group', change.group_number,
+      'date', left(coalesce(change.after->>'start_time', change.before->>'start_time'), 10),
+      'teachers', coalesce((
+        SELECT json_agg(DISTINCT pair->>'teacher')
+        FROM jsonb_array_elements(change.pairs) pair
+        WHERE pair->>'teacher' <> ''
+      ), '[]'::json),
+      'auditoriums', coalesce((
+        SELECT json_agg(DISTINCT pair->>'auditorium')
+        FROM jsonb_array_elements(change.pairs) pair
+        WHERE pair->>'auditorium' <> ''
+      ), '[]'::json)
+    ) ORDER BY change.id)
+    FROM (
+      SELECT
+        id,
+        group_number,
+        before,
+        after,
+        coalesce(before->'teacher_auditoriums', '[]'::jsonb)
+          || coalesce(after->'teacher_auditoriums', '[]'::jsonb) AS pairs
+      FROM lesson_change
+      WHERE id > $1
+      ORDER BY id
+      LIMIT $2
+    ) change
+  ), '[]'::json)
+) AS batch_json
+`
+	return findOneJsonContext[models.ScheduleStreamBatch](ctx, sr.pg.DB, query, afterID, limit)
+}
+
+// ListenScheduleChanges holds a dedicated connection listening to lesson change notifications and calls notify
+// with the last change id: with 0 once listening started, so missed changes can be read, and on every notification.
+// Returns when ctx is canceled or the connection breaks.
+func ListenScheduleChanges(ctx context.Context, dsn string, notify func(lastID int64)) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+scheduleChangesChannel); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	notify(0)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		lastID, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			return fmt.Errorf("notification payload %q: %w", notification.Payload, err)
+		}
+		notify(lastID)
+	}
+}
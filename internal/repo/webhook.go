@@ -0,0 +1,297 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const webhookJSON = `json_build_object(
+            'id', webhook.id,
+            'url', webhook.url,
+            'scope', webhook.scope,
+            'scope_id', coalesce(webhook.group_number, webhook.teacher_id::text, webhook.auditorium_id::text),
+            'active', webhook.active,
+            'last_revision', webhook.last_revision,
+            'created_at', webhook.created_at
+        )`
+
+// lastCommittedChangeID is the mark webhooks move to. Writes to lesson_change are serialized until commit
+// (migration 00014), so the visible max(id) is committed and no change with a smaller id appears later.
+const lastCommittedChangeID = `(SELECT coalesce(max(id), 0) FROM lesson_change)`
+
+// CreateWebhook subscribes to the changes after the current revision,
+// returns ErrNoResults when the group, teacher or auditorium does not exist.
+func (sr *ScheduleRepo) CreateWebhook(ctx context.Context, owner string, webhook *models.Webhook) error {
+	const query = `
+        WITH scope_owner AS (
+            SELECT number AS group_number, NULL::integer AS teacher_id, NULL::integer AS auditorium_id
+            FROM "group"
+            WHERE $4::text = 'group' AND number = $5::text
+            UNION ALL
+            SELECT NULL, id, NULL
+            FROM teacher
+            WHERE $4::text = 'teacher' AND id::text = $5::text
+            UNION ALL
+            SELECT NULL, NULL, id
+            FROM auditorium
+            WHERE $4::text = 'auditorium' AND id::text = $5::text
+        )
+        INSERT INTO webhook (
+            owner, url, secret, scope, group_number, teacher_id, auditorium_id, last_revision, last_change_id
+        )
+        SELECT
+            $1::text, $2::text, $3::text, $4::text, scope_owner.group_number, scope_owner.teacher_id, scope_owner.auditorium_id,
+            (SELECT revision FROM calendar_revision WHERE id = 1),
+            ` + lastCommittedChangeID + `
+        FROM scope_owner
+        LIMIT 1
+        RETURNING id, last_revision, created_at
+    `
+	err := sr.pg.DB.QueryRowContext(ctx, query,
+		owner, webhook.URL, webhook.Secret, webhook.Scope, webhook.ScopeID,
+	).Scan(&webhook.Id, &webhook.LastRevision, &webhook.CreatedAt)
+	return noRowsToNoResults(err)
+}
+
+func (sr *ScheduleRepo) GetWebhooks(ctx context.Context, owner string) ([]models.Webhook, error) {
+	const query = `
+        SELECT coalesce(json_agg(` + webhookJSON + ` ORDER BY webhook.id), '[]'::json) AS webhooks_json
+        FROM webhook
+        WHERE owner = $1
+    `
+	webhooks, err := findOneJsonContext[[]models.Webhook](ctx, sr.pg.DB, query, owner)
+	if err != nil {
+		return nil, err
+	}
+	return *webhooks, nil
+}
+
+func (sr *ScheduleRepo) GetWebhook(ctx context.Context, owner string, id int) (*models.Webhook, error) {
+	const query = `
+        SELECT ` + webhookJSON + ` AS webhook_json
+        FROM webhook
+        WHERE owner = $1 AND id = $2
+    `
+	return findOneJsonContext[models.Webhook](ctx, sr.pg.DB, query, owner, id)
+}
+
+// SetWebhookActive pauses or resumes a webhook, a resumed webhook skips the changes made while it was paused.
+func (sr *ScheduleRepo) SetWebhookActive(ctx context.Context, owner string, id int, active bool) error {
+	const query = `
+        UPDATE webhook
+        SET active = $3,
+            last_revision = CASE WHEN active THEN last_revision
+                ELSE (SELECT revision FROM calendar_revision WHERE id = 1) END,
+            last_change_id = CASE WHEN active THEN last_change_id
+                ELSE ` + lastCommittedChangeID + ` END,
+            updated_at = now()
+        WHERE owner = $1 AND id = $2
+        RETURNING id
+    `
+	return noRowsToNoResults(sr.pg.DB.QueryRowContext(ctx, query, owner, id, active).Scan(&id))
+}
+
+func (sr *ScheduleRepo) DeleteWebhook(ctx context.Context, owner string, id int) error {
+	result, err := sr.pg.DB.ExecContext(ctx, `DELETE FROM webhook WHERE owner = $1 AND id = $2`, owner, id)
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+// GetWebhookDeliveries returns the latest delivery attempts of the webhook, newest first.
+func (sr *ScheduleRepo) GetWebhookDeliveries(ctx context.Context, owner string, id, limit int) ([]models.WebhookDelivery, error) {
+	const query = `
+        SELECT coalesce((
+            SELECT json_agg(json_build_object(
+                'id', delivery.id,
+                'outbox_id', delivery.outbox_id,
+                'attempt', delivery.attempt,
+                'status_code', delivery.status_code,
+                'error', delivery.error,
+                'duration_ms', delivery.duration_ms,
+                'created_at', delivery.created_at
+            ) ORDER BY delivery.created_at DESC, delivery.id DESC)
+            FROM (
+                SELECT *
+                FROM webhook_delivery
+                WHERE webhook_id = webhook.id
+                ORDER BY created_at DESC, id DESC
+                LIMIT $3
+            ) delivery
+        ), '[]'::json) AS deliveries_json
+        FROM webhook
+        WHERE owner = $1 AND id = $2
+    `
+	deliveries, err := findOneJsonContext[[]models.WebhookDelivery](ctx, sr.pg.DB, query, owner, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	return *deliveries, nil
+}
+
+// GetWebhookBatches returns active webhooks behind the current revision with the logged changes in their scope.
+// Teachers and auditoriums match changes by the names in the lesson versions.
+func (sr *ScheduleRepo) GetWebhookBatches(ctx context.Context) ([]models.WebhookBatch, error) {
+	const query = `
+WITH current_revision AS (
+  SELECT revision
+  FROM calendar_revision
+  WHERE id = 1
+),
+last_change AS (
+  SELECT ` + lastCommittedChangeID + ` AS id
+)
+SELECT coalesce(json_agg(json_build_object(
+  'webhook', ` + webhookJSON + `,
+  'revision', revision.revision,
+  'last_change_id', last_change.id,
+  'changes', coalesce((
+    SELECT json_agg(json_build_object(
+      'id', change.id,
+      'kind', change.kind,
+      'group', change.group_number,
+      'uid', change.uid,
+      'before', change.before,
+      'after', change.after,
+      'changed_at', change.changed_at
+    ) ORDER BY change.id)
+    FROM lesson_change change
+    WHERE change.id > webhook.last_change_id
+      AND change.id <= last_change.id
+      AND CASE webhook.scope
+        WHEN 'group' THEN change.group_number = webhook.group_number
+        WHEN 'teacher' THEN EXISTS (
+          SELECT 1
+          FROM teacher
+          WHERE teacher.id = webhook.teacher_id
+            AND (coalesce(change.before->'teacher_auditoriums', '[]'::jsonb)
+              || coalesce(change.after->'teacher_auditoriums', '[]'::jsonb))
+              @> jsonb_build_array(jsonb_build_object('teacher', teacher.full_name))
+        )
+        ELSE EXISTS (
+          SELECT 1
+          FROM auditorium
+          JOIN building ON building.id = auditorium.building_id
+          WHERE auditorium.id = webhook.auditorium_id
+            AND (coalesce(change.before->'teacher_auditoriums', '[]'::jsonb)
+              || coalesce(change.after->'teacher_auditoriums', '[]'::jsonb))
+              @> jsonb_build_array(jsonb_build_object('auditorium', concat_ws(' ', auditorium.number, building.letter)))
+        )
+      END
+  ), '[]'::json)
+) ORDER BY webhook.id), '[]'::json) AS batches_json
+FROM webhook
+CROSS JOIN current_revision revision
+CROSS JOIN last_change
+WHERE webhook.active
+  AND webhook.last_revision < revision.revision
+`
+	batches, err := findOneJsonContext[[]models.WebhookBatch](ctx, sr.pg.DB, query)
+	if err != nil {
+		return nil, err
+	}
+	return *batches, nil
+}
+
+// EnqueueWebhookEvent moves the webhook to the revision and puts the payload to the outbox in one statement,
+// a nil payload only moves the webhook. Concurrent workers enqueue a revision once.
+func (sr *ScheduleRepo) EnqueueWebhookEvent(ctx context.Context, batch *models.WebhookBatch, payload []byte) error {
+	const query = `
+        WITH advanced AS (
+            UPDATE webhook
+            SET last_revision = $2,
+                last_change_id = $3,
+                updated_at = now()
+            WHERE id = $1 AND last_revision < $2
+            RETURNING id
+        )
+        INSERT INTO webhook_outbox (webhook_id, revision, payload)
+        SELECT advanced.id, $2, $4::jsonb
+        FROM advanced
+        WHERE $4::jsonb IS NOT NULL
+        ON CONFLICT (webhook_id, revision) DO NOTHING
+    `
+	var body any
+	if payload != nil {
+		body = string(payload)
+	}
+	_, err := sr.pg.DB.ExecContext(ctx, query, batch.Webhook.Id, batch.Revision, batch.LastChangeID, body)
+	return err
+}
+
+// ClaimWebhookEvents takes due outbox entries and postpones them by lease,
+// so other workers do not send them while the delivery is in progress.
+func (sr *ScheduleRepo) ClaimWebhookEvents(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookEvent, error) {
+	const query = `
+        WITH due AS (
+            SELECT id
+            FROM webhook_outbox
+            WHERE delivered_at IS NULL
+              AND failed_at IS NULL
+              AND next_attempt_at <= now()
+            ORDER BY next_attempt_at, id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        ),
+        claimed AS (
+            UPDATE webhook_outbox outbox
+            SET attempts = outbox.attempts + 1,
+                next_attempt_at = now() + make_interval(secs => $2)
+            FROM due
+            WHERE outbox.id = due.id
+            RETURNING outbox.id, outbox.webhook_id, outbox.payload, outbox.attempts
+        )
+        SELECT coalesce(json_agg(json_build_object(
+            'id', claimed.id,
+            'webhook_id', claimed.webhook_id,
+            'url', webhook.url,
+            'secret', webhook.secret,
+            'payload', claimed.payload,
+            'attempt', claimed.attempts
+        ) ORDER BY claimed.id), '[]'::json) AS events_json
+        FROM claimed
+        JOIN webhook ON webhook.id = claimed.webhook_id
+    `
+	events, err := findOneJsonContext[[]models.WebhookEvent](ctx, sr.pg.DB, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	return *events, nil
+}
+
+// CompleteWebhookEvent logs the delivery attempt and marks the event delivered, failed when retryAt is nil,
+// or due again at retryAt.
+func (sr *ScheduleRepo) CompleteWebhookEvent(
+	ctx context.Context,
+	event *models.WebhookEvent,
+	delivery *models.WebhookDelivery,
+	delivered bool,
+	retryAt *time.Time,
+) error {
+	const query = `
+        WITH logged AS (
+            INSERT INTO webhook_delivery (outbox_id, webhook_id, attempt, status_code, error, duration_ms)
+            VALUES ($1, $2, $3, $4, $5, $6)
+        )
+        UPDATE webhook_outbox
+        SET delivered_at = CASE WHEN $7 THEN now() END,
+            failed_at = CASE WHEN NOT $7 AND $8::timestamptz IS NULL THEN now() END,
+            next_attempt_at = coalesce($8::timestamptz, next_attempt_at)
+        WHERE id = $1
+    `
+	_, err := sr.pg.DB.ExecContext(ctx, query,
+		event.Id, event.WebhookID, event.Attempt, delivery.StatusCode, delivery.Error, delivery.DurationMs,
+		delivered, retryAt,
+	)
+	return err
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+func (s *ScheduleService) GetAcademicPeriods(ctx context.Context) ([]models.AcademicPeriod, error) {
+	return s.Repo.GetAcademicPeriods(ctx)
+}
+
+func (s *ScheduleService) GetAcademicPeriod(ctx context.Context, id int) (*models.AcademicPeriod, error) {
+	period, err := s.Repo.GetAcademicPeriod(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("academic period '%v' not found", id)}
+		}
+		return nil, err
+	}
+	return period, nil
+}
+
+func (s *ScheduleService) CreateAcademicPeriod(ctx context.Context, period *models.AcademicPeriod) error {
+	if err := s.validateAcademicPeriod(ctx, period); err != nil {
+		return err
+	}
+	return s.Repo.CreateAcademicPeriod(ctx, period)
+}
+
+func (s *ScheduleService) UpdateAcademicPeriod(ctx context.Context, period *models.AcademicPeriod) error {
+	if err := s.validateAcademicPeriod(ctx, period); err != nil {
+		return err
+	}
+	if err := s.Repo.UpdateAcademicPeriod(ctx, period); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return NotFoundError{fmt.Sprintf("academic period '%v' not found", period.Id)}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ScheduleService) DeleteAcademicPeriod(ctx context.Context, id int) error {
+	if err := s.Repo.DeleteAcademicPeriod(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return NotFoundError{fmt.Sprintf("academic period '%v' not found", id)}
+		}
+		return err
+	}
+	return nil
+}
+
+// validateAcademicPeriod checks the dates and that semesters do not overlap:
+// the semester of a date defines its week type.
+func (s *ScheduleService) validateAcademicPeriod(ctx context.Context, period *models.AcademicPeriod) error {
+	start, end, err := academicPeriodDates(period)
+	if err != nil {
+		return BadRequestError{err.Error()}
+	}
+	if end.Before(start) {
+		return BadRequestError{"end_date must not be before start_date"}
+	}
+	if period.Kind != models.AcademicPeriodSemester {
+		if period.NumeratorStart != "" {
+			return BadRequestError{"numerator_start is only allowed for semesters"}
+		}
+		return nil
+	}
+
+	numeratorStart, err := time.Parse(time.DateOnly, period.NumeratorStart)
+	if err != nil {
+		return BadRequestError{"numerator_start " + ErrInvalidDateFormat.Error()}
+	}
+	if numeratorStart.Weekday() != time.Monday {
+		return BadRequestError{"numerator_start must be a Monday"}
+	}
+
+	periods, err := s.Repo.GetAcademicPeriods(ctx)
+	if err != nil {
+		return err
+	}
+	for index := range periods {
+		other := &periods[index]
+		if other.Id == period.Id || other.Kind != models.AcademicPeriodSemester {
+			continue
+		}
+		otherStart, otherEnd, err := academicPeriodDates(other)
+		if err != nil {
+			return err
+		}
+		if !start.After(otherEnd) && !end.Before(otherStart) {
+			return BadRequestError{fmt.Sprintf("semester overlaps with '%v'", other.Title)}
+		}
+	}
+	return nil
+}
+
+func academicPeriodDates(period *models.AcademicPeriod) (start, end time.Time, err error) {
+	if start, err = time.Parse(time.DateOnly, period.StartDate); err != nil {
+		return start, end, fmt.Errorf("start_date %w", ErrInvalidDateFormat)
+	}
+	if end, err = time.Parse(time.DateOnly, period.EndDate); err != nil {
+		return start, end, fmt.Errorf("end_date %w", ErrInvalidDateFormat)
+	}
+	return start, end, nil
+}
+
+// CurrentAcademicPeriod returns the most specific period containing date: an exam session or practice
+// rather than the semester around it.
+func CurrentAcademicPeriod(periods []models.AcademicPeriod, date time.Time) *models.AcademicPeriod {
+	date = dateOnly(date)
+	var current *models.AcademicPeriod
+	var currentDays float64
+	for index := range periods {
+		start, end, err := academicPeriodDates(&periods[index])
+		if err != nil || date.Before(start) || date.After(end) {
+			continue
+		}
+		if days := end.Sub(start).Hours(); current == nil || days < currentDays {
+			current, currentDays = &periods[index], days
+		}
+	}
+	return current
+}
+
+// AcademicSemester returns the semester containing date, otherwise the last one started before it,
+// so week types continue through holidays.
+func AcademicSemester(periods []models.AcademicPeriod, date time.Time) *models.AcademicPeriod {
+	date = dateOnly(date)
+	var semester *models.AcademicPeriod
+	for index := range periods {
+		period := &periods[index]
+		start, _, err := academicPeriodDates(period)
+		if err != nil || period.Kind != models.AcademicPeriodSemester || start.After(date) {
+			continue
+		}
+		// Периоды отсортированы по дате начала.
+		semester = period
+	}
+	if semester == nil {
+		for index := range periods {
+			if periods[index].Kind == models.AcademicPeriodSemester {
+				return &periods[index]
+			}
+		}
+	}
+	return semester
+}
+
+// WeekType returns the week type of date by the numerator anchor of its semester,
+// empty when there are no semesters.
+func WeekType(periods []models.AcademicPeriod, date time.Time) string {
+	semester := AcademicSemester(periods, date)
+	if semester == nil {
+		return ""
+	}
+	numeratorStart, err := time.Parse(time.DateOnly, semester.NumeratorStart)
+	if err != nil {
+		return ""
+	}
+	return utils.GetWeekType(numeratorStart, date)
+}
+
+// EnglishWeekType translates a WeekType result for the fields that use numerator and denominator.
+func EnglishWeekType(weekType string) string {
+	switch weekType {
+	case "числитель":
+		return "numerator"
+	case "знаменатель":
+		return "denominator"
+	default:
+		return ""
+	}
+}
+
+// AcademicWeekBounds returns the two-week schedule window of date. The window does not cross
+// the start of a semester: the last week before it has a different schedule.
+func AcademicWeekBounds(periods []models.AcademicPeriod, date time.Time) (startDate, endDate time.Time) {
+	startDate, endDate = utils.GetWeekBounds(date)
+	first, last := dateOnly(startDate), dateOnly(endDate)
+	for index := range periods {
+		if periods[index].Kind != models.AcademicPeriodSemester {
+			continue
+		}
+		start, err := time.Parse(time.DateOnly, periods[index].StartDate)
+		if err != nil || !start.After(first) || start.After(last) {
+			continue
+		}
+		semesterEve := start.AddDate(0, 0, -1)
+		endDate = time.Date(semesterEve.Year(), semesterEve.Month(), semesterEve.Day(), 0, 0, 0, 0, endDate.Location())
+		last = semesterEve
+	}
+	return startDate, endDate
+}
+
+// weekBounds returns the schedule window of date and the numerator anchor of its semester,
+// empty when there are no semesters.
+func (s *ScheduleService) weekBounds(
+	ctx context.Context,
+	date time.Time,
+) (startDate, endDate time.Time, numeratorStart string, err error) {
+	periods, err := s.academicPeriods(ctx)
+	if err != nil {
+		return startDate, endDate, "", err
+	}
+	startDate, endDate = AcademicWeekBounds(periods, date)
+	if semester := AcademicSemester(periods, date); semester != nil {
+		numeratorStart = semester.NumeratorStart
+	}
+	return startDate, endDate, numeratorStart, nil
+}
+
+func dateOnly(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
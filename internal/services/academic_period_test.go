@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func testAcademicPeriods() []models.AcademicPeriod {
+	return []models.AcademicPeriod{
+		{
+			Kind: models.AcademicPeriodSemester, Title: "Осенний семестр",
+			StartDate: "2025-09-01", EndDate: "2025-12-28", NumeratorStart: "2025-09-01", Id: 1,
+		},
+		{
+			Kind: models.AcademicPeriodExamSession, Title: "Зимняя сессия",
+			StartDate: "2026-01-09", EndDate: "2026-01-31", Id: 2,
+		},
+		{
+			Kind: models.AcademicPeriodSemester, Title: "Весенний семестр",
+			StartDate: "2026-02-09", EndDate: "2026-06-30", NumeratorStart: "2026-02-09", Id: 3,
+		},
+		{
+			Kind: models.AcademicPeriodExamSession, Title: "Летняя сессия",
+			StartDate: "2026-06-08", EndDate: "2026-06-30", Id: 4,
+		},
+	}
+}
+
+func TestWeekType(t *testing.T) {
+	periods := testAcademicPeriods()
+
+	for date, expected := range map[string]string{
+		"2025-09-03": "числитель",
+		"2025-09-08": "знаменатель",
+		"2026-01-15": "знаменатель", // сессия продолжает осенний семестр
+		"2026-02-09": "числитель",
+		"2026-02-22": "знаменатель",
+		"2026-03-01": "числитель",
+		"2026-03-02": "знаменатель",
+	} {
+		day, _ := time.Parse(time.DateOnly, date)
+		if actual := services.WeekType(periods, day); actual != expected {
+			t.Errorf("%v: expected %v, got %v", date, expected, actual)
+		}
+	}
+
+	if actual := services.WeekType(nil, time.Now()); actual != "" {
+		t.Errorf("expected no week type without semesters, got %v", actual)
+	}
+}
+
+func TestCurrentAcademicPeriod(t *testing.T) {
+	periods := testAcademicPeriods()
+
+	if period := services.CurrentAcademicPeriod(periods, time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)); period == nil || period.Id != 4 {
+		t.Errorf("expected the exam session, got %+v", period)
+	}
+	if period := services.CurrentAcademicPeriod(periods, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)); period == nil || period.Id != 3 {
+		t.Errorf("expected the semester, got %+v", period)
+	}
+	if period := services.CurrentAcademicPeriod(periods, time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC)); period != nil {
+		t.Errorf("expected no period between semesters, got %+v", period)
+	}
+}
+
+func TestAcademicWeekBounds(t *testing.T) {
+	periods := testAcademicPeriods()
+
+	start, end := services.AcademicWeekBounds(periods, time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("window must stop before the semester: %v - %v", start, end)
+	}
+
+	start, end = services.AcademicWeekBounds(periods, time.Date(2026, 2, 11, 0, 0, 0, 0, time.UTC))
+	if !start.Equal(time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2026, 2, 22, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected window %v - %v", start, end)
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+)
+
+var apiClientNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,62}$`)
+
+// CreateAPIClient issues a client, the returned client holds the API key, it is not shown again.
+func (s *ScheduleService) CreateAPIClient(ctx context.Context, client *models.APIClient) (*models.APIClient, error) {
+	if !apiClientNameRe.MatchString(client.Name) {
+		return nil, BadRequestError{"name must be 2-63 lowercase latin letters, digits, dots, dashes or underscores"}
+	}
+	if err := validateAPIClientScopes(client); err != nil {
+		return nil, err
+	}
+	key, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	client.Active = true
+	if err := s.Repo.CreateAPIClient(ctx, client, secretTokenHash(key)); err != nil {
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return nil, ConflictError{fmt.Sprintf("client %v already exists", client.Name)}
+		}
+		return nil, err
+	}
+	client.Key = key
+	return client, nil
+}
+
+func validateAPIClientScopes(client *models.APIClient) error {
+	if len(client.Scopes) == 0 {
+		return BadRequestError{"at least one scope is required"}
+	}
+	for _, scope := range client.Scopes {
+		if !knownAPIClientScope(scope) {
+			return BadRequestError{fmt.Sprintf("unknown scope %v, expected read, admin or webhooks", scope)}
+		}
+	}
+	slices.Sort(client.Scopes)
+	client.Scopes = slices.Compact(client.Scopes)
+	return nil
+}
+
+func knownAPIClientScope(scope models.APIClientScope) bool {
+	switch scope {
+	case models.APIClientScopeRead, models.APIClientScopeAdmin, models.APIClientScopeWebhooks:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *ScheduleService) GetAPIClients(ctx context.Context) ([]models.APIClient, error) {
+	return s.Repo.GetAPIClients(ctx)
+}
+
+func (s *ScheduleService) GetAPIClient(ctx context.Context, id int) (*models.APIClient, error) {
+	client, err := s.Repo.GetAPIClient(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("client %v not found", id)}
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+// UpdateAPIClient changes the scopes and disables or enables the client, the name and the key stay.
+func (s *ScheduleService) UpdateAPIClient(ctx context.Context, client *models.APIClient) (*models.APIClient, error) {
+	if err := validateAPIClientScopes(client); err != nil {
+		return nil, err
+	}
+	if err := s.Repo.UpdateAPIClient(ctx, client); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("client %v not found", client.Id)}
+		}
+		return nil, err
+	}
+	return client, nil
+}
+
+// RotateAPIClientKey issues a new key, the old one stops working immediately.
+func (s *ScheduleService) RotateAPIClientKey(ctx context.Context, id int) (*models.APIClient, error) {
+	key, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Repo.RotateAPIClientKey(ctx, id, secretTokenHash(key)); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("client %v not found", id)}
+		}
+		return nil, err
+	}
+	client, err := s.GetAPIClient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	client.Key = key
+	return client, nil
+}
+
+func (s *ScheduleService) DeleteAPIClient(ctx context.Context, id int) error {
+	if err := s.Repo.DeleteAPIClient(ctx, id); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return NotFoundError{fmt.Sprintf("client %v not found", id)}
+		}
+		return err
+	}
+	return nil
+}
+
+// GetAPIClientByName returns the active client a JWT was issued to.
+func (s *ScheduleService) GetAPIClientByName(ctx context.Context, name string) (*models.APIClient, error) {
+	if name == "" {
+		return nil, NotFoundError{"client not found"}
+	}
+	return s.getActiveAPIClient(ctx, name, "")
+}
+
+// GetAPIClientByKey returns the active client with the API key.
+func (s *ScheduleService) GetAPIClientByKey(ctx context.Context, key string) (*models.APIClient, error) {
+	return s.getActiveAPIClient(ctx, "", secretTokenHash(key))
+}
+
+func (s *ScheduleService) getActiveAPIClient(ctx context.Context, name, keyHash string) (*models.APIClient, error) {
+	client, err := s.Repo.GetActiveAPIClient(ctx, name, keyHash)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{"client not found"}
+		}
+		return nil, err
+	}
+	return client, nil
+}
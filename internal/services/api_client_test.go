@@ -0,0 +1,31 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestCreateAPIClientValidation(t *testing.T) {
+	s := services.NewScheduleService(nil)
+	for _, client := range []models.APIClient{
+		{Name: "Telegram Bot", Scopes: []models.APIClientScope{models.APIClientScopeRead}},
+		{Name: "x", Scopes: []models.APIClientScope{models.APIClientScopeRead}},
+		{Name: "telegram-bot"},
+		{Name: "telegram-bot", Scopes: []models.APIClientScope{"root"}},
+	} {
+		if _, err := s.CreateAPIClient(context.Background(), &client); !errors.As(err, &services.BadRequestError{}) {
+			t.Errorf("%+v: expected bad request, got %v", client, err)
+		}
+	}
+}
+
+func TestAPIClientHasScope(t *testing.T) {
+	client := &models.APIClient{Scopes: []models.APIClientScope{models.APIClientScopeRead, models.APIClientScopeWebhooks}}
+	if !client.HasScope(models.APIClientScopeWebhooks) || client.HasScope(models.APIClientScopeAdmin) {
+		t.Errorf("unexpected scopes check for %v", client.Scopes)
+	}
+}
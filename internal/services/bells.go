@@ -0,0 +1,138 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+// bellSchedule returns the bell schedule, lessons_times values are its pairs.
+func bellSchedule() []models.Bell {
+	return []models.Bell{
+		{Number: 1, Time: "08.10-09.45", Start: "08:10", End: "09:45", BreakStart: "08:55", BreakEnd: "09:00"},
+		{Number: 2, Time: "09.55-11.30", Start: "09:55", End: "11:30", BreakStart: "10:40", BreakEnd: "10:45"},
+		{Number: 3, Time: "11.40-13.15", Start: "11:40", End: "13:15", BreakStart: "12:25", BreakEnd: "12:30"},
+		{Number: 4, Time: "13.35-15.10", Start: "13:35", End: "15:10", BreakStart: "14:20", BreakEnd: "14:25"},
+		{Number: 5, Time: "15.20-16.55", Start: "15:20", End: "16:55", BreakStart: "16:05", BreakEnd: "16:10"},
+		{Number: 6, Time: "17.05-18.40", Start: "17:05", End: "18:40", BreakStart: "17:50", BreakEnd: "17:55"},
+		{Number: 7, Time: "18.50-20.15", Start: "18:50", End: "20:15", BreakStart: "19:35", BreakEnd: "19:40"},
+		{Number: 8, Time: "20.25-21.50", Start: "20:25", End: "21:50", BreakStart: "21:10", BreakEnd: "21:15"},
+	}
+}
+
+// GetBells returns the pairs of the bell schedule and the breaks between them.
+func (s *ScheduleService) GetBells() *models.BellSchedule {
+	bells := bellSchedule()
+	schedule := &models.BellSchedule{
+		Bells:  bells,
+		Breaks: make([]models.BellBreak, 0, len(bells)-1),
+	}
+	for index := 1; index < len(bells); index++ {
+		previous, next := &bells[index-1], &bells[index]
+		end, _ := time.Parse(clockLayout, previous.End)
+		start, _ := time.Parse(clockLayout, next.Start)
+		schedule.Breaks = append(schedule.Breaks, models.BellBreak{
+			After:   previous.Number,
+			Start:   previous.End,
+			End:     next.Start,
+			Minutes: int(start.Sub(end).Minutes()),
+		})
+	}
+	return schedule
+}
+
+// PairNumber returns the number of the pair the lesson starts at, 0 for lessons off the bell schedule.
+// A lab taking two pairs gets the number of the first one.
+func PairNumber(lessonTime string) int {
+	return pairNumberAt(lessonStart(lessonTime))
+}
+
+// pairNumberAt returns the number of the pair starting at the HH:MM clock, 0 off the bell schedule.
+func pairNumberAt(start string) int {
+	bells := bellSchedule()
+	for index := range bells {
+		if bells[index].Start == start {
+			return bells[index].Number
+		}
+	}
+	return 0
+}
+
+// lessonStart returns the start of a lessons_times value like 08.10-09.45 as HH:MM, empty if it is malformed.
+func lessonStart(lessonTime string) string {
+	start, _, _ := strings.Cut(lessonTime, "-")
+	clock, err := time.Parse("15.04", strings.TrimSpace(start))
+	if err != nil {
+		return ""
+	}
+	return clock.Format(clockLayout)
+}
+
+// pairBreak returns the break in the middle of the pair, empty for lessons off the bell schedule.
+func pairBreak(start, end time.Time) (breakStart, breakEnd string) {
+	bells := bellSchedule()
+	for index := range bells {
+		if bells[index].Start == start.Format(clockLayout) && bells[index].End == end.Format(clockLayout) {
+			return bells[index].BreakStart, bells[index].BreakEnd
+		}
+	}
+	return "", ""
+}
+
+// NumberStudentPairs sets the pair numbers of the bell schedule on the lessons.
+func NumberStudentPairs(schedule *models.StudentSchedule) {
+	for _, week := range []*models.StudentWeek{&schedule.Schedule.Numerator, &schedule.Schedule.Denominator} {
+		for _, lessons := range weekDays((*models.Week[models.StudentLesson])(week)) {
+			for index := range lessons {
+				lessons[index].PairNumber = PairNumber(lessons[index].Time)
+			}
+		}
+	}
+}
+
+// NumberTeacherPairs sets the pair numbers of the bell schedule on the lessons.
+func NumberTeacherPairs(schedule *models.TeacherSchedule) {
+	for _, week := range []*models.TeacherWeek{&schedule.Schedule.Numerator, &schedule.Schedule.Denominator} {
+		for _, lessons := range weekDays((*models.Week[models.TeacherLesson])(week)) {
+			for index := range lessons {
+				lessons[index].PairNumber = PairNumber(lessons[index].Time)
+			}
+		}
+	}
+}
+
+// NumberAuditoriumPairs sets the pair numbers of the bell schedule on the lessons.
+func NumberAuditoriumPairs(schedule *models.AuditoriumSchedule) {
+	for _, week := range []*models.AuditoriumWeek{&schedule.Schedule.Numerator, &schedule.Schedule.Denominator} {
+		for _, lessons := range weekDays((*models.Week[models.AuditoriumLesson])(week)) {
+			for index := range lessons {
+				lessons[index].PairNumber = PairNumber(lessons[index].Time)
+			}
+		}
+	}
+}
+
+func numberLessons(lessons []models.Lesson) {
+	for index := range lessons {
+		lessons[index].PairNumber = PairNumber(lessons[index].Time)
+	}
+}
+
+func numberLessonSlots(slots []models.LessonSlot) {
+	for index := range slots {
+		slots[index].PairNumber = PairNumber(slots[index].Time)
+	}
+}
+
+// numberLessonVersion sets the pair number of a version from a lesson change, nil for added or removed lessons.
+func numberLessonVersion(version *models.LessonVersion) {
+	if version == nil {
+		return
+	}
+	start, err := time.Parse(lessonStartTimeFormat, version.StartTime)
+	if err != nil {
+		return
+	}
+	version.PairNumber = pairNumberAt(start.Format(clockLayout))
+}
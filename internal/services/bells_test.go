@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestPairNumber(t *testing.T) {
+	tests := map[string]int{
+		"08.10-09.45": 1,
+		"11.40-13.15": 3,
+		"8.10-9.45":   1,
+		"11.40-15.10": 3,
+		"20.25-21.50": 8,
+		"12.00-13.00": 0,
+		"":            0,
+	}
+	for lessonTime, expected := range tests {
+		if number := services.PairNumber(lessonTime); number != expected {
+			t.Errorf("%q: got pair %v, expected %v", lessonTime, number, expected)
+		}
+	}
+}
+
+func TestGetBells(t *testing.T) {
+	schedule := services.NewScheduleService(nil).GetBells()
+	if len(schedule.Bells) != 8 || len(schedule.Breaks) != len(schedule.Bells)-1 {
+		t.Fatalf("unexpected bell schedule %+v", schedule)
+	}
+	for index, bell := range schedule.Bells {
+		if bell.Number != index+1 || services.PairNumber(bell.Time) != bell.Number {
+			t.Errorf("unexpected bell %+v", bell)
+		}
+	}
+	if lunch := schedule.Breaks[2]; lunch.After != 3 || lunch.Start != "13:15" || lunch.End != "13:35" || lunch.Minutes != 20 {
+		t.Errorf("unexpected break after the third pair %+v", lunch)
+	}
+}
+
+func TestAddEmptyLessonsOrder(t *testing.T) {
+	var schedule models.NumeratorDenominator[models.StudentWeek]
+	schedule.Numerator.Monday = []models.StudentLesson{{Time: "11.40-13.15", Lesson: "Физика"}}
+
+	services.NewScheduleService(nil).AddEmptyLessons(&schedule, []string{"11.40-13.15", "8.10-9.45", "09.55-11.30"})
+
+	monday := schedule.Numerator.Monday
+	if len(monday) != 3 || monday[0].Time != "8.10-9.45" || monday[2].Lesson != "Физика" {
+		t.Errorf("expected the empty pairs before the lesson by start time, got %+v", monday)
+	}
+}
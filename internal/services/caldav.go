@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+// GetCalendar returns the events of a group, teacher or auditorium calendar,
+// id is the group number or the teacher or auditorium id.
+func (s *ScheduleService) GetCalendar(ctx context.Context, owner models.CalendarOwner, id string) (*models.Calendar, error) {
+	switch owner {
+	case models.CalendarOwnerGroup:
+		return s.groupCalendar(ctx, id)
+	case models.CalendarOwnerTeacher:
+		teacherID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, NotFoundError{fmt.Sprintf("calendar for teacher '%v' not found", id)}
+		}
+		return s.teacherCalendar(ctx, teacherID)
+	case models.CalendarOwnerAuditorium:
+		auditoriumID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, NotFoundError{fmt.Sprintf("calendar for auditorium '%v' not found", id)}
+		}
+		return s.auditoriumCalendar(ctx, auditoriumID)
+	}
+	return nil, NotFoundError{fmt.Sprintf("calendar owner '%v' not found", owner)}
+}
+
+// CalendarObjectsInRange keeps objects overlapping [start, end), a zero bound is unlimited.
+func CalendarObjectsInRange(objects []CalendarObject, start, end time.Time) []CalendarObject {
+	filtered := make([]CalendarObject, 0, len(objects))
+	for index := range objects {
+		object := &objects[index]
+		if !end.IsZero() && !object.Start.Before(end) {
+			continue
+		}
+		if !start.IsZero() && !object.End.After(start) {
+			continue
+		}
+		filtered = append(filtered, *object)
+	}
+	return filtered
+}
+
+// GetCalendarChanges returns uids of the calendar events changed after the revision, the calendar sync token.
+func (s *ScheduleService) GetCalendarChanges(
+	ctx context.Context,
+	owner models.CalendarOwner,
+	id string,
+	since int64,
+) (*models.CalendarChanges, error) {
+	if owner != models.CalendarOwnerGroup {
+		// uid календаря преподавателя и аудитории строится из id, "007" и "7" — один календарь.
+		ownerID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, NotFoundError{fmt.Sprintf("calendar for %v '%v' not found", owner, id)}
+		}
+		id = strconv.Itoa(ownerID)
+	}
+	return s.Repo.GetCalendarChanges(ctx, owner, id, since)
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestCalendarObjectsInRange(t *testing.T) {
+	calendar := &models.Calendar{
+		Owner: models.CalendarOwnerGroup,
+		Name:  "344",
+		Events: []models.CalendarEvent{
+			{
+				UID:       "morning@rsreu-schedule.ru",
+				StartTime: time.Date(2026, 9, 1, 8, 10, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 9, 1, 9, 45, 0, 0, time.UTC),
+			},
+			{
+				UID:       "evening@rsreu-schedule.ru",
+				StartTime: time.Date(2026, 9, 1, 18, 50, 0, 0, time.UTC),
+				EndTime:   time.Date(2026, 9, 1, 20, 15, 0, 0, time.UTC),
+			},
+		},
+		Holidays: []models.Holiday{{Date: "2026-09-02", Title: "День знаний", Kind: models.HolidayKindHoliday}},
+	}
+	objects := services.GenerateCalendarObjects(calendar, services.DefaultCalendarOptions())
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		expected   []string
+	}{
+		{
+			"unlimited", time.Time{}, time.Time{},
+			[]string{"morning@rsreu-schedule.ru", "evening@rsreu-schedule.ru", "holiday-20260902@rsreu-schedule.ru"},
+		},
+		// 08:10 по Москве — это 05:10 UTC.
+		{"ends at start", time.Time{}, time.Date(2026, 9, 1, 5, 10, 0, 0, time.UTC), nil},
+		{"overlaps start", time.Time{}, time.Date(2026, 9, 1, 5, 11, 0, 0, time.UTC), []string{"morning@rsreu-schedule.ru"}},
+		{"after morning", time.Date(2026, 9, 1, 6, 45, 0, 0, time.UTC), time.Date(2026, 9, 1, 21, 0, 0, 0, time.UTC),
+			[]string{"evening@rsreu-schedule.ru"}},
+		// Праздник начинается в полночь по Москве, 21:00 UTC накануне.
+		{"holiday", time.Date(2026, 9, 1, 21, 0, 0, 0, time.UTC), time.Time{}, []string{"holiday-20260902@rsreu-schedule.ru"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var uids []string
+			for _, object := range services.CalendarObjectsInRange(objects, test.start, test.end) {
+				uids = append(uids, object.UID)
+			}
+			if strings.Join(uids, ",") != strings.Join(test.expected, ",") {
+				t.Fatalf("expected %v, got %v", test.expected, uids)
+			}
+		})
+	}
+}
+
+func TestGenerateCalendarObjects(t *testing.T) {
+	calendar := &models.Calendar{
+		Owner:     models.CalendarOwnerGroup,
+		Name:      "344",
+		UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
+		Events: []models.CalendarEvent{
+			{
+				UID:        "first@rsreu-schedule.ru",
+				StartTime:  time.Date(2026, 9, 1, 8, 10, 0, 0, time.UTC),
+				EndTime:    time.Date(2026, 9, 1, 9, 45, 0, 0, time.UTC),
+				Title:      "Физика",
+				LessonType: "lecture",
+			},
+			{
+				UID:        "second@rsreu-schedule.ru",
+				StartTime:  time.Date(2026, 9, 2, 8, 10, 0, 0, time.UTC),
+				EndTime:    time.Date(2026, 9, 2, 9, 45, 0, 0, time.UTC),
+				Title:      "Химия",
+				LessonType: "lecture",
+			},
+		},
+		Holidays: []models.Holiday{{Date: "2026-11-04", Title: "День народного единства", Kind: models.HolidayKindHoliday}},
+	}
+
+	objects := services.GenerateCalendarObjects(calendar, services.DefaultCalendarOptions())
+	if len(objects) != 3 {
+		t.Fatalf("expected two events and a holiday, got %v objects", len(objects))
+	}
+
+	for _, object := range objects {
+		result := string(object.Data)
+		if strings.Count(result, "BEGIN:VEVENT") != 1 || !strings.Contains(result, "UID:"+object.UID+"\r\n") {
+			t.Errorf("expected only %v:\n%s", object.UID, result)
+		}
+		for _, unexpected := range []string{"METHOD:", "X-WR-CALNAME:", "REFRESH-INTERVAL"} {
+			if strings.Contains(result, unexpected) {
+				t.Errorf("calendar object resource contains %q:\n%s", unexpected, result)
+			}
+		}
+	}
+
+	if !strings.Contains(string(objects[1].Data), "BEGIN:VTIMEZONE\r\n") {
+		t.Errorf("event has no VTIMEZONE:\n%s", objects[1].Data)
+	}
+	holiday := objects[2]
+	if holiday.UID != "holiday-20261104@rsreu-schedule.ru" || strings.Contains(string(holiday.Data), "VTIMEZONE") {
+		t.Errorf("expected an all-day holiday without VTIMEZONE, got %v:\n%s", holiday.UID, holiday.Data)
+	}
+	if !holiday.End.Equal(holiday.Start.AddDate(0, 0, 1)) {
+		t.Errorf("expected the holiday to last a day, got %v - %v", holiday.Start, holiday.End)
+	}
+}
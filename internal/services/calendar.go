@@ -15,11 +15,64 @@ import (
 )
 
 const (
-	calendarGeo = "54.6132708;39.7236472"
-	calendarURL = "https://www.google.com/maps?q=54.6132708,39.7236472"
+	campusLatitude  = 54.6132708
+	campusLongitude = 39.7236472
 )
 
-func (s *ScheduleService) GetGroupCalendar(ctx context.Context, group, source string) ([]byte, error) {
+type CalendarLang string
+
+const (
+	CalendarLangRu CalendarLang = "ru"
+	CalendarLangEn CalendarLang = "en"
+)
+
+// CalendarOptions controls the presentation of a generated calendar.
+type CalendarOptions struct {
+	// Alarm is the reminder offset before the lesson, zero disables reminders.
+	Alarm time.Duration
+	Emoji bool
+	Lang  CalendarLang
+	Color string
+}
+
+func DefaultCalendarOptions() CalendarOptions {
+	const defaultAlarm = 30 * time.Minute
+	return CalendarOptions{
+		Alarm: defaultAlarm,
+		Emoji: true,
+		Lang:  CalendarLangRu,
+		Color: "#5288c1",
+	}
+}
+
+func (s *ScheduleService) GetGroupCalendar(ctx context.Context, group, source string, options CalendarOptions) ([]byte, error) {
+	calendar, err := s.groupCalendar(ctx, group)
+	if err != nil {
+		return nil, err
+	}
+	calendar.Source = source
+	return GenerateCalendar(calendar, options), nil
+}
+
+func (s *ScheduleService) GetTeacherCalendar(ctx context.Context, teacherID int, source string, options CalendarOptions) ([]byte, error) {
+	calendar, err := s.teacherCalendar(ctx, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	calendar.Source = source
+	return GenerateCalendar(calendar, options), nil
+}
+
+func (s *ScheduleService) GetAuditoriumCalendar(ctx context.Context, auditoriumID int, source string, options CalendarOptions) ([]byte, error) {
+	calendar, err := s.auditoriumCalendar(ctx, auditoriumID)
+	if err != nil {
+		return nil, err
+	}
+	calendar.Source = source
+	return GenerateCalendar(calendar, options), nil
+}
+
+func (s *ScheduleService) groupCalendar(ctx context.Context, group string) (*models.Calendar, error) {
 	group = strings.ToUpper(strings.TrimSpace(group))
 	calendar, err := s.Repo.GetGroupCalendar(ctx, group)
 	if err != nil {
@@ -28,66 +81,347 @@ func (s *ScheduleService) GetGroupCalendar(ctx context.Context, group, source st
 		}
 		return nil, err
 	}
-	calendar.Source = source
-	return GenerateCalendar(calendar), nil
+	if err := s.calendarHolidays(ctx, calendar); err != nil {
+		return nil, err
+	}
+	return calendar, nil
 }
 
-func GenerateCalendar(calendar *models.GroupCalendar) []byte {
+func (s *ScheduleService) teacherCalendar(ctx context.Context, teacherID int) (*models.Calendar, error) {
+	calendar, err := s.Repo.GetTeacherCalendar(ctx, teacherID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("calendar for teacher '%v' not found", teacherID)}
+		}
+		return nil, err
+	}
+	if err := s.calendarHolidays(ctx, calendar); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func (s *ScheduleService) auditoriumCalendar(ctx context.Context, auditoriumID int) (*models.Calendar, error) {
+	calendar, err := s.Repo.GetAuditoriumCalendar(ctx, auditoriumID)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("calendar for auditorium '%v' not found", auditoriumID)}
+		}
+		return nil, err
+	}
+	if err := s.calendarHolidays(ctx, calendar); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+func GenerateCalendar(calendar *models.Calendar, options CalendarOptions) []byte {
+	texts := calendarTextsFor(options.Lang)
+
 	var result strings.Builder
-	writeCalendarLine(&result, "BEGIN:VCALENDAR")
-	writeCalendarLine(&result, "VERSION:2.0")
-	writeCalendarLine(&result, "PRODID:-//schedule-rsreu//Schedule API//RU")
-	writeCalendarLine(&result, "CALSCALE:GREGORIAN")
+	writeCalendarHeader(&result)
 	writeCalendarLine(&result, "METHOD:PUBLISH")
-	calendarName := "Расписание группы " + calendar.Group
+	calendarName := calendarName(calendar, texts)
 	writeProperty(&result, "NAME", calendarName)
 	writeProperty(&result, "X-WR-CALNAME", calendarName)
-	writeCalendarLine(&result, "X-WR-TIMEZONE:Europe/Moscow")
+	writeCalendarLine(&result, "X-WR-TIMEZONE:"+calendarTimezone)
 	if calendar.Source != "" {
 		writeCalendarLine(&result, "SOURCE;VALUE=URI:"+calendar.Source)
 	}
 	writeCalendarLine(&result, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
-	writeCalendarLine(&result, "COLOR:#5288c1")
+	if options.Color != "" {
+		writeCalendarLine(&result, "COLOR:"+options.Color)
+	}
+	writeCalendarEvents(&result, calendar, calendar.Events, options, texts)
+	for index := range calendar.Holidays {
+		writeHolidayEvent(&result, calendar, &calendar.Holidays[index], options, texts)
+	}
+	writeCalendarLine(&result, "END:VCALENDAR")
+	return []byte(result.String())
+}
+
+// CalendarObject is an event or a holiday of a calendar rendered as a CalDAV calendar object resource.
+// Start and End are the instants it covers.
+type CalendarObject struct {
+	UID   string
+	Start time.Time
+	End   time.Time
+	Data  []byte
+}
+
+// GenerateCalendarObjects renders every event and holiday as a separate calendar object resource,
+// which must not carry METHOD and calendar-level properties. VTIMEZONE is rendered once for the whole
+// calendar and shared by the events, all-day holidays do not need it.
+func GenerateCalendarObjects(calendar *models.Calendar, options CalendarOptions) []CalendarObject {
+	texts := calendarTextsFor(options.Lang)
+	location := calendarLocation()
+	from, to := calendarWindow(calendar.Events, calendar.UpdatedAt, location)
+	var timezone strings.Builder
+	writeTimezone(&timezone, calendarTimezone, location, from, to)
 
 	dtstamp := calendar.UpdatedAt.UTC().Format("20060102T150405Z")
+	objects := make([]CalendarObject, 0, len(calendar.Events)+len(calendar.Holidays))
 	for index := range calendar.Events {
 		event := &calendar.Events[index]
-		emoji, lessonTypeName, lessonTypeShortName := lessonPresentation(event.LessonType)
-		auditoriums := eventAuditoriums(event)
-		summary := emoji + " " + lessonTypeShortName + " " + event.Title
-		if len(auditoriums) > 0 {
-			summary += " " + strings.Join(auditoriums, ", ")
-		}
-		writeCalendarLine(&result, "BEGIN:VEVENT")
-		writeProperty(&result, "UID", event.UID)
-		writeCalendarLine(&result, "DTSTAMP:"+dtstamp)
-		writeCalendarLine(&result, "DTSTART:"+moscowWallTimeUTC(event.StartTime))
-		writeCalendarLine(&result, "DTEND:"+moscowWallTimeUTC(event.EndTime))
-		writeProperty(&result, "SUMMARY", summary)
-		writeCalendarLine(&result, "CATEGORIES:EDUCATION,"+lessonCategory(event.LessonType))
-		writeProperty(&result, "DESCRIPTION", eventDescription(event, lessonTypeName))
-		writeProperty(&result, "LOCATION", eventLocation(auditoriums))
-		writeCalendarLine(&result, "GEO:"+calendarGeo)
-		writeCalendarLine(&result, "URL:"+calendarURL)
-		writeCalendarLine(&result, "SEQUENCE:"+strconv.FormatInt(event.Sequence+1, 10))
-		if event.Cancelled {
-			writeCalendarLine(&result, "STATUS:CANCELLED")
-		} else {
-			writeCalendarLine(&result, "STATUS:CONFIRMED")
+		var result strings.Builder
+		writeCalendarHeader(&result)
+		result.WriteString(timezone.String())
+		writeCalendarEvent(&result, calendar, event, dtstamp, options, texts)
+		writeCalendarLine(&result, "END:VCALENDAR")
+		objects = append(objects, CalendarObject{
+			UID:   event.UID,
+			Start: calendarEventInstant(event.StartTime, location),
+			End:   calendarEventInstant(event.EndTime, location),
+			Data:  []byte(result.String()),
+		})
+	}
+	for index := range calendar.Holidays {
+		holiday := &calendar.Holidays[index]
+		date, err := time.ParseInLocation(time.DateOnly, holiday.Date, location)
+		if err != nil {
+			continue
 		}
-		writeCalendarLine(&result, "TRANSP:OPAQUE")
-		if !event.Cancelled {
-			writeCalendarLine(&result, "BEGIN:VALARM")
-			writeCalendarLine(&result, "TRIGGER:-PT30M")
-			writeCalendarLine(&result, "ACTION:DISPLAY")
-			writeProperty(&result, "DESCRIPTION", "Пара через 30 минут: "+event.Title)
-			writeCalendarLine(&result, "END:VALARM")
+		var result strings.Builder
+		writeCalendarHeader(&result)
+		writeHolidayEvent(&result, calendar, holiday, options, texts)
+		writeCalendarLine(&result, "END:VCALENDAR")
+		objects = append(objects, CalendarObject{
+			UID:   holidayUID(date),
+			Start: date,
+			End:   date.AddDate(0, 0, 1),
+			Data:  []byte(result.String()),
+		})
+	}
+	return objects
+}
+
+// CalendarDisplayName returns the calendar title shown by clients.
+func CalendarDisplayName(calendar *models.Calendar, lang CalendarLang) string {
+	return calendarName(calendar, calendarTextsFor(lang))
+}
+
+func writeCalendarEvents(
+	result *strings.Builder,
+	calendar *models.Calendar,
+	events []models.CalendarEvent,
+	options CalendarOptions,
+	texts *calendarTexts,
+) {
+	location := calendarLocation()
+	from, to := calendarWindow(events, calendar.UpdatedAt, location)
+	writeTimezone(result, calendarTimezone, location, from, to)
+
+	dtstamp := calendar.UpdatedAt.UTC().Format("20060102T150405Z")
+	for index := range events {
+		writeCalendarEvent(result, calendar, &events[index], dtstamp, options, texts)
+	}
+}
+
+func writeCalendarEvent(
+	result *strings.Builder,
+	calendar *models.Calendar,
+	event *models.CalendarEvent,
+	dtstamp string,
+	options CalendarOptions,
+	texts *calendarTexts,
+) {
+	presentation := lessonPresentation(event.LessonType)
+	lessonTypeName, lessonTypeShortName := presentation.name, presentation.shortName
+	if options.Lang == CalendarLangEn {
+		lessonTypeName, lessonTypeShortName = presentation.nameEn, presentation.shortNameEn
+	}
+	auditoriums := eventAuditoriums(event)
+	summary := lessonTypeShortName + " " + event.Title
+	if options.Emoji {
+		summary = presentation.emoji + " " + summary
+	}
+	if details := eventSummaryDetails(calendar.Owner, event, auditoriums); details != "" {
+		summary += " " + details
+	}
+	location := auditoriums
+	if calendar.Owner == models.CalendarOwnerAuditorium {
+		location = []string{calendar.Name}
+	}
+	latitude, longitude := eventCoordinates(event)
+
+	writeCalendarLine(result, "BEGIN:VEVENT")
+	writeProperty(result, "UID", event.UID)
+	writeCalendarLine(result, "DTSTAMP:"+dtstamp)
+	writeCalendarLine(result, "DTSTART;TZID="+calendarTimezone+":"+calendarLocalTime(event.StartTime))
+	writeCalendarLine(result, "DTEND;TZID="+calendarTimezone+":"+calendarLocalTime(event.EndTime))
+	writeProperty(result, "SUMMARY", summary)
+	writeCalendarLine(result, "CATEGORIES:EDUCATION,"+lessonCategory(event.LessonType))
+	writeProperty(result, "DESCRIPTION", eventDescription(calendar.Owner, event, lessonTypeName, texts))
+	writeProperty(result, "LOCATION", eventLocation(location, event.Buildings, texts))
+	writeCalendarLine(result, "GEO:"+formatCoordinate(latitude)+";"+formatCoordinate(longitude))
+	writeCalendarLine(result, "URL:https://www.google.com/maps?q="+formatCoordinate(latitude)+","+formatCoordinate(longitude))
+	writeCalendarLine(result, "SEQUENCE:"+strconv.FormatInt(event.Sequence+1, 10))
+	if event.Cancelled {
+		writeCalendarLine(result, "STATUS:CANCELLED")
+	} else {
+		writeCalendarLine(result, "STATUS:CONFIRMED")
+	}
+	writeCalendarLine(result, "TRANSP:OPAQUE")
+	if !event.Cancelled && options.Alarm > 0 {
+		minutes := int(options.Alarm / time.Minute)
+		writeCalendarLine(result, "BEGIN:VALARM")
+		writeCalendarLine(result, "TRIGGER:-PT"+strconv.Itoa(minutes)+"M")
+		writeCalendarLine(result, "ACTION:DISPLAY")
+		writeProperty(result, "DESCRIPTION", texts.alarm(minutes)+": "+event.Title)
+		writeCalendarLine(result, "END:VALARM")
+	}
+	writeCalendarLine(result, "END:VEVENT")
+}
+
+// writeHolidayEvent writes a holiday as a free all-day event.
+func writeHolidayEvent(
+	result *strings.Builder,
+	calendar *models.Calendar,
+	holiday *models.Holiday,
+	options CalendarOptions,
+	texts *calendarTexts,
+) {
+	date, err := time.Parse(time.DateOnly, holiday.Date)
+	if err != nil {
+		return
+	}
+	summary := holiday.Title
+	if options.Emoji {
+		summary = "🎉 " + summary
+	}
+
+	writeCalendarLine(result, "BEGIN:VEVENT")
+	writeCalendarLine(result, "UID:"+holidayUID(date))
+	writeCalendarLine(result, "DTSTAMP:"+calendar.UpdatedAt.UTC().Format("20060102T150405Z"))
+	writeCalendarLine(result, "DTSTART;VALUE=DATE:"+date.Format("20060102"))
+	writeCalendarLine(result, "DTEND;VALUE=DATE:"+date.AddDate(0, 0, 1).Format("20060102"))
+	writeProperty(result, "SUMMARY", summary)
+	writeCalendarLine(result, "CATEGORIES:HOLIDAY")
+	writeProperty(result, "DESCRIPTION", texts.holidayKind(holiday.Kind))
+	writeCalendarLine(result, "STATUS:CONFIRMED")
+	writeCalendarLine(result, "TRANSP:TRANSPARENT")
+	writeCalendarLine(result, "END:VEVENT")
+}
+
+func holidayUID(date time.Time) string {
+	return "holiday-" + date.Format("20060102") + "@rsreu-schedule.ru"
+}
+
+func writeCalendarHeader(result *strings.Builder) {
+	writeCalendarLine(result, "BEGIN:VCALENDAR")
+	writeCalendarLine(result, "VERSION:2.0")
+	writeCalendarLine(result, "PRODID:-//schedule-rsreu//Schedule API//RU")
+	writeCalendarLine(result, "CALSCALE:GREGORIAN")
+}
+
+type calendarTexts struct {
+	groupCalendar      string
+	teacherCalendar    string
+	auditoriumCalendar string
+	groups             string
+	breakPeriod        string
+	university         string
+	alarm              func(minutes int) string
+	holidayKind        func(kind models.HolidayKind) string
+}
+
+func calendarTextsFor(lang CalendarLang) *calendarTexts {
+	if lang == CalendarLangEn {
+		return &calendarTexts{
+			groupCalendar:      "Group schedule",
+			teacherCalendar:    "Teacher schedule",
+			auditoriumCalendar: "Room schedule",
+			groups:             "Groups",
+			breakPeriod:        "Break",
+			university:         "RSREU",
+			alarm: func(minutes int) string {
+				if minutes == 1 {
+					return "Class in 1 minute"
+				}
+				return "Class in " + strconv.Itoa(minutes) + " minutes"
+			},
+			holidayKind: func(kind models.HolidayKind) string {
+				switch kind {
+				case models.HolidayKindNonWorking:
+					return "Day off"
+				case models.HolidayKindClosure:
+					return "The university is closed"
+				case models.HolidayKindHoliday:
+				}
+				return "Public holiday"
+			},
 		}
-		writeCalendarLine(&result, "END:VEVENT")
 	}
+	return &calendarTexts{
+		groupCalendar:      "Расписание группы",
+		teacherCalendar:    "Расписание преподавателя",
+		auditoriumCalendar: "Расписание аудитории",
+		groups:             "Группы",
+		breakPeriod:        "Перерыв",
+		university:         "РГРТУ",
+		alarm: func(minutes int) string {
+			return "Пара через " + strconv.Itoa(minutes) + " " + russianMinutes(minutes)
+		},
+		holidayKind: func(kind models.HolidayKind) string {
+			switch kind {
+			case models.HolidayKindNonWorking:
+				return "Выходной день"
+			case models.HolidayKindClosure:
+				return "Университет закрыт"
+			case models.HolidayKindHoliday:
+			}
+			return "Нерабочий праздничный день"
+		},
+	}
+}
 
-	writeCalendarLine(&result, "END:VCALENDAR")
-	return []byte(result.String())
+func russianMinutes(minutes int) string {
+	const (
+		ten     = 10
+		hundred = 100
+	)
+	switch lastTwo, last := minutes%hundred, minutes%ten; {
+	case lastTwo >= 11 && lastTwo <= 14:
+		return "минут"
+	case last == 1:
+		return "минуту"
+	case last >= 2 && last <= 4:
+		return "минуты"
+	default:
+		return "минут"
+	}
+}
+
+func calendarName(calendar *models.Calendar, texts *calendarTexts) string {
+	switch calendar.Owner {
+	case models.CalendarOwnerTeacher:
+		return texts.teacherCalendar + " " + calendar.Name
+	case models.CalendarOwnerAuditorium:
+		return texts.auditoriumCalendar + " " + calendar.Name
+	case models.CalendarOwnerGroup:
+	}
+	return texts.groupCalendar + " " + calendar.Name
+}
+
+// eventSummaryDetails возвращает то, что отличает занятие в календаре владельца:
+// группе важны аудитории, преподавателю — группы и аудитории, аудитории — группы.
+func eventSummaryDetails(owner models.CalendarOwner, event *models.CalendarEvent, auditoriums []string) string {
+	var parts [][]string
+	switch owner {
+	case models.CalendarOwnerTeacher:
+		parts = [][]string{uniqueSorted(event.Groups), auditoriums}
+	case models.CalendarOwnerAuditorium:
+		parts = [][]string{uniqueSorted(event.Groups)}
+	case models.CalendarOwnerGroup:
+		parts = [][]string{auditoriums}
+	}
+	details := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if len(part) > 0 {
+			details = append(details, strings.Join(part, ", "))
+		}
+	}
+	return strings.Join(details, " · ")
 }
 
 func lessonCategory(lessonType string) string {
@@ -95,17 +429,13 @@ func lessonCategory(lessonType string) string {
 	return strings.ToUpper(category)
 }
 
-func moscowWallTimeUTC(value time.Time) string {
-	const moscowOffset = 3 * time.Hour
-	return time.Date(
-		value.Year(), value.Month(), value.Day(),
-		value.Hour(), value.Minute(), value.Second(), 0,
-		time.UTC,
-	).Add(-moscowOffset).Format("20060102T150405Z")
-}
-
-func eventDescription(event *models.CalendarEvent, lessonTypeName string) string {
+func eventDescription(owner models.CalendarOwner, event *models.CalendarEvent, lessonTypeName string, texts *calendarTexts) string {
 	lines := []string{lessonTypeName, event.Title}
+	if owner != models.CalendarOwnerGroup {
+		if groups := uniqueSorted(event.Groups); len(groups) > 0 {
+			lines = append(lines, texts.groups+": "+strings.Join(groups, ", "))
+		}
+	}
 	pairs := append([]models.CalendarTeacherAuditorium(nil), event.TeacherAuditoriums...)
 	sort.Slice(pairs, func(i, j int) bool {
 		return pairs[i].Teacher+"\x00"+pairs[i].Auditorium < pairs[j].Teacher+"\x00"+pairs[j].Auditorium
@@ -122,7 +452,7 @@ func eventDescription(event *models.CalendarEvent, lessonTypeName string) string
 		}
 	}
 	if breakPeriod := getBreakPeriod(event.StartTime, event.EndTime); breakPeriod != "" {
-		lines = append(lines, "", "Перерыв: "+breakPeriod)
+		lines = append(lines, "", texts.breakPeriod+": "+breakPeriod)
 	}
 	return strings.Join(lines, "\n")
 }
@@ -135,12 +465,31 @@ func eventAuditoriums(event *models.CalendarEvent) []string {
 	return uniqueSorted(auditoriums)
 }
 
-func eventLocation(auditoriums []string) string {
+// eventLocation lists auditoriums and, when the lesson is in a single building with a known address, the address.
+func eventLocation(auditoriums []string, buildings []models.CalendarBuilding, texts *calendarTexts) string {
+	location := texts.university
+	if len(buildings) == 1 && strings.TrimSpace(buildings[0].Address) != "" {
+		location += ", " + strings.TrimSpace(buildings[0].Address)
+	}
 	auditoriums = uniqueSorted(auditoriums)
 	if len(auditoriums) == 0 {
-		return "РГРТУ"
+		return location
+	}
+	return strings.Join(auditoriums, ", ") + " · " + location
+}
+
+// eventCoordinates returns the first building with known coordinates, falling back to the main campus.
+func eventCoordinates(event *models.CalendarEvent) (latitude, longitude float64) {
+	for _, building := range event.Buildings {
+		if building.Latitude != nil && building.Longitude != nil {
+			return *building.Latitude, *building.Longitude
+		}
 	}
-	return strings.Join(auditoriums, ", ") + " · РГРТУ"
+	return campusLatitude, campusLongitude
+}
+
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
 }
 
 func uniqueSorted(values []string) []string {
@@ -159,39 +508,41 @@ func uniqueSorted(values []string) []string {
 	return result
 }
 
-func lessonPresentation(lessonType string) (emoji, name, shortName string) {
-	presentations := [...]struct {
-		lessonType string
-		emoji      string
-		name       string
-		shortName  string
-	}{
-		{"lecture", "📘", "Лекция", "Лек."},
-		{"practice", "✏️", "Практика", "Упр."},
-		{"lab", "🧪", "Лабораторная работа", "Лаб."},
-		{"coursework", "📄", "Курсовая работа", "Курс. раб."},
-		{"course_project", "🛠️", "Курсовой проект", "Курс. пр."},
-		{"exam", "🎓", "Экзамен", "Экз."},
-		{"zachet", "🎓", "Зачёт", "Зач."},
-		{"consultation", "❓", "Консультация", "Конс."},
-		{"elective", "🧭", "Факультатив", "Фак."},
+type lessonTypePresentation struct {
+	lessonType  string
+	emoji       string
+	name        string
+	shortName   string
+	nameEn      string
+	shortNameEn string
+}
+
+func lessonPresentation(lessonType string) lessonTypePresentation {
+	presentations := [...]lessonTypePresentation{
+		{"lecture", "📘", "Лекция", "Лек.", "Lecture", "Lec."},
+		{"practice", "✏️", "Практика", "Упр.", "Practice", "Pr."},
+		{"lab", "🧪", "Лабораторная работа", "Лаб.", "Laboratory work", "Lab"},
+		{"coursework", "📄", "Курсовая работа", "Курс. раб.", "Coursework", "CW"},
+		{"course_project", "🛠️", "Курсовой проект", "Курс. пр.", "Course project", "CP"},
+		{"exam", "🎓", "Экзамен", "Экз.", "Exam", "Exam"},
+		{"zachet", "🎓", "Зачёт", "Зач.", "Pass/fail test", "Test"},
+		{"consultation", "❓", "Консультация", "Конс.", "Consultation", "Cons."},
+		{"elective", "🧭", "Факультатив", "Фак.", "Elective", "El."},
 	}
 	for index := range presentations {
-		presentation := &presentations[index]
-		if presentation.lessonType == lessonType {
-			return presentation.emoji, presentation.name, presentation.shortName
+		if presentations[index].lessonType == lessonType {
+			return presentations[index]
 		}
 	}
-	return "🎓", "Занятие", "Зан."
+	return lessonTypePresentation{lessonType, "🎓", "Занятие", "Зан.", "Class", "Class"}
 }
 
 func getBreakPeriod(start, end time.Time) string {
-	return map[string]string{
-		"08:10-09:45": "08:55–09:00", "09:55-11:30": "10:40–10:45",
-		"11:40-13:15": "12:25–12:30", "13:35-15:10": "14:20–14:25",
-		"15:20-16:55": "16:05–16:10", "17:05-18:40": "17:50–17:55",
-		"18:50-20:15": "19:35–19:40", "20:25-21:50": "21:10–21:15",
-	}[start.Format("15:04")+"-"+end.Format("15:04")]
+	breakStart, breakEnd := pairBreak(start, end)
+	if breakStart == "" {
+		return ""
+	}
+	return breakStart + "–" + breakEnd
 }
 
 func writeProperty(result *strings.Builder, name, value string) {
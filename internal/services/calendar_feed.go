@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+)
+
+const secretTokenBytes = 32
+
+func (s *ScheduleService) CreateCalendarFeed(ctx context.Context, feed *models.CalendarFeed) (*models.CalendarFeed, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed.Group = strings.ToUpper(strings.TrimSpace(feed.Group))
+	if err := s.Repo.CreateCalendarFeed(ctx, secretTokenHash(token), feed); err != nil {
+		if errors.As(err, &repo.NoScheduleGroupError{}) {
+			return nil, NotFoundError{fmt.Sprintf("group %v not found", feed.Group)}
+		}
+		return nil, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+func (s *ScheduleService) GetCalendarFeed(ctx context.Context, token string) (*models.CalendarFeed, error) {
+	feed, err := s.Repo.GetCalendarFeed(ctx, secretTokenHash(token))
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{"calendar feed not found"}
+		}
+		return nil, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+func (s *ScheduleService) UpdateCalendarFeed(ctx context.Context, token string, feed *models.CalendarFeed) (*models.CalendarFeed, error) {
+	feed.Group = strings.ToUpper(strings.TrimSpace(feed.Group))
+	if err := s.Repo.UpdateCalendarFeed(ctx, secretTokenHash(token), feed); err != nil {
+		if errors.As(err, &repo.NoScheduleGroupError{}) {
+			return nil, NotFoundError{fmt.Sprintf("group %v not found", feed.Group)}
+		}
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{"calendar feed not found"}
+		}
+		return nil, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+func (s *ScheduleService) DeleteCalendarFeed(ctx context.Context, token string) error {
+	if err := s.Repo.DeleteCalendarFeed(ctx, secretTokenHash(token)); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return NotFoundError{"calendar feed not found"}
+		}
+		return err
+	}
+	return nil
+}
+
+// GetCalendarFeedCalendar renders the group calendar with only the events matching the feed rules.
+// UIDs are the same as in the group calendar, so changing the rules does not duplicate events.
+func (s *ScheduleService) GetCalendarFeedCalendar(ctx context.Context, feed *models.CalendarFeed, source string, options CalendarOptions,
+) ([]byte, error) {
+	calendar, err := s.groupCalendar(ctx, feed.Group)
+	if err != nil {
+		return nil, err
+	}
+	calendar.Source = source
+	calendar.Events = FilterCalendarEvents(calendar.Events, feed)
+	return GenerateCalendar(calendar, options), nil
+}
+
+// FilterCalendarEvents keeps events matching any include rule (all events if there are none)
+// and no exclude rule.
+func FilterCalendarEvents(events []models.CalendarEvent, feed *models.CalendarFeed) []models.CalendarEvent {
+	filtered := make([]models.CalendarEvent, 0, len(events))
+	for index := range events {
+		event := &events[index]
+		if len(feed.Include) > 0 && !anyRuleMatches(feed.Include, event) {
+			continue
+		}
+		if anyRuleMatches(feed.Exclude, event) {
+			continue
+		}
+		filtered = append(filtered, *event)
+	}
+	return filtered
+}
+
+func anyRuleMatches(rules []models.CalendarFeedRule, event *models.CalendarEvent) bool {
+	for index := range rules {
+		if ruleMatches(&rules[index], event) {
+			return true
+		}
+	}
+	return false
+}
+
+func ruleMatches(rule *models.CalendarFeedRule, event *models.CalendarEvent) bool {
+	if rule.Title != "" && !containsFold(event.Title, rule.Title) {
+		return false
+	}
+	if rule.LessonType != "" && rule.LessonType != event.LessonType {
+		return false
+	}
+	if rule.Teacher != "" && !anyPairMatches(event, rule.Teacher, func(pair models.CalendarTeacherAuditorium) string {
+		return pair.Teacher
+	}) {
+		return false
+	}
+	if rule.Auditorium != "" && !anyPairMatches(event, rule.Auditorium, func(pair models.CalendarTeacherAuditorium) string {
+		return pair.Auditorium
+	}) {
+		return false
+	}
+	return true
+}
+
+func anyPairMatches(event *models.CalendarEvent, value string, field func(models.CalendarTeacherAuditorium) string) bool {
+	for _, pair := range event.TeacherAuditoriums {
+		if containsFold(field(pair), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(value, substring string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(strings.TrimSpace(substring)))
+}
+
+func newSecretToken() (string, error) {
+	token := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generate secret token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+// secretTokenHash is stored instead of the token, so a database dump does not expose feed URLs or API keys.
+func secretTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestFilterCalendarEvents(t *testing.T) {
+	events := []models.CalendarEvent{
+		{
+			UID: "lecture", Title: "Высшая математика", LessonType: "lecture",
+			TeacherAuditoriums: []models.CalendarTeacherAuditorium{{Teacher: "Конюхов Алексей Николаевич", Auditorium: "333 C"}},
+		},
+		{
+			UID: "lab-first", Title: "Информатика", LessonType: "lab",
+			TeacherAuditoriums: []models.CalendarTeacherAuditorium{{Teacher: "Бурмистров Александр Сергеевич", Auditorium: "106а C"}},
+		},
+		{
+			UID: "lab-second", Title: "Информатика", LessonType: "lab",
+			TeacherAuditoriums: []models.CalendarTeacherAuditorium{{Teacher: "Соловьев Александр Вадимович", Auditorium: "110 C"}},
+		},
+		{UID: "elective", Title: "Иностранный язык (факультатив)", LessonType: "elective"},
+	}
+
+	tests := []struct {
+		name     string
+		feed     models.CalendarFeed
+		expected []string
+	}{
+		{"no rules", models.CalendarFeed{}, []string{"lecture", "lab-first", "lab-second", "elective"}},
+		{
+			"exclude other subgroup",
+			models.CalendarFeed{Exclude: []models.CalendarFeedRule{{LessonType: "lab", Teacher: "соловьев"}}},
+			[]string{"lecture", "lab-first", "elective"},
+		},
+		{
+			"exclude elective by title",
+			models.CalendarFeed{Exclude: []models.CalendarFeedRule{{Title: "ФАКУЛЬТАТИВ"}}},
+			[]string{"lecture", "lab-first", "lab-second"},
+		},
+		{
+			"include lectures and one auditorium",
+			models.CalendarFeed{Include: []models.CalendarFeedRule{{LessonType: "lecture"}, {Auditorium: "106а"}}},
+			[]string{"lecture", "lab-first"},
+		},
+		{
+			"exclude wins over include",
+			models.CalendarFeed{
+				Include: []models.CalendarFeedRule{{Title: "Информатика"}},
+				Exclude: []models.CalendarFeedRule{{Auditorium: "110 C"}},
+			},
+			[]string{"lab-first"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered := services.FilterCalendarEvents(events, &test.feed)
+			uids := make([]string, 0, len(filtered))
+			for _, event := range filtered {
+				uids = append(uids, event.UID)
+			}
+			if len(uids) != len(test.expected) {
+				t.Fatalf("expected %v, got %v", test.expected, uids)
+			}
+			for index := range uids {
+				if uids[index] != test.expected[index] {
+					t.Fatalf("expected %v, got %v", test.expected, uids)
+				}
+			}
+		})
+	}
+}
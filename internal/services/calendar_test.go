@@ -1,6 +1,9 @@
 package services_test
 
 import (
+	"flag"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -10,9 +13,12 @@ import (
 	"github.com/schedule-rsreu/schedule-api/internal/services"
 )
 
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
 func TestGenerateCalendar(t *testing.T) {
-	calendar := &models.GroupCalendar{
-		Group:     "344",
+	calendar := &models.Calendar{
+		Owner:     models.CalendarOwnerGroup,
+		Name:      "344",
 		Source:    "https://api.example.com/api/v1/schedule/groups/344/calendar.ics",
 		UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
 		Events: []models.CalendarEvent{
@@ -40,7 +46,7 @@ func TestGenerateCalendar(t *testing.T) {
 		},
 	}
 
-	result := string(services.GenerateCalendar(calendar))
+	result := string(services.GenerateCalendar(calendar, services.DefaultCalendarOptions()))
 	unfolded := strings.ReplaceAll(result, "\r\n ", "")
 
 	for _, expected := range []string{
@@ -49,7 +55,8 @@ func TestGenerateCalendar(t *testing.T) {
 		"REFRESH-INTERVAL;VALUE=DURATION:PT1H\r\n",
 		"COLOR:#5288c1\r\n",
 		"DTSTAMP:20260819T120000Z\r\n",
-		"DTSTART:20260819T103500Z\r\n",
+		"DTSTART;TZID=Europe/Moscow:20260819T133500\r\n",
+		"DTEND;TZID=Europe/Moscow:20260819T151000\r\n",
 		"SUMMARY:🧪 Лаб. Проектирование информационных систем с очень длинным названием для проверки переноса строки 106а C\\, 110 C\r\n",
 		"CATEGORIES:EDUCATION,LAB\r\n",
 		"LOCATION:106а C\\, 110 C · РГРТУ\r\n",
@@ -98,8 +105,9 @@ func TestGenerateCalendarLessonTypes(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.lessonType, func(t *testing.T) {
-			calendar := &models.GroupCalendar{
-				Group:     "344",
+			calendar := &models.Calendar{
+				Owner:     models.CalendarOwnerGroup,
+				Name:      "344",
 				UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
 				Events: []models.CalendarEvent{{
 					UID:        test.lessonType + "@rsreu-schedule.ru",
@@ -110,7 +118,7 @@ func TestGenerateCalendarLessonTypes(t *testing.T) {
 				}},
 			}
 
-			result := strings.ReplaceAll(string(services.GenerateCalendar(calendar)), "\r\n ", "")
+			result := strings.ReplaceAll(string(services.GenerateCalendar(calendar, services.DefaultCalendarOptions())), "\r\n ", "")
 			if !strings.Contains(result, "SUMMARY:"+test.emoji+" "+test.shortName+" Предмет\r\n") ||
 				!strings.Contains(result, "DESCRIPTION:"+test.name+"\\nПредмет\\n\\nПерерыв: 14:20–14:25\r\n") {
 				t.Fatalf("unexpected lesson presentation:\n%s", result)
@@ -118,3 +126,272 @@ func TestGenerateCalendarLessonTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateCalendarOwners(t *testing.T) {
+	event := models.CalendarEvent{
+		UID:        "stream@rsreu-schedule.ru",
+		StartTime:  time.Date(2026, 8, 19, 8, 10, 0, 0, time.UTC),
+		EndTime:    time.Date(2026, 8, 19, 9, 45, 0, 0, time.UTC),
+		Title:      "Высшая математика",
+		LessonType: "lecture",
+		Groups:     []string{"345", "344"},
+		TeacherAuditoriums: []models.CalendarTeacherAuditorium{
+			{Teacher: "Конюхов Алексей Николаевич", Auditorium: "333 C"},
+		},
+	}
+
+	tests := []struct {
+		owner    models.CalendarOwner
+		name     string
+		expected []string
+	}{
+		{
+			owner: models.CalendarOwnerGroup,
+			name:  "344",
+			expected: []string{
+				"NAME:Расписание группы 344\r\n",
+				"SUMMARY:📘 Лек. Высшая математика 333 C\r\n",
+				"DESCRIPTION:Лекция\\nВысшая математика\\nКонюхов Алексей Николаевич — 333 C\\n\\nПерерыв: 08:55–09:00\r\n",
+			},
+		},
+		{
+			owner: models.CalendarOwnerTeacher,
+			name:  "Конюхов Алексей Николаевич",
+			expected: []string{
+				"NAME:Расписание преподавателя Конюхов Алексей Николаевич\r\n",
+				"SUMMARY:📘 Лек. Высшая математика 344\\, 345 · 333 C\r\n",
+				"DESCRIPTION:Лекция\\nВысшая математика\\nГруппы: 344\\, 345\\nКонюхов Алексей Николаевич — 333 C\\n\\nПерерыв: 08:55–09:00\r\n",
+				"LOCATION:333 C · РГРТУ\r\n",
+			},
+		},
+		{
+			owner: models.CalendarOwnerAuditorium,
+			name:  "333 C",
+			expected: []string{
+				"NAME:Расписание аудитории 333 C\r\n",
+				"SUMMARY:📘 Лек. Высшая математика 344\\, 345\r\n",
+				"LOCATION:333 C · РГРТУ\r\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.owner), func(t *testing.T) {
+			calendar := &models.Calendar{
+				Owner:     test.owner,
+				Name:      test.name,
+				UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
+				Events:    []models.CalendarEvent{event},
+			}
+
+			result := strings.ReplaceAll(string(services.GenerateCalendar(calendar, services.DefaultCalendarOptions())), "\r\n ", "")
+			for _, expected := range test.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("calendar does not contain %q:\n%s", expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCalendarOptions(t *testing.T) {
+	latitude, longitude := 54.63, 39.69
+	event := models.CalendarEvent{
+		UID:        "options@rsreu-schedule.ru",
+		StartTime:  time.Date(2026, 8, 19, 8, 10, 0, 0, time.UTC),
+		EndTime:    time.Date(2026, 8, 19, 9, 45, 0, 0, time.UTC),
+		Title:      "Физика",
+		LessonType: "practice",
+		TeacherAuditoriums: []models.CalendarTeacherAuditorium{
+			{Teacher: "Иванов Иван Иванович", Auditorium: "21 Л"},
+		},
+		Buildings: []models.CalendarBuilding{
+			{Letter: "Л", Address: "ул. Гагарина, 59/1", Latitude: &latitude, Longitude: &longitude},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		options    func(options *services.CalendarOptions)
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:    "default",
+			options: func(*services.CalendarOptions) {},
+			expected: []string{
+				"SUMMARY:✏️ Упр. Физика 21 Л\r\n",
+				"LOCATION:21 Л · РГРТУ\\, ул. Гагарина\\, 59/1\r\n",
+				"GEO:54.63;39.69\r\n",
+				"URL:https://www.google.com/maps?q=54.63,39.69\r\n",
+				"DESCRIPTION:Пара через 30 минут: Физика\r\n",
+			},
+		},
+		{
+			name:       "no alarm",
+			options:    func(options *services.CalendarOptions) { options.Alarm = 0 },
+			unexpected: []string{"BEGIN:VALARM"},
+		},
+		{
+			name:     "alarm",
+			options:  func(options *services.CalendarOptions) { options.Alarm = 21 * time.Minute },
+			expected: []string{"TRIGGER:-PT21M\r\n", "DESCRIPTION:Пара через 21 минуту: Физика\r\n"},
+		},
+		{
+			name:       "no emoji",
+			options:    func(options *services.CalendarOptions) { options.Emoji = false },
+			expected:   []string{"SUMMARY:Упр. Физика 21 Л\r\n"},
+			unexpected: []string{"✏️"},
+		},
+		{
+			name: "english",
+			options: func(options *services.CalendarOptions) {
+				options.Lang = services.CalendarLangEn
+				options.Color = "#ff0000"
+			},
+			expected: []string{
+				"NAME:Group schedule 344\r\n",
+				"COLOR:#ff0000\r\n",
+				"SUMMARY:✏️ Pr. Физика 21 Л\r\n",
+				"DESCRIPTION:Practice\\nФизика\\nИванов Иван Иванович — 21 Л\\n\\nBreak: 08:55–09:00\r\n",
+				"LOCATION:21 Л · RSREU\\, ул. Гагарина\\, 59/1\r\n",
+				"DESCRIPTION:Class in 30 minutes: Физика\r\n",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calendar := &models.Calendar{
+				Owner:     models.CalendarOwnerGroup,
+				Name:      "344",
+				UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
+				Events:    []models.CalendarEvent{event},
+			}
+			options := services.DefaultCalendarOptions()
+			test.options(&options)
+
+			result := strings.ReplaceAll(string(services.GenerateCalendar(calendar, options)), "\r\n ", "")
+			for _, expected := range test.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("calendar does not contain %q:\n%s", expected, result)
+				}
+			}
+			for _, unexpected := range test.unexpected {
+				if strings.Contains(result, unexpected) {
+					t.Errorf("calendar contains %q:\n%s", unexpected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCalendarGolden(t *testing.T) {
+	calendar := &models.Calendar{
+		Owner:     models.CalendarOwnerGroup,
+		Name:      "344",
+		Source:    "https://api.example.com/api/v1/schedule/groups/344/calendar.ics",
+		UpdatedAt: time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC),
+		Events: []models.CalendarEvent{
+			{
+				UID:        "escaping@rsreu-schedule.ru",
+				StartTime:  time.Date(2026, 9, 1, 8, 10, 0, 0, time.UTC),
+				EndTime:    time.Date(2026, 9, 1, 9, 45, 0, 0, time.UTC),
+				Title:      "Теория; практика, и C:\\Windows\nпереносы",
+				LessonType: "lecture",
+				TeacherAuditoriums: []models.CalendarTeacherAuditorium{
+					{Teacher: "Конюхов Алексей Николаевич", Auditorium: "333 C"},
+				},
+				Sequence: 1,
+			},
+			{
+				UID:        "folding@rsreu-schedule.ru",
+				StartTime:  time.Date(2026, 9, 2, 13, 35, 0, 0, time.UTC),
+				EndTime:    time.Date(2026, 9, 2, 15, 10, 0, 0, time.UTC),
+				Title:      "Проектирование информационных систем с очень длинным названием для проверки переноса строки",
+				LessonType: "lab",
+				Sequence:   2,
+				Cancelled:  true,
+			},
+		},
+	}
+
+	assertGolden(t, "calendar.ics", services.GenerateCalendar(calendar, services.DefaultCalendarOptions()))
+}
+
+func TestGenerateTimezoneGolden(t *testing.T) {
+	tests := []struct {
+		golden   string
+		tzid     string
+		from, to time.Time
+	}{
+		{
+			golden: "timezone_moscow.ics",
+			tzid:   "Europe/Moscow",
+			from:   time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC),
+			to:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Переход на постоянное время в 2011 и обратно в 2014 году.
+			golden: "timezone_moscow_2011_2014.ics",
+			tzid:   "Europe/Moscow",
+			from:   time.Date(2011, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:     time.Date(2014, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// RFC 5545, 3.6.5: New York с переходами на летнее время.
+			golden: "timezone_new_york.ics",
+			tzid:   "America/New_York",
+			from:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:     time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.golden, func(t *testing.T) {
+			location, err := time.LoadLocation(test.tzid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, test.golden, services.GenerateTimezone(test.tzid, location, test.from, test.to))
+		})
+	}
+}
+
+func TestGenerateTimezoneAfterCachedTransitions(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	services.GenerateTimezone("America/New_York", location,
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	// Окно выходит за найденные ранее переходы: каждый год по переходу на летнее время и обратно.
+	result := string(services.GenerateTimezone("America/New_York", location,
+		time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2040, 12, 31, 0, 0, 0, 0, time.UTC)))
+	daylight, standard := strings.Count(result, "BEGIN:DAYLIGHT"), strings.Count(result, "BEGIN:STANDARD")
+	if daylight != 11 || standard != 12 {
+		t.Errorf("expected 11 daylight and 12 standard observances, got %v and %v:\n%s", daylight, standard, result)
+	}
+	if !strings.Contains(result, "DTSTART:20400311T020000") {
+		t.Errorf("expected the transition of 2040:\n%s", result)
+	}
+}
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		if err := os.WriteFile(path, actual, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(expected) != string(actual) {
+		t.Errorf("%s does not match:\n%s", path, actual)
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const calendarTimezone = "Europe/Moscow"
+
+type timezoneObservance struct {
+	start      time.Time
+	name       string
+	offsetFrom int
+	offsetTo   int
+	daylight   bool
+}
+
+func calendarLocation() *time.Location {
+	location, err := time.LoadLocation(calendarTimezone)
+	if err != nil {
+		const moscowOffset = 3 * 60 * 60
+		return time.FixedZone("MSK", moscowOffset)
+	}
+	return location
+}
+
+// calendarLocalTime formats the wall clock of a lesson, times are stored as Moscow time in UTC.
+func calendarLocalTime(value time.Time) string {
+	return value.Format("20060102T150405")
+}
+
+// calendarWindow returns the instants covered by the events, used to limit VTIMEZONE to the needed transitions.
+func calendarWindow(events []models.CalendarEvent, fallback time.Time, location *time.Location) (from, to time.Time) {
+	if len(events) == 0 {
+		return fallback, fallback
+	}
+	from, to = calendarEventInstant(events[0].StartTime, location), calendarEventInstant(events[0].EndTime, location)
+	for index := range events {
+		if start := calendarEventInstant(events[index].StartTime, location); start.Before(from) {
+			from = start
+		}
+		if end := calendarEventInstant(events[index].EndTime, location); end.After(to) {
+			to = end
+		}
+	}
+	return from, to
+}
+
+// calendarEventInstant converts the stored wall clock of a lesson to an instant in location.
+func calendarEventInstant(value time.Time, location *time.Location) time.Time {
+	return time.Date(
+		value.Year(), value.Month(), value.Day(),
+		value.Hour(), value.Minute(), value.Second(), 0,
+		location,
+	)
+}
+
+// writeTimezone writes VTIMEZONE with the observance in effect at from and every transition until to.
+func writeTimezone(result *strings.Builder, tzid string, location *time.Location, from, to time.Time) {
+	writeCalendarLine(result, "BEGIN:VTIMEZONE")
+	writeProperty(result, "TZID", tzid)
+	for _, observance := range timezoneObservances(location, from, to) {
+		component := "STANDARD"
+		if observance.daylight {
+			component = "DAYLIGHT"
+		}
+		// DTSTART наблюдения указывается по местному времени до перехода.
+		start := observance.start.UTC().Add(time.Duration(observance.offsetFrom) * time.Second)
+		writeCalendarLine(result, "BEGIN:"+component)
+		writeCalendarLine(result, "DTSTART:"+start.Format("20060102T150405"))
+		writeCalendarLine(result, "TZOFFSETFROM:"+formatUTCOffset(observance.offsetFrom))
+		writeCalendarLine(result, "TZOFFSETTO:"+formatUTCOffset(observance.offsetTo))
+		if observance.name != "" {
+			writeProperty(result, "TZNAME", observance.name)
+		}
+		writeCalendarLine(result, "END:"+component)
+	}
+	writeCalendarLine(result, "END:VTIMEZONE")
+}
+
+// timezoneObservances returns the observance in effect at from and the transitions until to.
+func timezoneObservances(location *time.Location, from, to time.Time) []timezoneObservance {
+	name, offset := from.In(location).Zone()
+	observances := []timezoneObservance{{
+		start:      time.Unix(0, 0),
+		name:       name,
+		offsetFrom: offset,
+		offsetTo:   offset,
+		daylight:   from.In(location).IsDST(),
+	}}
+	for _, transition := range locationTransitions(location, to) {
+		switch {
+		case !transition.start.After(from):
+			observances[0] = transition
+		case !transition.start.After(to):
+			observances = append(observances, transition)
+		}
+	}
+	return observances
+}
+
+// locationTransitionsHorizon is how far the transitions are found past the requested instant,
+// so feeds of the following months reuse them.
+const locationTransitionsHorizon = 5
+
+type locationTransitionsCache struct {
+	until       time.Time
+	transitions []timezoneObservance
+}
+
+var (
+	locationTransitionsMu sync.Mutex                                   //nolint:gochecknoglobals // Guards locationTransitionsOf.
+	locationTransitionsOf = make(map[string]*locationTransitionsCache) //nolint:gochecknoglobals,lll // Transitions of a location do not change, every feed shares them.
+)
+
+// locationTransitions returns the transitions of the location since the epoch until at least to.
+// They are found once per location and only extended when a calendar reaches past them.
+func locationTransitions(location *time.Location, to time.Time) []timezoneObservance {
+	locationTransitionsMu.Lock()
+	defer locationTransitionsMu.Unlock()
+
+	cached, ok := locationTransitionsOf[location.String()]
+	if !ok {
+		cached = &locationTransitionsCache{until: time.Unix(0, 0)}
+		locationTransitionsOf[location.String()] = cached
+	}
+	if cached.until.Before(to) {
+		until := to.AddDate(locationTransitionsHorizon, 0, 0)
+		cached.transitions = append(cached.transitions, scanTransitions(location, cached.until, until)...)
+		cached.until = until
+	}
+	return cached.transitions
+}
+
+// scanTransitions finds zone transitions by probing offsets day by day,
+// time.Location does not expose its transitions.
+func scanTransitions(location *time.Location, from, to time.Time) []timezoneObservance {
+	const step = 24 * time.Hour
+	var transitions []timezoneObservance
+	name, offset := from.In(location).Zone()
+	// Шаг не выходит за to: следующий отрезок начинается с него и не находит переход повторно.
+	for at := from; at.Before(to); {
+		next := at.Add(step)
+		if next.After(to) {
+			next = to
+		}
+		if nextName, nextOffset := next.In(location).Zone(); nextName != name || nextOffset != offset {
+			transitions = append(transitions, timezoneTransition(location, at, next))
+			name, offset = nextName, nextOffset
+		}
+		at = next
+	}
+	return transitions
+}
+
+// timezoneTransition narrows the transition between before and after down to a second.
+func timezoneTransition(location *time.Location, before, after time.Time) timezoneObservance {
+	beforeName, beforeOffset := before.In(location).Zone()
+	for after.Sub(before) > time.Second {
+		middle := before.Add(after.Sub(before) / 2)
+		if name, offset := middle.In(location).Zone(); name == beforeName && offset == beforeOffset {
+			before = middle
+		} else {
+			after = middle
+		}
+	}
+	after = after.Truncate(time.Second)
+	name, offset := after.In(location).Zone()
+	return timezoneObservance{
+		start:      after,
+		name:       name,
+		offsetFrom: beforeOffset,
+		offsetTo:   offset,
+		daylight:   after.In(location).IsDST(),
+	}
+}
+
+func formatUTCOffset(offset int) string {
+	const (
+		secondsInMinute = 60
+		secondsInHour   = 60 * secondsInMinute
+	)
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	result := sign + twoDigits(offset/secondsInHour) + twoDigits(offset%secondsInHour/secondsInMinute)
+	if seconds := offset % secondsInMinute; seconds != 0 {
+		result += twoDigits(seconds)
+	}
+	return result
+}
+
+func twoDigits(value int) string {
+	const ten = 10
+	if value < ten {
+		return "0" + strconv.Itoa(value)
+	}
+	return strconv.Itoa(value)
+}
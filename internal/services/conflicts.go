@@ -0,0 +1,206 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const (
+	conflictsMaxDays = 31
+	conflictsDays    = 13
+)
+
+// GetScheduleConflicts returns the lessons of the date range that clash: a teacher in two places at once,
+// an auditorium taken by unrelated lessons and a group with two lessons at the same time.
+// With facultyID only conflicts involving groups of the faculty are kept.
+func (s *ScheduleService) GetScheduleConflicts(
+	ctx context.Context,
+	fromStr, toStr string,
+	facultyID int,
+) (*models.ScheduleConflicts, error) {
+	from, to, err := parseDateRange(fromStr, toStr, conflictsDays, conflictsMaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	lessons, err := s.Repo.GetScheduledLessons(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ScheduleConflicts{
+		From:      from.Format(time.DateOnly),
+		To:        to.Format(time.DateOnly),
+		FacultyID: facultyID,
+		Conflicts: ScheduleConflicts(lessons, facultyID),
+	}, nil
+}
+
+// conflictOccupation is what a teacher, auditorium or group is busy with, a stream is a single occupation.
+type conflictOccupation struct {
+	key       string
+	start     string
+	end       string
+	lesson    models.ConflictLesson
+	faculties []int
+}
+
+type conflictSubject struct {
+	conflict    models.ScheduleConflict
+	occupations []*conflictOccupation
+}
+
+// add merges the lesson into the occupation with the same key or starts a new one.
+func (s *conflictSubject) add(lesson *models.ScheduledLesson, key string) {
+	index := slices.IndexFunc(s.occupations, func(occupation *conflictOccupation) bool { return occupation.key == key })
+	if index < 0 {
+		s.occupations = append(s.occupations, &conflictOccupation{
+			key:   key,
+			start: lesson.Start,
+			end:   lesson.End,
+			lesson: models.ConflictLesson{
+				Time:       lesson.Time,
+				PairNumber: PairNumber(lesson.Time),
+				Title:      lesson.Title,
+				Type:       lesson.Type,
+			},
+		})
+		index = len(s.occupations) - 1
+	}
+
+	occupation := s.occupations[index]
+	if slices.Contains(occupation.lesson.Ids, lesson.Id) {
+		return
+	}
+	occupation.lesson.Ids = append(occupation.lesson.Ids, lesson.Id)
+	if !slices.Contains(occupation.lesson.Groups, lesson.Group) {
+		occupation.lesson.Groups = append(occupation.lesson.Groups, lesson.Group)
+	}
+	if !slices.Contains(occupation.faculties, lesson.FacultyID) {
+		occupation.faculties = append(occupation.faculties, lesson.FacultyID)
+	}
+	for _, ta := range lesson.TeacherAuditoriums {
+		same := func(other models.StudentTeacherAuditorium) bool {
+			return teacherID(other.Teacher) == teacherID(ta.Teacher) && auditoriumID(other.Auditorium) == auditoriumID(ta.Auditorium)
+		}
+		if !slices.ContainsFunc(occupation.lesson.TeacherAuditoriums, same) {
+			occupation.lesson.TeacherAuditoriums = append(occupation.lesson.TeacherAuditoriums, ta)
+		}
+	}
+}
+
+// ScheduleConflicts finds overlapping lessons of the same teacher, auditorium or group among lessons
+// ordered by date and start time. Lessons of a stream share the title, type and time, so a teacher
+// giving one in a single auditorium, or an auditorium hosting one, is not a conflict. Neither are
+// lessons of a group's subgroups held in parallel with different teachers and auditoriums.
+func ScheduleConflicts(lessons []models.ScheduledLesson, facultyID int) []models.ScheduleConflict {
+	subjects := make(map[string]*conflictSubject)
+	order := make([]*conflictSubject, 0)
+	subject := func(key string, conflict models.ScheduleConflict) *conflictSubject {
+		found, ok := subjects[key]
+		if !ok {
+			found = &conflictSubject{conflict: conflict}
+			subjects[key] = found
+			order = append(order, found)
+		}
+		return found
+	}
+
+	for index := range lessons {
+		lesson := &lessons[index]
+		event := strings.Join([]string{lesson.Title, lesson.Type, lesson.Start, lesson.End}, "|")
+
+		subject(fmt.Sprintf("group|%v|%v", lesson.Date, lesson.Group), models.ScheduleConflict{
+			Kind:  models.ScheduleConflictGroup,
+			Date:  lesson.Date,
+			Group: lesson.Group,
+		}).add(lesson, fmt.Sprint(lesson.Id))
+
+		for _, ta := range lesson.TeacherAuditoriums {
+			if ta.Auditorium != nil {
+				subject(fmt.Sprintf("auditorium|%v|%v", lesson.Date, ta.Auditorium.Id), models.ScheduleConflict{
+					Kind:       models.ScheduleConflictAuditorium,
+					Date:       lesson.Date,
+					Auditorium: ta.Auditorium,
+				}).add(lesson, event)
+			}
+			if ta.Teacher != nil {
+				subject(fmt.Sprintf("teacher|%v|%v", lesson.Date, ta.Teacher.Id), models.ScheduleConflict{
+					Kind:    models.ScheduleConflictTeacher,
+					Date:    lesson.Date,
+					Teacher: ta.Teacher,
+				}).add(lesson, fmt.Sprintf("%v|%v", event, auditoriumID(ta.Auditorium)))
+			}
+		}
+	}
+
+	conflicts := make([]models.ScheduleConflict, 0)
+	for _, subject := range order {
+		for index, first := range subject.occupations {
+			for _, second := range subject.occupations[index+1:] {
+				if first.start >= second.end || second.start >= first.end {
+					continue
+				}
+				if subject.conflict.Kind == models.ScheduleConflictGroup && subgroupLessons(first, second) {
+					continue
+				}
+				if facultyID != 0 && !slices.Contains(first.faculties, facultyID) && !slices.Contains(second.faculties, facultyID) {
+					continue
+				}
+				conflict := subject.conflict
+				conflict.Lessons = []models.ConflictLesson{first.lesson, second.lesson}
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+
+	slices.SortStableFunc(conflicts, func(a, b models.ScheduleConflict) int {
+		return cmp.Or(strings.Compare(a.Date, b.Date), strings.Compare(a.Lessons[1].Time, b.Lessons[1].Time))
+	})
+	return conflicts
+}
+
+// subgroupLessonType reports whether a group is split into subgroups for lessons of the type.
+func subgroupLessonType(lessonType string) bool {
+	return lessonType == "lab" || lessonType == "practice"
+}
+
+// subgroupLessons reports whether two lessons of a group are held by its subgroups: they take the same time,
+// are split into subgroups by type and share no teacher or auditorium.
+func subgroupLessons(first, second *conflictOccupation) bool {
+	if first.start != second.start || first.end != second.end ||
+		!subgroupLessonType(first.lesson.Type) || !subgroupLessonType(second.lesson.Type) {
+		return false
+	}
+	for _, a := range first.lesson.TeacherAuditoriums {
+		for _, b := range second.lesson.TeacherAuditoriums {
+			if a.Teacher != nil && teacherID(a.Teacher) == teacherID(b.Teacher) {
+				return false
+			}
+			if a.Auditorium != nil && auditoriumID(a.Auditorium) == auditoriumID(b.Auditorium) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func teacherID(teacher *models.StudentTeacherInfo) int {
+	if teacher == nil {
+		return 0
+	}
+	return teacher.Id
+}
+
+func auditoriumID(auditorium *models.Auditorium) int {
+	if auditorium == nil {
+		return 0
+	}
+	return auditorium.Id
+}
@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func scheduledLesson(id int, group, title, start, end string, teacherID, auditoriumID int) models.ScheduledLesson {
+	return models.ScheduledLesson{
+		Id:        id,
+		Date:      "2026-03-02",
+		Time:      start + "-" + end,
+		Start:     start,
+		End:       end,
+		Title:     title,
+		Type:      "lecture",
+		Group:     group,
+		FacultyID: 1,
+		TeacherAuditoriums: []models.StudentTeacherAuditorium{{
+			Teacher:    &models.StudentTeacherInfo{Id: teacherID},
+			Auditorium: &models.Auditorium{Id: auditoriumID},
+		}},
+	}
+}
+
+func TestScheduleConflictsSkipStreams(t *testing.T) {
+	lessons := []models.ScheduledLesson{
+		scheduledLesson(1, "344", "Высшая математика", "08:10", "09:45", 1, 10),
+		scheduledLesson(2, "345", "Высшая математика", "08:10", "09:45", 1, 10),
+		scheduledLesson(3, "344", "Физика", "09:55", "11:30", 2, 11),
+	}
+	if conflicts := services.ScheduleConflicts(lessons, 0); len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", conflicts)
+	}
+}
+
+func TestScheduleConflictsSkipSubgroups(t *testing.T) {
+	lessons := []models.ScheduledLesson{
+		// Subgroups of 344 in parallel labs
+		scheduledLesson(1, "344", "Физика", "08:10", "09:45", 1, 10),
+		scheduledLesson(2, "344", "Информатика", "08:10", "09:45", 2, 11),
+		// Labs of 345 sharing an auditorium are not subgroups
+		scheduledLesson(3, "345", "Физика", "09:55", "11:30", 3, 12),
+		scheduledLesson(4, "345", "Информатика", "09:55", "11:30", 4, 12),
+	}
+	for index := range lessons {
+		lessons[index].Type = "lab"
+	}
+
+	conflicts := services.ScheduleConflicts(lessons, 0)
+	if len(conflicts) != 2 || conflicts[0].Kind != models.ScheduleConflictGroup || conflicts[0].Group != "345" ||
+		conflicts[1].Kind != models.ScheduleConflictAuditorium {
+		t.Errorf("expected the group and auditorium conflicts of 345 only, got %+v", conflicts)
+	}
+}
+
+func TestScheduleConflicts(t *testing.T) {
+	lessons := []models.ScheduledLesson{
+		// A stream of two groups and another lesson of the teacher in another auditorium
+		scheduledLesson(1, "344", "Высшая математика", "08:10", "09:45", 1, 10),
+		scheduledLesson(2, "345", "Высшая математика", "08:10", "09:45", 1, 10),
+		scheduledLesson(3, "346", "Дискретная математика", "08:10", "09:45", 1, 11),
+		// An auditorium given to an unrelated group and a group with two lessons
+		scheduledLesson(4, "444", "Физика", "11:40", "13:15", 2, 12),
+		scheduledLesson(5, "445", "Химия", "12:00", "13:35", 3, 12),
+		scheduledLesson(6, "445", "История", "13:00", "14:35", 4, 13),
+	}
+	lessons[5].FacultyID = 2
+
+	conflicts := services.ScheduleConflicts(lessons, 0)
+	kinds := []models.ScheduleConflictKind{
+		models.ScheduleConflictTeacher,
+		models.ScheduleConflictAuditorium,
+		models.ScheduleConflictGroup,
+	}
+	if len(conflicts) != len(kinds) {
+		t.Fatalf("expected %v conflicts, got %+v", len(kinds), conflicts)
+	}
+	for index, kind := range kinds {
+		if conflicts[index].Kind != kind || len(conflicts[index].Lessons) != 2 {
+			t.Errorf("conflict %v: expected a %v conflict, got %+v", index, kind, conflicts[index])
+		}
+	}
+
+	stream := conflicts[0].Lessons[0]
+	if conflicts[0].Teacher.Id != 1 || len(stream.Ids) != 2 || len(stream.Groups) != 2 {
+		t.Errorf("expected the stream merged into one lesson, got %+v", conflicts[0])
+	}
+	if conflicts[1].Auditorium.Id != 12 || conflicts[2].Group != "445" {
+		t.Errorf("unexpected conflicts %+v", conflicts[1:])
+	}
+
+	conflicts = services.ScheduleConflicts(lessons, 2)
+	if len(conflicts) != 1 || conflicts[0].Kind != models.ScheduleConflictGroup {
+		t.Errorf("expected the faculty group conflict only, got %+v", conflicts)
+	}
+}
+
+func TestGetScheduleConflictsValidation(t *testing.T) {
+	s := services.NewScheduleService(nil)
+	tests := []struct{ from, to string }{
+		{"2026-03-01", "2026-02-01"},
+		{"2026-03-01", "2026-05-01"},
+		{"01.03.2026", ""},
+	}
+	for _, test := range tests {
+		if _, err := s.GetScheduleConflicts(t.Context(), test.from, test.to, 0); !errors.As(err, &services.BadRequestError{}) {
+			t.Errorf("%+v: expected bad request, got %v", test, err)
+		}
+	}
+}
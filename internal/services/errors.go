@@ -1,6 +1,11 @@
 package services
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
 
 type NotFoundError struct {
 	s string
@@ -10,4 +15,37 @@ func (e NotFoundError) Error() string {
 	return e.s
 }
 
+// SuggestionsError is a NotFoundError with the entities the client may have meant,
+// it is written as a models.NotFoundResponse body.
+type SuggestionsError struct {
+	NotFoundError
+	Suggestions []models.Suggestion
+}
+
+func (e SuggestionsError) Unwrap() error {
+	return e.NotFoundError
+}
+
+func (e SuggestionsError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(models.NotFoundResponse{Message: e.Error(), Suggestions: e.Suggestions})
+}
+
 var ErrInvalidDateFormat = errors.New("invalid date format, expected YYYY-MM-DD")
+
+// BadRequestError reports invalid request parameters.
+type BadRequestError struct {
+	s string
+}
+
+func (e BadRequestError) Error() string {
+	return e.s
+}
+
+// ConflictError reports a request conflicting with an existing entity.
+type ConflictError struct {
+	s string
+}
+
+func (e ConflictError) Error() string {
+	return e.s
+}
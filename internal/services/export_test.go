@@ -0,0 +1,18 @@
+package services
+
+import (
+	"strings"
+	"time"
+)
+
+func GenerateTimezone(tzid string, location *time.Location, from, to time.Time) []byte {
+	var result strings.Builder
+	writeTimezone(&result, tzid, location, from, to)
+	return []byte(result.String())
+}
+
+var (
+	LessonsFilter       = lessonsFilter
+	EncodeLessonsCursor = encodeLessonsCursor
+	ParseChangesSince   = parseChangesSince
+)
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+const clockLayout = "15:04"
+
+// GetFreeAuditoriums returns auditoriums without lessons in minSlots pairs in a row on the date, starting
+// with the pair going on at timeStr or the next one. The pairs come from the lesson time grid of the date's
+// two-week window, so numerator and denominator weeks use the lessons actually held on the date.
+// timeStr is a clock time or a lessons_times value, the current time by default. Lessons are not held on holidays.
+func (s *ScheduleService) GetFreeAuditoriums(
+	ctx context.Context,
+	dateStr, timeStr string,
+	buildingID, minSlots int,
+) (*models.FreeAuditoriums, error) {
+	date, err := ParseDateOrNow(dateStr)
+	if err != nil {
+		return nil, BadRequestError{"date " + ErrInvalidDateFormat.Error()}
+	}
+	if timeStr == "" {
+		timeStr = utils.GetNowWithZone().Format(clockLayout)
+	}
+	minSlots = max(minSlots, 1)
+
+	periods, err := s.Repo.GetAcademicPeriods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	startDate, endDate := AcademicWeekBounds(periods, date)
+	slots, err := s.Repo.GetLessonSlots(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	numberLessonSlots(slots)
+	start, err := FindLessonSlot(slots, timeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy, err := s.Repo.GetAuditoriumsOccupancy(ctx, date, buildingID)
+	if err != nil {
+		return nil, err
+	}
+	if len(occupancy) == 0 {
+		return nil, NotFoundError{fmt.Sprintf("auditoriums in building '%v' not found", buildingID)}
+	}
+	holidays, err := s.Repo.GetHolidays(ctx, date, date)
+	if err != nil {
+		return nil, err
+	}
+	if FindHoliday(holidays, date) != nil {
+		// В праздник занятия не проводятся, как и в общих свободных парах.
+		for index := range occupancy {
+			occupancy[index].Busy = nil
+		}
+	}
+
+	return &models.FreeAuditoriums{
+		Date:        date.Format(time.DateOnly),
+		WeekType:    EnglishWeekType(WeekType(periods, date)),
+		Slots:       slots[start:min(start+minSlots, len(slots))],
+		Auditoriums: FreeAuditoriums(occupancy, slots[start:], minSlots),
+	}, nil
+}
+
+// FindLessonSlot returns the index of the slot with the lessons_times value, or of the pair
+// going on at the clock time or starting after it.
+func FindLessonSlot(slots []models.LessonSlot, value string) (int, error) {
+	for index := range slots {
+		if slots[index].Time == value {
+			return index, nil
+		}
+	}
+
+	clock, err := time.Parse(clockLayout, strings.Replace(value, ".", ":", 1))
+	if err != nil {
+		return 0, BadRequestError{"time must be HH:MM or a lesson time like 08.10-09.45"}
+	}
+	moment := clock.Format(clockLayout)
+	for index := range slots {
+		if slots[index].End > moment {
+			return index, nil
+		}
+	}
+	return 0, NotFoundError{fmt.Sprintf("no lessons after %v", moment)}
+}
+
+// FreeAuditoriums keeps auditoriums without lessons in the first minSlots slots and counts how many slots
+// in a row each one is free. There are none when fewer than minSlots slots are left.
+func FreeAuditoriums(occupancy []models.AuditoriumOccupancy, slots []models.LessonSlot, minSlots int) []models.FreeAuditorium {
+	if minSlots > len(slots) {
+		return make([]models.FreeAuditorium, 0)
+	}
+	free := make([]models.FreeAuditorium, 0, len(occupancy))
+	for index := range occupancy {
+		freeSlots := 0
+		for freeSlots < len(slots) && !slotBusy(&slots[freeSlots], occupancy[index].Busy) {
+			freeSlots++
+		}
+		if freeSlots >= minSlots {
+			free = append(free, models.FreeAuditorium{Auditorium: occupancy[index].Auditorium, FreeSlots: freeSlots})
+		}
+	}
+	return free
+}
+
+// slotBusy reports whether any lesson overlaps the slot. Clock times are zero-padded, so they compare as strings.
+func slotBusy(slot *models.LessonSlot, lessons []models.LessonSlot) bool {
+	for index := range lessons {
+		if lessons[index].Start < slot.End && slot.Start < lessons[index].End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+var lessonSlots = []models.LessonSlot{
+	{Time: "08.10-09.45", Start: "08:10", End: "09:45"},
+	{Time: "09.55-11.30", Start: "09:55", End: "11:30"},
+	{Time: "11.40-13.15", Start: "11:40", End: "13:15"},
+	{Time: "13.35-15.10", Start: "13:35", End: "15:10"},
+}
+
+func TestFindLessonSlot(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"11.40-13.15", 2},
+		{"07:00", 0},
+		{"09:50", 1},
+		{"12:00", 2},
+		{"12.00", 2},
+		{"13:15", 3},
+	}
+	for _, test := range tests {
+		index, err := services.FindLessonSlot(lessonSlots, test.value)
+		if err != nil || index != test.expected {
+			t.Errorf("%v: got slot %v, %v, expected %v", test.value, index, err, test.expected)
+		}
+	}
+
+	if _, err := services.FindLessonSlot(lessonSlots, "15:10"); !errors.As(err, &services.NotFoundError{}) {
+		t.Errorf("expected no slots after the last pair, got %v", err)
+	}
+	if _, err := services.FindLessonSlot(lessonSlots, "third"); !errors.As(err, &services.BadRequestError{}) {
+		t.Errorf("expected bad request, got %v", err)
+	}
+}
+
+func TestFreeAuditoriums(t *testing.T) {
+	occupancy := []models.AuditoriumOccupancy{
+		{Auditorium: models.Auditorium{Id: 1}, Busy: []models.LessonSlot{{Start: "09:55", End: "11:30"}}},
+		{Auditorium: models.Auditorium{Id: 2}, Busy: []models.LessonSlot{{Start: "13:35", End: "16:45"}}},
+		{Auditorium: models.Auditorium{Id: 3}},
+		// A lab takes two pairs
+		{Auditorium: models.Auditorium{Id: 4}, Busy: []models.LessonSlot{{Start: "11:40", End: "15:10"}}},
+	}
+
+	free := services.FreeAuditoriums(occupancy, lessonSlots[2:], 1)
+	expected := map[int]int{1: 2, 2: 1, 3: 2}
+	if len(free) != len(expected) {
+		t.Fatalf("unexpected free auditoriums %+v", free)
+	}
+	for _, auditorium := range free {
+		if expected[auditorium.Id] != auditorium.FreeSlots {
+			t.Errorf("auditorium %v: %v free slots, expected %v", auditorium.Id, auditorium.FreeSlots, expected[auditorium.Id])
+		}
+	}
+
+	free = services.FreeAuditoriums(occupancy, lessonSlots[2:], 2)
+	if len(free) != 2 || free[0].Id != 1 || free[1].Id != 3 {
+		t.Errorf("unexpected auditoriums free for two pairs %+v", free)
+	}
+
+	free = services.FreeAuditoriums(occupancy, lessonSlots[3:], 5)
+	if free == nil || len(free) != 0 {
+		t.Errorf("expected no auditoriums when fewer pairs are left, got %+v", free)
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+const (
+	commonFreeSlotsMaxMembers = 20
+	commonFreeSlotsMaxDays    = 31
+	commonFreeSlotsDays       = 6
+)
+
+// CommonFreeSlotsQuery holds comma separated group numbers and teacher ids, dates are YYYY-MM-DD.
+type CommonFreeSlotsQuery struct {
+	Groups         string
+	Teachers       string
+	From           string
+	To             string
+	WithAuditorium bool
+	BuildingID     int
+}
+
+// GetCommonFreeSlots returns the pairs of the lesson time grid in which none of the groups and teachers
+// has a lesson, so retakes and consultations do not need comparing their schedules by hand.
+// Sundays and holidays are skipped. With WithAuditorium every slot gets an auditorium free at that time.
+func (s *ScheduleService) GetCommonFreeSlots(ctx context.Context, query CommonFreeSlotsQuery) (*models.CommonFreeSlots, error) {
+	groups := splitStreamList(query.Groups)
+	for index := range groups {
+		groups[index] = strings.ToUpper(groups[index])
+	}
+	teacherIDs, err := parseStreamIDs("teachers", query.Teachers)
+	if err != nil {
+		return nil, err
+	}
+	groups, teacherIDs = uniqueSorted(groups), sortedIDs(teacherIDs)
+	if len(groups)+len(teacherIDs) == 0 {
+		return nil, BadRequestError{"at least one of groups or teachers is required"}
+	}
+	if len(groups)+len(teacherIDs) > commonFreeSlotsMaxMembers {
+		return nil, BadRequestError{fmt.Sprintf("at most %v groups and teachers can be compared", commonFreeSlotsMaxMembers)}
+	}
+
+	from, to, err := parseDateRange(query.From, query.To, commonFreeSlotsDays, commonFreeSlotsMaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// Подписка на поток проверяет те же группы и преподавателей.
+	existing, err := s.Repo.GetScheduleStreamSubscription(ctx, groups, teacherIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if !slices.Contains(existing.Groups, group) {
+			return nil, NotFoundError{fmt.Sprintf("group %v not found", group)}
+		}
+	}
+	for _, id := range teacherIDs {
+		if _, ok := existing.Teachers[id]; !ok {
+			return nil, NotFoundError{fmt.Sprintf("teacher %v not found", id)}
+		}
+	}
+
+	grid, err := s.Repo.GetLessonSlots(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	numberLessonSlots(grid)
+	lessons, err := s.Repo.GetLessonIntervals(ctx, from, to, groups, teacherIDs)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.Repo.GetHolidays(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := CommonFreeSlots(from, to, grid, lessons, holidays)
+	if query.WithAuditorium {
+		if err := s.addFreeAuditoriums(ctx, slots, query.BuildingID); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.CommonFreeSlots{
+		From:     from.Format(time.DateOnly),
+		To:       to.Format(time.DateOnly),
+		Groups:   groups,
+		Teachers: teacherIDs,
+		Slots:    slots,
+	}, nil
+}
+
+// parseDateRange parses YYYY-MM-DD bounds, from defaults to today and to to days after from.
+func parseDateRange(fromStr, toStr string, days, maxDays int) (from, to time.Time, err error) {
+	if fromStr == "" {
+		from = dateOnly(utils.GetNowWithZone())
+	} else if from, err = time.Parse(time.DateOnly, fromStr); err != nil {
+		return from, to, BadRequestError{"from " + ErrInvalidDateFormat.Error()}
+	}
+	if toStr == "" {
+		to = from.AddDate(0, 0, days)
+	} else if to, err = time.Parse(time.DateOnly, toStr); err != nil {
+		return from, to, BadRequestError{"to " + ErrInvalidDateFormat.Error()}
+	}
+	if to.Before(from) {
+		return from, to, BadRequestError{"to must not be before from"}
+	}
+	if to.After(from.AddDate(0, 0, maxDays)) {
+		return from, to, BadRequestError{fmt.Sprintf("date range must not exceed %d days", maxDays)}
+	}
+	return from, to, nil
+}
+
+// CommonFreeSlots returns the grid slots of every working day from from to to that no lesson overlaps.
+func CommonFreeSlots(
+	from, to time.Time,
+	grid []models.LessonSlot,
+	lessons []models.LessonInterval,
+	holidays []models.Holiday,
+) []models.CommonFreeSlot {
+	busy := make(map[string][]models.LessonSlot)
+	for _, lesson := range lessons {
+		busy[lesson.Date] = append(busy[lesson.Date], models.LessonSlot{Start: lesson.Start, End: lesson.End})
+	}
+
+	slots := make([]models.CommonFreeSlot, 0)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == time.Sunday || FindHoliday(holidays, date) != nil {
+			continue
+		}
+		day := date.Format(time.DateOnly)
+		for index := range grid {
+			if !slotBusy(&grid[index], busy[day]) {
+				slots = append(slots, models.CommonFreeSlot{Date: day, LessonSlot: grid[index]})
+			}
+		}
+	}
+	return slots
+}
+
+// addFreeAuditoriums sets the first auditorium free in each slot, slots without one are left without it.
+func (s *ScheduleService) addFreeAuditoriums(ctx context.Context, slots []models.CommonFreeSlot, buildingID int) error {
+	occupancy := make(map[string][]models.AuditoriumOccupancy)
+	for index := range slots {
+		slot := &slots[index]
+		auditoriums, ok := occupancy[slot.Date]
+		if !ok {
+			date, err := time.Parse(time.DateOnly, slot.Date)
+			if err != nil {
+				return err
+			}
+			if auditoriums, err = s.Repo.GetAuditoriumsOccupancy(ctx, date, buildingID); err != nil {
+				return err
+			}
+			occupancy[slot.Date] = auditoriums
+		}
+
+		if free := FreeAuditoriums(auditoriums, []models.LessonSlot{slot.LessonSlot}, 1); len(free) > 0 {
+			slot.Auditorium = &free[0].Auditorium
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestCommonFreeSlots(t *testing.T) {
+	// Saturday to Monday, Monday is a holiday
+	from := time.Date(2026, 2, 21, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 23, 0, 0, 0, 0, time.UTC)
+	lessons := []models.LessonInterval{
+		{Date: "2026-02-21", Start: "08:10", End: "09:45"},
+		{Date: "2026-02-21", Start: "11:40", End: "15:10"},
+		{Date: "2026-02-23", Start: "09:55", End: "11:30"},
+	}
+	holidays := []models.Holiday{{Date: "2026-02-23", Kind: models.HolidayKindHoliday}}
+
+	slots := services.CommonFreeSlots(from, to, lessonSlots, lessons, holidays)
+	if len(slots) != 1 || slots[0].Date != "2026-02-21" || slots[0].Time != "09.55-11.30" {
+		t.Fatalf("unexpected free slots %+v", slots)
+	}
+
+	slots = services.CommonFreeSlots(from, to, lessonSlots, lessons, nil)
+	if len(slots) != 4 {
+		t.Fatalf("expected the Monday slots but one, got %+v", slots)
+	}
+	for _, slot := range slots[1:] {
+		if slot.Date != "2026-02-23" || slot.Time == "09.55-11.30" {
+			t.Errorf("unexpected free slot %+v", slot)
+		}
+	}
+}
+
+func TestGetCommonFreeSlotsValidation(t *testing.T) {
+	s := services.NewScheduleService(nil)
+	tests := []services.CommonFreeSlotsQuery{
+		{},
+		{Teachers: "1,two"},
+		{Groups: "344", From: "2026-03-01", To: "2026-02-01"},
+		{Groups: "344", From: "2026-03-01", To: "2026-05-01"},
+		{Groups: "344", From: "01.03.2026"},
+		{Teachers: "1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21"},
+	}
+	for _, query := range tests {
+		if _, err := s.GetCommonFreeSlots(t.Context(), query); !errors.As(err, &services.BadRequestError{}) {
+			t.Errorf("%+v: expected bad request, got %v", query, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import "github.com/schedule-rsreu/schedule-api/internal/models"
+
+// Layouts of the week schedules: the lessons of every day, or a day × pair matrix of the bell schedule.
+const (
+	LayoutWeek = "week"
+	LayoutGrid = "grid"
+)
+
+// StudentScheduleGrid replaces the weeks of the schedule with their grid.
+func StudentScheduleGrid(schedule *models.StudentSchedule) {
+	schedule.Grid = scheduleGrid(
+		(*models.Week[models.StudentLesson])(&schedule.Schedule.Numerator),
+		(*models.Week[models.StudentLesson])(&schedule.Schedule.Denominator),
+		func(lesson *models.StudentLesson) string { return lesson.Time },
+	)
+	schedule.Schedule = models.NumeratorDenominator[models.StudentWeek]{}
+}
+
+// TeacherScheduleGrid replaces the weeks of the schedule with their grid.
+func TeacherScheduleGrid(schedule *models.TeacherSchedule) {
+	schedule.Grid = scheduleGrid(
+		(*models.Week[models.TeacherLesson])(&schedule.Schedule.Numerator),
+		(*models.Week[models.TeacherLesson])(&schedule.Schedule.Denominator),
+		func(lesson *models.TeacherLesson) string { return lesson.Time },
+	)
+	schedule.Schedule = models.NumeratorDenominator[models.TeacherWeek]{}
+}
+
+// AuditoriumScheduleGrid replaces the weeks of the schedule with their grid.
+func AuditoriumScheduleGrid(schedule *models.AuditoriumSchedule) {
+	schedule.Grid = scheduleGrid(
+		(*models.Week[models.AuditoriumLesson])(&schedule.Schedule.Numerator),
+		(*models.Week[models.AuditoriumLesson])(&schedule.Schedule.Denominator),
+		func(lesson *models.AuditoriumLesson) string { return lesson.Time },
+	)
+	schedule.Schedule = models.NumeratorDenominator[models.AuditoriumWeek]{}
+}
+
+func scheduleGrid[TLesson models.StudentLesson | models.TeacherLesson | models.AuditoriumLesson](
+	numerator, denominator *models.Week[TLesson],
+	lessonTime func(lesson *TLesson) string,
+) *models.ScheduleGrid[TLesson] {
+	return &models.ScheduleGrid[TLesson]{
+		Numerator:   gridWeek(numerator, lessonTime),
+		Denominator: gridWeek(denominator, lessonTime),
+	}
+}
+
+func gridWeek[TLesson models.StudentLesson | models.TeacherLesson | models.AuditoriumLesson](
+	week *models.Week[TLesson],
+	lessonTime func(lesson *TLesson) string,
+) []models.GridDay[TLesson] {
+	dayNames := [...]string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+	days := make([]models.GridDay[TLesson], 0, len(dayNames))
+	for index, lessons := range weekDays(week) {
+		days = append(days, models.GridDay[TLesson]{Day: dayNames[index], Cells: gridCells(lessons, lessonTime)})
+	}
+	return days
+}
+
+// gridCells puts the lessons into the cells of the pairs they start at. Every day has all pairs of the bell
+// schedule, so the matrix keeps its size. A lesson off the schedule goes to the pair going on at its start,
+// or the next one.
+func gridCells[TLesson models.StudentLesson | models.TeacherLesson | models.AuditoriumLesson](
+	lessons []TLesson,
+	lessonTime func(lesson *TLesson) string,
+) []models.GridCell[TLesson] {
+	bells := bellSchedule()
+	cells := make([]models.GridCell[TLesson], 0, len(bells))
+	for _, bell := range bells {
+		cells = append(cells, models.GridCell[TLesson]{Bell: bell, Lessons: make([]TLesson, 0)})
+	}
+
+	for index := range lessons {
+		start := lessonStart(lessonTime(&lessons[index]))
+		cell := len(cells) - 1
+		for bellIndex := range bells {
+			if bells[bellIndex].End > start {
+				cell = bellIndex
+				break
+			}
+		}
+		cells[cell].Lessons = append(cells[cell].Lessons, lessons[index])
+	}
+	return cells
+}
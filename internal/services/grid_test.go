@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestStudentScheduleGrid(t *testing.T) {
+	schedule := &models.StudentSchedule{Group: "344"}
+	schedule.Schedule.Numerator.Monday = []models.StudentLesson{
+		{Time: "11.40-13.15", Lesson: "Физика", PairNumber: 3},
+		{Time: "11.40-13.15", Lesson: "Информатика", PairNumber: 3},
+	}
+	schedule.Schedule.Denominator.Friday = []models.StudentLesson{{Time: "12.00-13.00", Lesson: "Английский язык"}}
+
+	services.StudentScheduleGrid(schedule)
+
+	grid := schedule.Grid
+	if grid == nil || len(grid.Numerator) != 6 || len(grid.Denominator) != 6 {
+		t.Fatalf("expected six days in both weeks, got %+v", grid)
+	}
+	for _, day := range append(grid.Numerator, grid.Denominator...) {
+		if len(day.Cells) != 8 {
+			t.Errorf("%v: expected a cell for every pair, got %v", day.Day, len(day.Cells))
+		}
+	}
+
+	monday := grid.Numerator[0]
+	if monday.Day != "monday" || len(monday.Cells[2].Lessons) != 2 || monday.Cells[2].Number != 3 {
+		t.Errorf("expected both subgroups in the third pair, got %+v", monday)
+	}
+	if monday.Cells[0].Lessons == nil || len(monday.Cells[0].Lessons) != 0 {
+		t.Errorf("expected an empty first pair, got %+v", monday.Cells[0])
+	}
+
+	friday := grid.Denominator[4]
+	if friday.Day != "friday" || len(friday.Cells[2].Lessons) != 1 {
+		t.Errorf("expected the lesson off the bell schedule in the third pair, got %+v", friday)
+	}
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), `"schedule"`) || !strings.Contains(string(data), `"lessons":[]`) {
+		t.Errorf("expected the grid instead of the weeks, got %s", data)
+	}
+}
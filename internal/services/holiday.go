@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+// productionCalendar is the bundled production calendar of the Russian Federation:
+// public holidays and days off transferred by the government.
+//
+//go:embed data/production_calendar.json
+var productionCalendar []byte
+
+// ProductionCalendar returns the days of the bundled production calendar.
+func ProductionCalendar() ([]models.Holiday, error) {
+	var holidays []models.Holiday
+	if err := json.Unmarshal(productionCalendar, &holidays); err != nil {
+		return nil, fmt.Errorf("production calendar: %w", err)
+	}
+	for index := range holidays {
+		holidays[index].Source = models.HolidaySourceProductionCalendar
+		if err := validateHoliday(&holidays[index]); err != nil {
+			return nil, fmt.Errorf("production calendar: %w", err)
+		}
+	}
+	return holidays, nil
+}
+
+// GetHolidays returns holidays between from and to inclusive, by default the holidays of the current year.
+func (s *ScheduleService) GetHolidays(ctx context.Context, fromStr, toStr string) ([]models.Holiday, error) {
+	now := utils.GetNowWithZone()
+	from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	if fromStr != "" {
+		var err error
+		if from, err = time.Parse(time.DateOnly, fromStr); err != nil {
+			return nil, BadRequestError{"from " + ErrInvalidDateFormat.Error()}
+		}
+	}
+	to := time.Date(from.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	if toStr != "" {
+		var err error
+		if to, err = time.Parse(time.DateOnly, toStr); err != nil {
+			return nil, BadRequestError{"to " + ErrInvalidDateFormat.Error()}
+		}
+	}
+	if to.Before(from) {
+		return nil, BadRequestError{"to must not be before from"}
+	}
+	return s.Repo.GetHolidays(ctx, from, to)
+}
+
+func (s *ScheduleService) SaveHoliday(ctx context.Context, holiday *models.Holiday) error {
+	holiday.Source = models.HolidaySourceManual
+	if err := validateHoliday(holiday); err != nil {
+		return BadRequestError{err.Error()}
+	}
+	return s.Repo.SaveHoliday(ctx, holiday)
+}
+
+func (s *ScheduleService) DeleteHoliday(ctx context.Context, date string) error {
+	if err := s.Repo.DeleteHoliday(ctx, date); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return NotFoundError{fmt.Sprintf("holiday '%v' not found", date)}
+		}
+		return err
+	}
+	return nil
+}
+
+// ImportProductionCalendar loads the bundled production calendar, only the given year when it is not zero.
+// Days changed through the admin API are kept.
+func (s *ScheduleService) ImportProductionCalendar(ctx context.Context, year int) (*models.HolidaysImport, error) {
+	holidays, err := ProductionCalendar()
+	if err != nil {
+		return nil, err
+	}
+	if year != 0 {
+		prefix := fmt.Sprintf("%04d-", year)
+		filtered := holidays[:0]
+		for index := range holidays {
+			if strings.HasPrefix(holidays[index].Date, prefix) {
+				filtered = append(filtered, holidays[index])
+			}
+		}
+		if len(filtered) == 0 {
+			return nil, NotFoundError{fmt.Sprintf("production calendar for year '%v' not found", year)}
+		}
+		holidays = filtered
+	}
+
+	imported, err := s.Repo.ImportHolidays(ctx, holidays)
+	if err != nil {
+		return nil, err
+	}
+	return &models.HolidaysImport{Imported: imported}, nil
+}
+
+func validateHoliday(holiday *models.Holiday) error {
+	if _, err := time.Parse(time.DateOnly, holiday.Date); err != nil {
+		return fmt.Errorf("date %w", ErrInvalidDateFormat)
+	}
+	if strings.TrimSpace(holiday.Title) == "" {
+		return fmt.Errorf("holiday %v has no title", holiday.Date)
+	}
+	switch holiday.Kind {
+	case models.HolidayKindHoliday, models.HolidayKindNonWorking, models.HolidayKindClosure:
+		return nil
+	}
+	return fmt.Errorf("holiday %v has unknown kind '%v'", holiday.Date, holiday.Kind)
+}
+
+// holidayDates indexes holidays by date.
+func holidayDates(holidays []models.Holiday) map[string]models.Holiday {
+	dates := make(map[string]models.Holiday, len(holidays))
+	for _, holiday := range holidays {
+		dates[holiday.Date] = holiday
+	}
+	return dates
+}
+
+// FindHoliday returns the holiday of date, nil on working days.
+func FindHoliday(holidays []models.Holiday, date time.Time) *models.Holiday {
+	day := date.Format(time.DateOnly)
+	for index := range holidays {
+		if holidays[index].Date == day {
+			return &holidays[index]
+		}
+	}
+	return nil
+}
+
+func weekDays[TLesson models.StudentLesson | models.TeacherLesson | models.AuditoriumLesson](
+	week *models.Week[TLesson],
+) [][]TLesson {
+	return [][]TLesson{week.Monday, week.Tuesday, week.Wednesday, week.Thursday, week.Friday, week.Saturday}
+}
+
+// MarkStudentHolidays flags lessons the import still has on holidays.
+func MarkStudentHolidays(schedule *models.StudentSchedule, holidays []models.Holiday) {
+	dates := holidayDates(holidays)
+	for _, week := range []*models.StudentWeek{&schedule.Schedule.Numerator, &schedule.Schedule.Denominator} {
+		for _, lessons := range weekDays((*models.Week[models.StudentLesson])(week)) {
+			for index := range lessons {
+				_, lessons[index].IsHoliday = dates[lessons[index].Date]
+			}
+		}
+	}
+	schedule.Holidays = holidays
+}
+
+// MarkTeacherHolidays flags lessons the import still has on holidays.
+func MarkTeacherHolidays(schedule *models.TeacherSchedule, holidays []models.Holiday) {
+	dates := holidayDates(holidays)
+	for _, week := range []*models.TeacherWeek{&schedule.Schedule.Numerator, &schedule.Schedule.Denominator} {
+		for _, lessons := range weekDays((*models.Week[models.TeacherLesson])(week)) {
+			for index := range lessons {
+				_, lessons[index].IsHoliday = dates[lessons[index].Date]
+			}
+		}
+	}
+	schedule.Holidays = holidays
+}
+
+// MarkAuditoriumHolidays flags lessons the import still has on holidays.
+func MarkAuditoriumHolidays(schedule *models.AuditoriumSchedule, holidays []models.Holiday) {
+	dates := holidayDates(holidays)
+	for _, week := range []*models.AuditoriumWeek{&schedule.Schedule.Numerator, &schedule.Schedule.Denominator} {
+		for _, lessons := range weekDays((*models.Week[models.AuditoriumLesson])(week)) {
+			for index := range lessons {
+				_, lessons[index].IsHoliday = dates[lessons[index].Date]
+			}
+		}
+	}
+	schedule.Holidays = holidays
+}
+
+// calendarHolidays loads the holidays between the first and the last event of the calendar.
+func (s *ScheduleService) calendarHolidays(ctx context.Context, calendar *models.Calendar) error {
+	if len(calendar.Events) == 0 {
+		return nil
+	}
+	location := calendarLocation()
+	from, to := calendarWindow(calendar.Events, calendar.UpdatedAt, location)
+	holidays, err := s.Repo.GetHolidays(ctx, dateOnly(from), dateOnly(to))
+	if err != nil {
+		return err
+	}
+	calendar.Holidays = holidays
+	return nil
+}
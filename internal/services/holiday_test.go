@@ -0,0 +1,96 @@
+package services_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestProductionCalendar(t *testing.T) {
+	holidays, err := services.ProductionCalendar()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holidays) == 0 {
+		t.Fatal("production calendar is empty")
+	}
+	for index := 1; index < len(holidays); index++ {
+		if holidays[index-1].Date >= holidays[index].Date {
+			t.Errorf("production calendar is not sorted or has duplicates at %v", holidays[index].Date)
+		}
+	}
+	if holiday := services.FindHoliday(holidays, time.Date(2026, 2, 23, 10, 0, 0, 0, time.UTC)); holiday == nil ||
+		holiday.Kind != models.HolidayKindHoliday || holiday.Source != models.HolidaySourceProductionCalendar {
+		t.Errorf("unexpected 2026-02-23: %+v", holiday)
+	}
+	if holiday := services.FindHoliday(holidays, time.Date(2026, 2, 24, 10, 0, 0, 0, time.UTC)); holiday != nil {
+		t.Errorf("2026-02-24 is a working day, got %+v", holiday)
+	}
+}
+
+func TestMarkStudentHolidays(t *testing.T) {
+	schedule := &models.StudentSchedule{}
+	schedule.Schedule.Numerator.Monday = []models.StudentLesson{
+		{Time: "08.10-09.45", Date: "2026-02-23"},
+	}
+	schedule.Schedule.Denominator.Monday = []models.StudentLesson{
+		{Time: "08.10-09.45", Date: "2026-02-16"},
+	}
+	holidays := []models.Holiday{{Date: "2026-02-23", Title: "День защитника Отечества", Kind: models.HolidayKindHoliday}}
+
+	services.MarkStudentHolidays(schedule, holidays)
+
+	if !schedule.Schedule.Numerator.Monday[0].IsHoliday {
+		t.Error("lesson on a holiday is not flagged")
+	}
+	if schedule.Schedule.Denominator.Monday[0].IsHoliday {
+		t.Error("lesson on a working day is flagged")
+	}
+	if len(schedule.Holidays) != 1 {
+		t.Errorf("expected the holidays of the window, got %+v", schedule.Holidays)
+	}
+}
+
+func TestGenerateCalendarHolidays(t *testing.T) {
+	calendar := &models.Calendar{
+		Owner:     models.CalendarOwnerGroup,
+		Name:      "344",
+		UpdatedAt: time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC),
+		Events: []models.CalendarEvent{
+			{
+				UID:        "lesson@rsreu-schedule.ru",
+				StartTime:  time.Date(2026, 2, 20, 8, 10, 0, 0, time.UTC),
+				EndTime:    time.Date(2026, 2, 20, 9, 45, 0, 0, time.UTC),
+				Title:      "Философия",
+				LessonType: "lecture",
+			},
+		},
+		Holidays: []models.Holiday{
+			{Date: "2026-02-23", Title: "День защитника Отечества", Kind: models.HolidayKindHoliday},
+		},
+	}
+
+	options := services.DefaultCalendarOptions()
+	options.Lang = services.CalendarLangEn
+	result := strings.ReplaceAll(string(services.GenerateCalendar(calendar, options)), "\r\n ", "")
+
+	for _, expected := range []string{
+		"BEGIN:VEVENT\r\nUID:holiday-20260223@rsreu-schedule.ru\r\n",
+		"DTSTART;VALUE=DATE:20260223\r\n",
+		"DTEND;VALUE=DATE:20260224\r\n",
+		"SUMMARY:🎉 День защитника Отечества\r\n",
+		"CATEGORIES:HOLIDAY\r\n",
+		"DESCRIPTION:Public holiday\r\n",
+		"TRANSP:TRANSPARENT\r\n",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("calendar does not contain %q:\n%s", expected, result)
+		}
+	}
+	if strings.Count(result, "BEGIN:VEVENT\r\n") != 2 {
+		t.Errorf("expected the lesson and the holiday:\n%s", result)
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+const (
+	defaultChangesDays = 7
+	changesPage        = 500
+)
+
+// GetGroupChanges returns a page of lesson changes of the group after the change id in afterStr, usually cursor
+// of the previous page. The first page starts after since: an RFC 3339 timestamp or a date, by default a week ago.
+// Changes are paged by id: changed_at is the start of the import transaction, so it can be earlier than
+// changed_at of the changes already returned.
+func (s *ScheduleService) GetGroupChanges(ctx context.Context, group, sinceStr, afterStr string,
+) (*models.LessonChanges, error) {
+	since, err := parseChangesSince(sinceStr)
+	if err != nil {
+		return nil, err
+	}
+	var afterID int64
+	if afterStr != "" {
+		if afterID, err = strconv.ParseInt(afterStr, 10, 64); err != nil || afterID < 0 {
+			return nil, BadRequestError{"after must be a change id"}
+		}
+	}
+
+	group = strings.ToUpper(strings.TrimSpace(group))
+	changes, err := s.Repo.GetGroupChanges(ctx, group, since, afterID, changesPage+1)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("group %v not found", group)}
+		}
+		return nil, err
+	}
+	if len(changes.Changes) > changesPage {
+		changes.Changes = changes.Changes[:changesPage]
+		changes.HasMore = true
+	}
+	changes.Cursor = afterID
+	for index := range changes.Changes {
+		describeLessonChange(&changes.Changes[index])
+		changes.Cursor = changes.Changes[index].Id
+	}
+	return changes, nil
+}
+
+// describeLessonChange fills what is derived from the stored versions: the changed fields and pair numbers.
+func describeLessonChange(change *models.LessonChange) {
+	change.Fields = ChangedLessonFields(change.Before, change.After)
+	numberLessonVersion(change.Before)
+	numberLessonVersion(change.After)
+}
+
+func parseChangesSince(sinceStr string) (time.Time, error) {
+	if sinceStr == "" {
+		return utils.GetNowWithZone().AddDate(0, 0, -defaultChangesDays), nil
+	}
+	if since, err := time.Parse(time.RFC3339Nano, sinceStr); err == nil {
+		return since, nil
+	}
+	since, err := time.ParseInLocation(time.DateOnly, sinceStr, calendarLocation())
+	if err != nil {
+		return time.Time{}, BadRequestError{"since must be an RFC 3339 timestamp or a date in YYYY-MM-DD format"}
+	}
+	return since, nil
+}
+
+// ChangedLessonFields lists what differs between two versions of a lesson: time, teacher or auditorium.
+func ChangedLessonFields(before, after *models.LessonVersion) []string {
+	if before == nil || after == nil {
+		return nil
+	}
+	var fields []string
+	if before.StartTime != after.StartTime || before.EndTime != after.EndTime {
+		fields = append(fields, "time")
+	}
+	teacher := func(pair models.CalendarTeacherAuditorium) string { return pair.Teacher }
+	if !slices.Equal(lessonVersionNames(before, teacher), lessonVersionNames(after, teacher)) {
+		fields = append(fields, "teacher")
+	}
+	auditorium := func(pair models.CalendarTeacherAuditorium) string { return pair.Auditorium }
+	if !slices.Equal(lessonVersionNames(before, auditorium), lessonVersionNames(after, auditorium)) {
+		fields = append(fields, "auditorium")
+	}
+	return fields
+}
+
+func lessonVersionNames(version *models.LessonVersion, name func(pair models.CalendarTeacherAuditorium) string) []string {
+	names := make([]string, 0, len(version.TeacherAuditoriums))
+	for _, pair := range version.TeacherAuditoriums {
+		names = append(names, name(pair))
+	}
+	return uniqueSorted(names)
+}
@@ -0,0 +1,78 @@
+package services_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestChangedLessonFields(t *testing.T) {
+	before := &models.LessonVersion{
+		StartTime: "2026-02-09T08:10:00",
+		EndTime:   "2026-02-09T09:45:00",
+		TeacherAuditoriums: []models.CalendarTeacherAuditorium{
+			{Teacher: "Конюхов Алексей Николаевич", Auditorium: "333 C"},
+		},
+	}
+
+	for _, test := range []struct {
+		name     string
+		after    models.LessonVersion
+		expected []string
+	}{
+		{"same", *before, nil},
+		{
+			"room move",
+			models.LessonVersion{
+				StartTime: before.StartTime, EndTime: before.EndTime,
+				TeacherAuditoriums: []models.CalendarTeacherAuditorium{
+					{Teacher: "Конюхов Алексей Николаевич", Auditorium: "445 C"},
+				},
+			},
+			[]string{"auditorium"},
+		},
+		{
+			"teacher swap and time shift",
+			models.LessonVersion{
+				StartTime: "2026-02-09T09:55:00", EndTime: "2026-02-09T11:30:00",
+				TeacherAuditoriums: []models.CalendarTeacherAuditorium{
+					{Teacher: "Соловьев Александр Вадимович", Auditorium: "333 C"},
+				},
+			},
+			[]string{"time", "teacher"},
+		},
+	} {
+		if actual := services.ChangedLessonFields(before, &test.after); !slices.Equal(actual, test.expected) {
+			t.Errorf("%v: expected %v, got %v", test.name, test.expected, actual)
+		}
+	}
+
+	if fields := services.ChangedLessonFields(nil, before); fields != nil {
+		t.Errorf("added lesson has no changed fields, got %v", fields)
+	}
+}
+
+func TestParseChangesSince(t *testing.T) {
+	since, err := services.ParseChangesSince("2026-02-10T09:00:00.123456+03:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !since.Equal(time.Date(2026, 2, 10, 6, 0, 0, 123456000, time.UTC)) {
+		t.Errorf("unexpected timestamp %v", since)
+	}
+
+	since, err = services.ParseChangesSince("2026-02-10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !since.Equal(time.Date(2026, 2, 9, 21, 0, 0, 0, time.UTC)) {
+		t.Errorf("date must start at midnight in Moscow, got %v", since)
+	}
+
+	if _, err = services.ParseChangesSince("yesterday"); err == nil {
+		t.Error("expected an error for an invalid since")
+	}
+}
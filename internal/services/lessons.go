@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+const (
+	// MaxLessonsRangeDays allows a whole semester with the exam session in one range.
+	MaxLessonsRangeDays = 184
+	DefaultLessonsLimit = 100
+	MaxLessonsLimit     = 500
+
+	defaultLessonsRangeDays = 13
+	lessonStartTimeFormat   = "2006-01-02T15:04:05"
+)
+
+// LessonsQuery is a date range of lessons with cursor pagination, empty fields take the defaults.
+type LessonsQuery struct {
+	From   string
+	To     string
+	Cursor string
+	Limit  int
+}
+
+// lessonsFinder returns the merged lessons of a group, teacher or auditorium matching the filter.
+type lessonsFinder func(filter models.LessonsFilter) ([]models.Lesson, error)
+
+func (s *ScheduleService) GetGroupLessons(ctx context.Context, group string, query LessonsQuery) (*models.LessonsPage, error) {
+	return getLessonsPage(query, s.groupLessons(ctx, group))
+}
+
+func (s *ScheduleService) GetTeacherLessons(ctx context.Context, teacherID int, query LessonsQuery) (*models.LessonsPage, error) {
+	return getLessonsPage(query, s.teacherLessons(ctx, teacherID))
+}
+
+func (s *ScheduleService) GetAuditoriumLessons(ctx context.Context, auditoriumID int, query LessonsQuery) (*models.LessonsPage, error) {
+	return getLessonsPage(query, s.auditoriumLessons(ctx, auditoriumID))
+}
+
+func (s *ScheduleService) groupLessons(ctx context.Context, group string) lessonsFinder {
+	group = strings.ToUpper(strings.TrimSpace(group))
+	return func(filter models.LessonsFilter) ([]models.Lesson, error) {
+		lessons, err := s.Repo.GetGroupLessons(ctx, group, filter)
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("group %v not found", group)}
+		}
+		return lessons, err
+	}
+}
+
+func (s *ScheduleService) teacherLessons(ctx context.Context, teacherID int) lessonsFinder {
+	return func(filter models.LessonsFilter) ([]models.Lesson, error) {
+		lessons, err := s.Repo.GetTeacherLessons(ctx, teacherID, filter)
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("teacher '%v' not found", teacherID)}
+		}
+		return lessons, err
+	}
+}
+
+func (s *ScheduleService) auditoriumLessons(ctx context.Context, auditoriumID int) lessonsFinder {
+	return func(filter models.LessonsFilter) ([]models.Lesson, error) {
+		lessons, err := s.Repo.GetAuditoriumLessons(ctx, auditoriumID, filter)
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("auditorium '%v' not found", auditoriumID)}
+		}
+		return lessons, err
+	}
+}
+
+func getLessonsPage(query LessonsQuery, find lessonsFinder) (*models.LessonsPage, error) {
+	filter, err := lessonsFilter(query)
+	if err != nil {
+		return nil, err
+	}
+
+	// На одну запись больше, чтобы узнать, есть ли следующая страница.
+	limit := filter.Limit
+	filter.Limit++
+	lessons, err := find(filter)
+	if err != nil {
+		return nil, err
+	}
+	numberLessons(lessons)
+
+	page := &models.LessonsPage{
+		From:    filter.From.Format(time.DateOnly),
+		To:      filter.To.Format(time.DateOnly),
+		Lessons: lessons,
+	}
+	if len(lessons) > limit {
+		page.Lessons = lessons[:limit]
+		last := page.Lessons[limit-1]
+		page.NextCursor = encodeLessonsCursor(last.StartTime, last.Id)
+	}
+	return page, nil
+}
+
+func lessonsFilter(query LessonsQuery) (models.LessonsFilter, error) {
+	filter := models.LessonsFilter{Limit: query.Limit}
+
+	var err error
+	if query.From == "" {
+		now := utils.GetNowWithZone()
+		filter.From = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	} else if filter.From, err = time.Parse(time.DateOnly, query.From); err != nil {
+		return filter, BadRequestError{"from " + ErrInvalidDateFormat.Error()}
+	}
+	if query.To == "" {
+		filter.To = filter.From.AddDate(0, 0, defaultLessonsRangeDays)
+	} else if filter.To, err = time.Parse(time.DateOnly, query.To); err != nil {
+		return filter, BadRequestError{"to " + ErrInvalidDateFormat.Error()}
+	}
+	if filter.To.Before(filter.From) {
+		return filter, BadRequestError{"to must not be before from"}
+	}
+	if filter.To.After(filter.From.AddDate(0, 0, MaxLessonsRangeDays)) {
+		return filter, BadRequestError{fmt.Sprintf("date range must not exceed %d days", MaxLessonsRangeDays)}
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = DefaultLessonsLimit
+	case filter.Limit < 0 || filter.Limit > MaxLessonsLimit:
+		return filter, BadRequestError{fmt.Sprintf("limit must be between 1 and %d", MaxLessonsLimit)}
+	}
+
+	if query.Cursor != "" {
+		afterStart, afterID, ok := decodeLessonsCursor(query.Cursor)
+		if !ok {
+			return filter, BadRequestError{"invalid cursor"}
+		}
+		filter.AfterStart, filter.AfterID = &afterStart, afterID
+	}
+	return filter, nil
+}
+
+// The cursor is the key of the last returned lesson: start time and id.
+func encodeLessonsCursor(startTime string, id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(startTime + "|" + strconv.Itoa(id)))
+}
+
+func decodeLessonsCursor(cursor string) (time.Time, int, bool) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	startValue, idValue, found := strings.Cut(string(decoded), "|")
+	if !found {
+		return time.Time{}, 0, false
+	}
+	startTime, err := time.Parse(lessonStartTimeFormat, startValue)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	id, err := strconv.Atoi(idValue)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	return startTime, id, true
+}
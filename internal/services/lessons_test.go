@@ -0,0 +1,46 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestLessonsFilter(t *testing.T) {
+	cursor := services.EncodeLessonsCursor("2026-02-09T08:10:00", 12)
+
+	filter, err := services.LessonsFilter(services.LessonsQuery{From: "2026-02-09", To: "2026-06-30", Cursor: cursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filter.From.Equal(time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC)) || !filter.To.Equal(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected range %v - %v", filter.From, filter.To)
+	}
+	if filter.Limit != services.DefaultLessonsLimit {
+		t.Errorf("expected default limit, got %d", filter.Limit)
+	}
+	if filter.AfterStart == nil || !filter.AfterStart.Equal(time.Date(2026, 2, 9, 8, 10, 0, 0, time.UTC)) || filter.AfterID != 12 {
+		t.Errorf("cursor is not decoded: %v %d", filter.AfterStart, filter.AfterID)
+	}
+
+	filter, err = services.LessonsFilter(services.LessonsQuery{From: "2026-02-09"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filter.To.Equal(time.Date(2026, 2, 22, 0, 0, 0, 0, time.UTC)) || filter.AfterStart != nil {
+		t.Errorf("unexpected default range end %v", filter.To)
+	}
+
+	for _, query := range []services.LessonsQuery{
+		{From: "09.02.2026"},
+		{From: "2026-02-09", To: "2026-02-08"},
+		{From: "2026-02-09", To: "2026-09-01"},
+		{From: "2026-02-09", Limit: services.MaxLessonsLimit + 1},
+		{From: "2026-02-09", Cursor: "not a cursor"},
+	} {
+		if _, err = services.LessonsFilter(query); err == nil {
+			t.Errorf("expected error for %+v", query)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/utils"
+)
+
+// GetGroupNow returns the current and the next lesson of the group by the server's Moscow clock.
+func (s *ScheduleService) GetGroupNow(ctx context.Context, group string) (*models.LessonsNow, error) {
+	return s.getLessonsNow(ctx, s.groupLessons(ctx, group), utils.GetNowWithZone())
+}
+
+// GetTeacherNow returns the current and the next lesson of the teacher by the server's Moscow clock.
+func (s *ScheduleService) GetTeacherNow(ctx context.Context, teacherID int) (*models.LessonsNow, error) {
+	return s.getLessonsNow(ctx, s.teacherLessons(ctx, teacherID), utils.GetNowWithZone())
+}
+
+// GetAuditoriumNow returns the current and the next lesson in the auditorium by the server's Moscow clock.
+func (s *ScheduleService) GetAuditoriumNow(ctx context.Context, auditoriumID int) (*models.LessonsNow, error) {
+	return s.getLessonsNow(ctx, s.auditoriumLessons(ctx, auditoriumID), utils.GetNowWithZone())
+}
+
+// getLessonsNow looks for the next lesson up to two weeks ahead, so it is found after weekends and holidays.
+func (s *ScheduleService) getLessonsNow(ctx context.Context, find lessonsFinder, now time.Time) (*models.LessonsNow, error) {
+	from := dateOnly(now)
+	to := from.AddDate(0, 0, defaultLessonsRangeDays)
+	lessons, err := find(models.LessonsFilter{From: from, To: to, Limit: MaxLessonsLimit})
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.Repo.GetHolidays(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	numberLessons(lessons)
+	return LessonsNow(lessons, holidays, now), nil
+}
+
+// LessonsNow finds the lesson going on at now and the next one among lessons ordered by start time,
+// lessons on holidays do not take place and are skipped. During a lesson the break is the one in the middle
+// of its pair, between two lessons of the day it is the time until the next one.
+// Lesson times are the local times of now's location.
+func LessonsNow(lessons []models.Lesson, holidays []models.Holiday, now time.Time) *models.LessonsNow {
+	res := &models.LessonsNow{Now: now.Format(time.RFC3339)}
+	today := now.Format(time.DateOnly)
+	dates := holidayDates(holidays)
+
+	var currentStart, currentEnd, previousEnd, nextStart time.Time
+	for index := range lessons {
+		lesson := &lessons[index]
+		if _, ok := dates[lesson.Date]; ok {
+			continue
+		}
+		start, startErr := time.ParseInLocation(lessonStartTimeFormat, lesson.StartTime, now.Location())
+		end, endErr := time.ParseInLocation(lessonStartTimeFormat, lesson.EndTime, now.Location())
+		if startErr != nil || endErr != nil {
+			continue
+		}
+		if lesson.Date == today && end.Format(clockLayout) > res.DayEnd {
+			res.DayEnd = end.Format(clockLayout)
+		}
+
+		switch {
+		case !now.Before(end):
+			if lesson.Date == today && end.After(previousEnd) {
+				previousEnd = end
+			}
+		case !now.Before(start):
+			if res.Current == nil {
+				res.Current = &models.NowLesson{Lesson: *lesson, Minutes: minutesUntil(now, end)}
+				currentStart, currentEnd = start, end
+			}
+		default:
+			if res.Next == nil {
+				res.Next = &models.NowLesson{Lesson: *lesson, Minutes: minutesUntil(now, start)}
+				nextStart = start
+			}
+		}
+	}
+
+	switch {
+	case res.Current != nil:
+		if breakStart, breakEnd := pairBreak(currentStart, currentEnd); breakStart != "" {
+			clock := now.Format(clockLayout)
+			res.Break = &models.LessonBreak{Start: breakStart, End: breakEnd, Active: clock >= breakStart && clock < breakEnd}
+		}
+	case res.Next != nil && res.Next.Date == today && !previousEnd.IsZero():
+		res.Break = &models.LessonBreak{Start: previousEnd.Format(clockLayout), End: nextStart.Format(clockLayout), Active: true}
+	}
+	return res
+}
+
+func minutesUntil(now, moment time.Time) int {
+	return int(math.Ceil(moment.Sub(now).Minutes()))
+}
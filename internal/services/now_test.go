@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestLessonsNow(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	lessons := []models.Lesson{
+		{Id: 1, Date: "2026-03-02", StartTime: "2026-03-02T08:10:00", EndTime: "2026-03-02T09:45:00"},
+		{Id: 2, Date: "2026-03-02", StartTime: "2026-03-02T11:40:00", EndTime: "2026-03-02T13:15:00"},
+		{Id: 3, Date: "2026-03-04", StartTime: "2026-03-04T09:55:00", EndTime: "2026-03-04T11:30:00"},
+		{Id: 4, Date: "2026-03-09", StartTime: "2026-03-09T08:10:00", EndTime: "2026-03-09T09:45:00"},
+		{Id: 5, Date: "2026-03-10", StartTime: "2026-03-10T09:55:00", EndTime: "2026-03-10T11:30:00"},
+	}
+	holidays := []models.Holiday{{Date: "2026-03-09", Title: "Международный женский день", Kind: models.HolidayKindHoliday}}
+
+	tests := []struct {
+		now     time.Time
+		current int
+		next    int
+		minutes int
+		pause   *models.LessonBreak
+		dayEnd  string
+	}{
+		{
+			now:     time.Date(2026, 3, 2, 7, 30, 0, 0, moscow),
+			next:    1,
+			minutes: 40,
+			dayEnd:  "13:15",
+		},
+		{
+			now:     time.Date(2026, 3, 2, 8, 56, 0, 0, moscow),
+			current: 1,
+			next:    2,
+			minutes: 49,
+			pause:   &models.LessonBreak{Start: "08:55", End: "09:00", Active: true},
+			dayEnd:  "13:15",
+		},
+		{
+			now:     time.Date(2026, 3, 2, 10, 0, 30, 0, moscow),
+			next:    2,
+			minutes: 100,
+			pause:   &models.LessonBreak{Start: "09:45", End: "11:40", Active: true},
+			dayEnd:  "13:15",
+		},
+		{
+			now:     time.Date(2026, 3, 2, 14, 0, 0, 0, moscow),
+			next:    3,
+			minutes: 2*24*60 - 4*60 - 5,
+			dayEnd:  "13:15",
+		},
+		{
+			now:     time.Date(2026, 3, 4, 12, 0, 0, 0, moscow),
+			next:    5,
+			minutes: 6*24*60 - 2*60 - 5,
+			dayEnd:  "11:30",
+		},
+		{
+			now:     time.Date(2026, 3, 9, 8, 30, 0, 0, moscow),
+			next:    5,
+			minutes: 24*60 + 85,
+		},
+	}
+	for _, test := range tests {
+		res := services.LessonsNow(lessons, holidays, test.now)
+		current, next := 0, 0
+		if res.Current != nil {
+			current = res.Current.Id
+		}
+		if res.Next != nil {
+			next = res.Next.Id
+		}
+		if current != test.current || next != test.next {
+			t.Errorf("%v: got current %v and next %v, expected %v and %v", test.now, current, next, test.current, test.next)
+			continue
+		}
+
+		minutes := 0
+		switch {
+		case res.Current != nil:
+			minutes = res.Current.Minutes
+		case res.Next != nil:
+			minutes = res.Next.Minutes
+		}
+		if minutes != test.minutes {
+			t.Errorf("%v: got %v minutes, expected %v", test.now, minutes, test.minutes)
+		}
+		if (res.Break == nil) != (test.pause == nil) || res.Break != nil && *res.Break != *test.pause {
+			t.Errorf("%v: got break %+v, expected %+v", test.now, res.Break, test.pause)
+		}
+		if res.DayEnd != test.dayEnd {
+			t.Errorf("%v: got day end %v, expected %v", test.now, res.DayEnd, test.dayEnd)
+		}
+	}
+}
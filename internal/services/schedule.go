@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	_ "time/tzdata"
 
+	"github.com/schedule-rsreu/schedule-api/internal/cache"
 	"github.com/schedule-rsreu/schedule-api/internal/models"
 	"github.com/schedule-rsreu/schedule-api/internal/repo"
 	"github.com/schedule-rsreu/schedule-api/internal/utils"
@@ -16,6 +19,10 @@ import (
 
 type ScheduleService struct {
 	Repo *repo.ScheduleRepo
+	// Cache serves week schedules of groups, teachers and auditoriums, nil disables it.
+	Cache *cache.Cache
+
+	revision atomic.Pointer[models.CalendarRevision]
 }
 
 func NewScheduleService(scheduleRepo *repo.ScheduleRepo) *ScheduleService {
@@ -45,21 +52,35 @@ func (s *ScheduleService) GetScheduleByGroup(ctx context.Context, group string,
 		return nil, err
 	}
 
-	startDate, endDate := utils.GetWeekBounds(date)
+	startDate, endDate, numeratorStart, err := s.weekBounds(ctx, date)
+	if err != nil {
+		return nil, err
+	}
 
 	group = strings.ToUpper(group)
 
-	resp, err := s.Repo.GetScheduleByGroup(ctx, group, startDate, endDate)
+	resp, err := cachedSchedule(ctx, s.Cache, groupCacheEntity, group, startDate, endDate,
+		func(ctx context.Context) (*models.StudentSchedule, error) {
+			return s.Repo.GetScheduleByGroup(ctx, group, startDate, endDate, numeratorStart)
+		})
 	if err != nil {
 		if errors.Is(err, repo.ErrNoResults) {
-			return nil, NotFoundError{fmt.Sprintf("schedule for group %v not found", group)}
+			notFound := NotFoundError{fmt.Sprintf("schedule for group %v not found", group)}
+			return nil, s.withSuggestions(ctx, notFound, models.SearchHitGroup, group, 0)
 		}
 		return nil, err
 	}
 
+	holidays, err := s.holidays(ctx, dateOnly(startDate), dateOnly(endDate))
+	if err != nil {
+		return nil, err
+	}
+	MarkStudentHolidays(resp, holidays)
+
 	if addEmptyLessons {
 		s.AddEmptyLessons(&resp.Schedule, resp.LessonsTimes)
 	}
+	NumberStudentPairs(resp)
 	return resp, err
 }
 
@@ -69,14 +90,26 @@ func (s *ScheduleService) GetSchedulesByGroups(ctx context.Context, dateStr stri
 		return nil, err
 	}
 
-	startDate, endDate := utils.GetWeekBounds(date)
-	resp, err := s.Repo.GetSchedulesByGroups(ctx, startDate, endDate, groups)
+	startDate, endDate, numeratorStart, err := s.weekBounds(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.Repo.GetSchedulesByGroups(ctx, startDate, endDate, numeratorStart, groups)
 	if err != nil {
 		if errors.Is(err, repo.ErrNoResults) {
 			return nil, NotFoundError{fmt.Sprintf("schedules for groups `%v` not found", groups)}
 		}
 		return nil, err
 	}
+
+	holidays, err := s.holidays(ctx, dateOnly(startDate), dateOnly(endDate))
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range resp {
+		MarkStudentHolidays(schedule, holidays)
+		NumberStudentPairs(schedule)
+	}
 	return resp, err
 }
 
@@ -148,28 +181,31 @@ func (s *ScheduleService) GetCourseFaculties(ctx context.Context, course int, da
 }
 
 func (s *ScheduleService) GetDay(ctx context.Context) (*models.Day, error) {
-	w := utils.GetWeekType()
+	periods, err := s.Repo.GetAcademicPeriods(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	loc, err := time.LoadLocation("Europe/Moscow")
+	now := utils.GetNowWithZone()
+	w := WeekType(periods, now)
 
+	holidays, err := s.Repo.GetHolidays(ctx, dateOnly(now), dateOnly(now))
 	if err != nil {
 		return nil, err
 	}
-	now := time.Now().In(loc)
+	holiday := FindHoliday(holidays, now)
 
 	shortDayNames := []string{"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"}
 
-	WeekTypeMap := map[string]string{
-		"числитель":   "numerator",
-		"знаменатель": "denominator",
-	}
-
 	return &models.Day{
 		WeekType:    w,
-		WeekTypeEng: WeekTypeMap[w],
+		WeekTypeEng: EnglishWeekType(w),
 		Day:         now.Weekday().String(),
 		DayRu:       shortDayNames[now.Weekday()],
 		Time:        now.Format("15:04"),
+		Period:      CurrentAcademicPeriod(periods, now),
+		IsHoliday:   holiday != nil,
+		Holiday:     holiday,
 	}, nil
 }
 
@@ -178,14 +214,28 @@ func (s *ScheduleService) GetTeacherSchedule(ctx context.Context, teacherID int,
 	if err != nil {
 		return nil, err
 	}
-	startDate, endDate := utils.GetWeekBounds(date)
-	resp, err := s.Repo.GetTeacherSchedule(ctx, teacherID, startDate, endDate)
+	startDate, endDate, numeratorStart, err := s.weekBounds(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cachedSchedule(ctx, s.Cache, teacherCacheEntity, strconv.Itoa(teacherID), startDate, endDate,
+		func(ctx context.Context) (*models.TeacherSchedule, error) {
+			return s.Repo.GetTeacherSchedule(ctx, teacherID, startDate, endDate, numeratorStart)
+		})
 	if err != nil {
 		if errors.Is(err, repo.ErrNoResults) {
-			return nil, NotFoundError{fmt.Sprintf("schedule for teacher '%v' not found", teacherID)}
+			notFound := NotFoundError{fmt.Sprintf("schedule for teacher '%v' not found", teacherID)}
+			return nil, s.withSuggestions(ctx, notFound, models.SearchHitTeacher, "", teacherID)
 		}
 		return nil, err
 	}
+
+	holidays, err := s.holidays(ctx, dateOnly(startDate), dateOnly(endDate))
+	if err != nil {
+		return nil, err
+	}
+	MarkTeacherHolidays(resp, holidays)
+	NumberTeacherPairs(resp)
 	return resp, err
 }
 
@@ -252,8 +302,9 @@ func addEmptyLessons(lessons []models.StudentLesson, times []string) []models.St
 		}
 	}
 
-	sort.Slice(lessons, func(i, j int) bool {
-		return lessons[i].Time < lessons[j].Time
+	// Начало пары разбирается как время, чтобы 8.10 не оказалось после 10.00.
+	sort.SliceStable(lessons, func(i, j int) bool {
+		return lessonStart(lessons[i].Time) < lessonStart(lessons[j].Time)
 	})
 
 	for i := len(lessons) - 1; i >= 0; i-- {
@@ -308,14 +359,28 @@ func (s *ScheduleService) GetAuditoriumSchedule(ctx context.Context, auditoriumI
 		return nil, err
 	}
 
-	startDate, endDate := utils.GetWeekBounds(date)
-	resp, err := s.Repo.GetAuditoriumSchedule(ctx, startDate, endDate, auditoriumID)
+	startDate, endDate, numeratorStart, err := s.weekBounds(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cachedSchedule(ctx, s.Cache, auditoriumCacheEntity, strconv.Itoa(auditoriumID), startDate, endDate,
+		func(ctx context.Context) (*models.AuditoriumSchedule, error) {
+			return s.Repo.GetAuditoriumSchedule(ctx, startDate, endDate, numeratorStart, auditoriumID)
+		})
 	if err != nil {
 		if errors.Is(err, repo.ErrNoResults) {
-			return nil, NotFoundError{fmt.Sprintf("schedules for auditorium `%v` not found", auditoriumID)}
+			notFound := NotFoundError{fmt.Sprintf("schedules for auditorium `%v` not found", auditoriumID)}
+			return nil, s.withSuggestions(ctx, notFound, models.SearchHitAuditorium, "", auditoriumID)
 		}
 		return nil, err
 	}
+
+	holidays, err := s.holidays(ctx, dateOnly(startDate), dateOnly(endDate))
+	if err != nil {
+		return nil, err
+	}
+	MarkAuditoriumHolidays(resp, holidays)
+	NumberAuditoriumPairs(resp)
 	return resp, err
 }
 
@@ -366,6 +431,31 @@ func (s *ScheduleService) GetBuilding(ctx context.Context, buildingID int) (*mod
 	return resp, err
 }
 
+// GetRevision returns the schedule revision used to validate cached responses. With the response cache it is
+// the revision the cache serves, read by CheckRevision at most once per the cache interval.
+func (s *ScheduleService) GetRevision(ctx context.Context) (*models.CalendarRevision, error) {
+	if s.Cache == nil {
+		return s.Repo.GetCalendarRevision(ctx)
+	}
+	if _, err := s.Cache.Revision(ctx); err != nil {
+		return nil, err
+	}
+	if revision := s.revision.Load(); revision != nil {
+		return revision, nil
+	}
+	return s.Repo.GetCalendarRevision(ctx)
+}
+
+// CheckRevision is the cache.RevisionFunc of the response cache, it keeps the read revision for GetRevision.
+func (s *ScheduleService) CheckRevision(ctx context.Context) (int64, error) {
+	revision, err := s.Repo.GetCalendarRevision(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s.revision.Store(revision)
+	return revision.Revision, nil
+}
+
 func (s *ScheduleService) GetLessonTypes() []models.LessonType {
 	return []models.LessonType{
 		{Type: "lecture", Description: "лекция"},
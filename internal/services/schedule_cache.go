@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/cache"
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const (
+	groupCacheEntity      = "group"
+	teacherCacheEntity    = "teacher"
+	auditoriumCacheEntity = "auditorium"
+	periodsCacheEntity    = "academic_periods"
+	holidaysCacheEntity   = "holidays"
+)
+
+// cachedSchedule reads a week schedule through the response cache. Every caller decodes its own copy,
+// so marking holidays and adding empty lessons never changes the cached value.
+func cachedSchedule[T any](
+	ctx context.Context,
+	c *cache.Cache,
+	entity, id string,
+	startDate, endDate time.Time,
+	load func(ctx context.Context) (*T, error),
+) (*T, error) {
+	key := fmt.Sprintf("%s:%s:%s", id, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	return cachedValue(ctx, c, entity, key, load)
+}
+
+// cachedValue reads a value through the response cache, a nil cache always loads it.
+func cachedValue[T any](
+	ctx context.Context,
+	c *cache.Cache,
+	entity, key string,
+	load func(ctx context.Context) (*T, error),
+) (*T, error) {
+	if c == nil {
+		return load(ctx)
+	}
+
+	data, err := c.Get(ctx, entity, key, func(ctx context.Context) ([]byte, error) {
+		value, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(value)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("decode cached %s: %w", entity, err)
+	}
+	return &value, nil
+}
+
+// academicPeriods are the semesters and vacations cached under the revision: editing them bumps it.
+func (s *ScheduleService) academicPeriods(ctx context.Context) ([]models.AcademicPeriod, error) {
+	periods, err := cachedValue(ctx, s.Cache, periodsCacheEntity, "all",
+		func(ctx context.Context) (*[]models.AcademicPeriod, error) {
+			periods, err := s.Repo.GetAcademicPeriods(ctx)
+			return &periods, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return *periods, nil
+}
+
+// holidays are the holidays between from and to cached under the revision: editing them bumps it.
+func (s *ScheduleService) holidays(ctx context.Context, from, to time.Time) ([]models.Holiday, error) {
+	key := from.Format(time.DateOnly) + ":" + to.Format(time.DateOnly)
+	holidays, err := cachedValue(ctx, s.Cache, holidaysCacheEntity, key,
+		func(ctx context.Context) (*[]models.Holiday, error) {
+			holidays, err := s.Repo.GetHolidays(ctx, from, to)
+			return &holidays, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return *holidays, nil
+}
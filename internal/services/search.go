@@ -0,0 +1,158 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/pkg/fuzzy"
+)
+
+const (
+	searchCacheEntity = "search"
+	searchLimit       = 20
+	suggestionsLimit  = 5
+)
+
+// searchHitTypes returns the types of search hits in the order equal scores are ranked.
+func searchHitTypes() []models.SearchHitType {
+	return []models.SearchHitType{
+		models.SearchHitGroup,
+		models.SearchHitTeacher,
+		models.SearchHitAuditorium,
+		models.SearchHitBuilding,
+		models.SearchHitSubject,
+	}
+}
+
+// Search finds groups, teachers, auditoriums, buildings and subjects by the query, ranked by how well they match.
+// An empty hitType searches all of them.
+func (s *ScheduleService) Search(
+	ctx context.Context,
+	query string,
+	hitType models.SearchHitType,
+	limit int,
+) (*models.SearchResults, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, BadRequestError{"q is required"}
+	}
+	if hitType != "" && !slices.Contains(searchHitTypes(), hitType) {
+		return nil, BadRequestError{"type must be one of group, teacher, auditorium, building, subject"}
+	}
+	if limit <= 0 {
+		limit = searchLimit
+	}
+
+	entries, err := s.searchEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := SearchEntries(entries, query, hitType)
+	return &models.SearchResults{
+		Query: query,
+		Hits:  hits[:min(limit, len(hits))],
+	}, nil
+}
+
+func (s *ScheduleService) searchEntries(ctx context.Context) ([]models.SearchEntry, error) {
+	entries, err := cachedSchedule(ctx, s.Cache, searchCacheEntity, "entries", time.Time{}, time.Time{},
+		func(ctx context.Context) (*[]models.SearchEntry, error) {
+			entries, err := s.Repo.GetSearchEntries(ctx)
+			return &entries, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return *entries, nil
+}
+
+// withSuggestions adds the entities the client may have meant to a schedule lookup miss, found by the name
+// or the id. Suggestions are best effort, the plain error is returned when they cannot be loaded.
+func (s *ScheduleService) withSuggestions(
+	ctx context.Context,
+	notFound NotFoundError,
+	hitType models.SearchHitType,
+	name string,
+	id int,
+) error {
+	entries, err := s.searchEntries(ctx)
+	if err != nil {
+		return notFound
+	}
+	return SuggestionsError{NotFoundError: notFound, Suggestions: Suggestions(entries, hitType, name, id)}
+}
+
+// Suggestions returns the entity named name or with the id, which exists but has no lessons,
+// followed by the entities of the type with names similar to name.
+func Suggestions(entries []models.SearchEntry, hitType models.SearchHitType, name string, id int) []models.Suggestion {
+	suggestions := make([]models.Suggestion, 0)
+	for index := range entries {
+		entry := &entries[index]
+		if entry.Type == hitType && (name != "" && entry.Title == name || id != 0 && entry.Id == id) {
+			suggestions = append(suggestions, models.Suggestion{
+				SearchHit: models.SearchHit{Type: entry.Type, Title: entry.Title, Subtitle: entry.Subtitle, Score: 1, Id: entry.Id},
+				Reason:    models.SuggestionNoLessons,
+			})
+		}
+	}
+	if name == "" {
+		return suggestions
+	}
+
+	for _, hit := range SearchEntries(entries, name, hitType) {
+		if len(suggestions) >= suggestionsLimit {
+			break
+		}
+		if hit.Title != name {
+			suggestions = append(suggestions, models.Suggestion{SearchHit: hit, Reason: models.SuggestionSimilarName})
+		}
+	}
+	return suggestions
+}
+
+// SearchEntries scores every entry by its best matching name, the query as typed or retyped in the Russian layout.
+// Hits are ordered by score, then groups, teachers, auditoriums, buildings and subjects.
+func SearchEntries(entries []models.SearchEntry, query string, hitType models.SearchHitType) []models.SearchHit {
+	queries := fuzzy.Queries(query)
+	hits := make([]models.SearchHit, 0)
+	for index := range entries {
+		entry := &entries[index]
+		if hitType != "" && entry.Type != hitType {
+			continue
+		}
+
+		score := 0.0
+		for _, name := range entry.Names {
+			tokens := fuzzy.Tokens(name)
+			for _, queryTokens := range queries {
+				score = max(score, fuzzy.Score(queryTokens, tokens))
+			}
+		}
+		if score < fuzzy.MinScore {
+			continue
+		}
+		hits = append(hits, models.SearchHit{
+			Type:     entry.Type,
+			Title:    entry.Title,
+			Subtitle: entry.Subtitle,
+			Score:    math.Round(score*1000) / 1000,
+			Id:       entry.Id,
+		})
+	}
+
+	types := searchHitTypes()
+	slices.SortStableFunc(hits, func(a, b models.SearchHit) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(slices.Index(types, a.Type), slices.Index(types, b.Type)),
+			strings.Compare(a.Title, b.Title),
+		)
+	})
+	return hits
+}
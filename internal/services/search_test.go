@@ -0,0 +1,101 @@
+package services_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+var searchEntries = []models.SearchEntry{
+	{Type: models.SearchHitGroup, Id: 1, Title: "344М", Names: []string{"344М"}},
+	{Type: models.SearchHitGroup, Id: 2, Title: "3441", Names: []string{"3441"}},
+	{Type: models.SearchHitTeacher, Id: 1, Title: "Конюхов Алексей Николаевич", Names: []string{"Конюхов Алексей Николаевич", "Конюхов А.Н."}},
+	{Type: models.SearchHitTeacher, Id: 2, Title: "Коновалов Игорь Петрович", Names: []string{"Коновалов Игорь Петрович", "Коновалов И.П."}},
+	{Type: models.SearchHitAuditorium, Id: 1, Title: "344 С", Names: []string{"344 С"}},
+	{Type: models.SearchHitSubject, Title: "Высшая математика", Names: []string{"Высшая математика"}},
+}
+
+func TestSearchEntries(t *testing.T) {
+	tests := []struct {
+		query    string
+		hitType  models.SearchHitType
+		expected string
+	}{
+		{"Конюхов А", "", "Конюхов Алексей Николаевич"},
+		{"rjy.[jd", "", "Конюхов Алексей Николаевич"},
+		{"Канюхов", "", "Конюхов Алексей Николаевич"},
+		{"344M", "", "344М"},
+		{"344", models.SearchHitAuditorium, "344 С"},
+		{"матиматика", "", "Высшая математика"},
+	}
+	for _, test := range tests {
+		hits := services.SearchEntries(searchEntries, test.query, test.hitType)
+		if len(hits) == 0 || hits[0].Title != test.expected {
+			t.Errorf("%v: expected %v first, got %+v", test.query, test.expected, hits)
+		}
+	}
+
+	hits := services.SearchEntries(searchEntries, "344", "")
+	if len(hits) != 3 || hits[0].Type != models.SearchHitGroup || hits[1].Type != models.SearchHitAuditorium {
+		t.Errorf("expected exact matches first and groups before auditoriums, got %+v", hits)
+	}
+	if hits := services.SearchEntries(searchEntries, "Сидоров", ""); len(hits) != 0 {
+		t.Errorf("expected no hits, got %+v", hits)
+	}
+}
+
+func TestSearchValidation(t *testing.T) {
+	s := services.NewScheduleService(nil)
+	if _, err := s.Search(t.Context(), " ", "", 0); !errors.As(err, &services.BadRequestError{}) {
+		t.Errorf("expected bad request for an empty query, got %v", err)
+	}
+	if _, err := s.Search(t.Context(), "344", "room", 0); !errors.As(err, &services.BadRequestError{}) {
+		t.Errorf("expected bad request for an unknown type, got %v", err)
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	suggestions := services.Suggestions(searchEntries, models.SearchHitGroup, "34", 0)
+	if len(suggestions) != 2 || suggestions[0].Reason != models.SuggestionSimilarName {
+		t.Errorf("expected groups starting with 34, got %+v", suggestions)
+	}
+
+	suggestions = services.Suggestions(searchEntries, models.SearchHitGroup, "344М", 0)
+	if len(suggestions) == 0 || suggestions[0].Title != "344М" || suggestions[0].Reason != models.SuggestionNoLessons {
+		t.Errorf("expected the group without lessons first, got %+v", suggestions)
+	}
+
+	suggestions = services.Suggestions(searchEntries, models.SearchHitGroup, "344M", 0)
+	if len(suggestions) == 0 || suggestions[0].Title != "344М" || suggestions[0].Reason != models.SuggestionSimilarName {
+		t.Errorf("expected the group with the Cyrillic letter, got %+v", suggestions)
+	}
+
+	suggestions = services.Suggestions(searchEntries, models.SearchHitTeacher, "", 2)
+	if len(suggestions) != 1 || suggestions[0].Id != 2 || suggestions[0].Reason != models.SuggestionNoLessons {
+		t.Errorf("expected the teacher without lessons, got %+v", suggestions)
+	}
+	if suggestions := services.Suggestions(searchEntries, models.SearchHitAuditorium, "", 99); len(suggestions) != 0 {
+		t.Errorf("expected no suggestions for a missing auditorium, got %+v", suggestions)
+	}
+}
+
+func TestSuggestionsErrorBody(t *testing.T) {
+	err := error(services.SuggestionsError{
+		Suggestions: services.Suggestions(searchEntries, models.SearchHitGroup, "34", 0),
+	})
+	if !errors.As(err, &services.NotFoundError{}) {
+		t.Fatal("expected a not found error")
+	}
+
+	body, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	var response models.NotFoundResponse
+	if marshalErr := json.Unmarshal(body, &response); marshalErr != nil || len(response.Suggestions) != 2 {
+		t.Errorf("unexpected body %s", body)
+	}
+}
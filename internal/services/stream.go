@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+)
+
+const (
+	streamMaxSubscriptions = 50
+	streamSubscriberBuffer = 16
+	streamChangesPage      = 500
+	streamReconnectDelay   = 5 * time.Second
+	// streamReplayChanges bounds the replay, a client that missed more reloads the schedules instead.
+	streamReplayChanges = 20 * streamChangesPage
+)
+
+var ErrScheduleStreamClosed = errors.New("schedule stream is closed")
+
+// ScheduleStreamResyncError is returned when the client missed too many changes to replay them,
+// it should reload the schedules it follows and resume after LastID.
+type ScheduleStreamResyncError struct {
+	LastID int64
+}
+
+func (e ScheduleStreamResyncError) Error() string {
+	return fmt.Sprintf("more than %v changes missed, resync after %v", streamReplayChanges, e.LastID)
+}
+
+// GetScheduleStreamSubscription parses comma separated groups, teacher ids and auditorium ids of a stream.
+func (s *ScheduleService) GetScheduleStreamSubscription(ctx context.Context, groupsStr, teachersStr, auditoriumsStr string,
+) (*models.ScheduleStreamSubscription, error) {
+	groups := splitStreamList(groupsStr)
+	for index := range groups {
+		groups[index] = strings.ToUpper(groups[index])
+	}
+	teacherIDs, err := parseStreamIDs("teachers", teachersStr)
+	if err != nil {
+		return nil, err
+	}
+	auditoriumIDs, err := parseStreamIDs("auditoriums", auditoriumsStr)
+	if err != nil {
+		return nil, err
+	}
+	groups, teacherIDs, auditoriumIDs = uniqueSorted(groups), sortedIDs(teacherIDs), sortedIDs(auditoriumIDs)
+
+	total := len(groups) + len(teacherIDs) + len(auditoriumIDs)
+	if total == 0 {
+		return nil, BadRequestError{"at least one of groups, teachers or auditoriums is required"}
+	}
+	if total > streamMaxSubscriptions {
+		return nil, BadRequestError{fmt.Sprintf("at most %v groups, teachers and auditoriums can be followed", streamMaxSubscriptions)}
+	}
+
+	subscription, err := s.Repo.GetScheduleStreamSubscription(ctx, groups, teacherIDs, auditoriumIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if !slices.Contains(subscription.Groups, group) {
+			return nil, NotFoundError{fmt.Sprintf("group %v not found", group)}
+		}
+	}
+	for _, id := range teacherIDs {
+		if _, ok := subscription.Teachers[id]; !ok {
+			return nil, NotFoundError{fmt.Sprintf("teacher %v not found", id)}
+		}
+	}
+	for _, id := range auditoriumIDs {
+		if _, ok := subscription.Auditoriums[id]; !ok {
+			return nil, NotFoundError{fmt.Sprintf("auditorium %v not found", id)}
+		}
+	}
+	return subscription, nil
+}
+
+func splitStreamList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseStreamIDs(name, value string) ([]int, error) {
+	var ids []int
+	for _, item := range splitStreamList(value) {
+		id, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, BadRequestError{fmt.Sprintf("%v must be comma separated integer ids", name)}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func sortedIDs(ids []int) []int {
+	slices.Sort(ids)
+	return slices.Compact(ids)
+}
+
+// GetScheduleStreamReplay returns the events a client missed after the lesson change id, one per page of changes.
+// Returns ScheduleStreamResyncError when more than streamReplayChanges were missed.
+func (s *ScheduleService) GetScheduleStreamReplay(ctx context.Context, subscription *models.ScheduleStreamSubscription,
+	afterID int64,
+) ([]models.ScheduleStreamEvent, error) {
+	lastID, err := s.Repo.GetLastLessonChangeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Откаченные транзакции оставляют пропуски в id, разница может быть больше числа изменений.
+	if lastID-afterID > streamReplayChanges {
+		return nil, ScheduleStreamResyncError{LastID: lastID}
+	}
+
+	var events []models.ScheduleStreamEvent
+	for afterID < lastID {
+		batch, err := s.Repo.GetScheduleStreamChanges(ctx, afterID, streamChangesPage)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch.Changes) == 0 {
+			return events, nil
+		}
+		if event := MatchScheduleStreamEvent(subscription, batch); event != nil {
+			events = append(events, *event)
+		}
+		afterID = batch.Changes[len(batch.Changes)-1].Id
+		if len(batch.Changes) < streamChangesPage {
+			return events, nil
+		}
+	}
+	return events, nil
+}
+
+// MatchScheduleStreamEvent returns the event about the changes in the subscription, nil when there are none.
+func MatchScheduleStreamEvent(subscription *models.ScheduleStreamSubscription, batch *models.ScheduleStreamBatch,
+) *models.ScheduleStreamEvent {
+	event := &models.ScheduleStreamEvent{
+		Revision:    batch.Revision,
+		Groups:      []string{},
+		Teachers:    []int{},
+		Auditoriums: []int{},
+		Dates:       []string{},
+	}
+	for _, change := range batch.Changes {
+		matched := false
+		if slices.Contains(subscription.Groups, change.Group) {
+			event.Groups = append(event.Groups, change.Group)
+			matched = true
+		}
+		for id, name := range subscription.Teachers {
+			if slices.Contains(change.Teachers, name) {
+				event.Teachers = append(event.Teachers, id)
+				matched = true
+			}
+		}
+		for id, name := range subscription.Auditoriums {
+			if slices.Contains(change.Auditoriums, name) {
+				event.Auditoriums = append(event.Auditoriums, id)
+				matched = true
+			}
+		}
+		if matched {
+			event.Dates = append(event.Dates, change.Date)
+		}
+	}
+	if len(event.Dates) == 0 {
+		return nil
+	}
+
+	event.Groups = uniqueSorted(event.Groups)
+	event.Teachers = sortedIDs(event.Teachers)
+	event.Auditoriums = sortedIDs(event.Auditoriums)
+	event.Dates = uniqueSorted(event.Dates)
+	event.Id = batch.Changes[len(batch.Changes)-1].Id
+	return event
+}
+
+// ScheduleStreamSubscriber receives the events of its subscription until the hub drops it.
+type ScheduleStreamSubscriber struct {
+	subscription *models.ScheduleStreamSubscription
+	events       chan models.ScheduleStreamEvent
+}
+
+// Events is closed when the subscriber falls behind or the hub is closed.
+func (sub *ScheduleStreamSubscriber) Events() <-chan models.ScheduleStreamEvent {
+	return sub.events
+}
+
+// ScheduleHub fans out lesson changes from Postgres notifications to the stream subscribers.
+type ScheduleHub struct {
+	s      *ScheduleService
+	logger *zerolog.Logger
+
+	mu          sync.Mutex
+	subscribers map[*ScheduleStreamSubscriber]struct{}
+	closed      bool
+	lastID      int64
+}
+
+func NewScheduleHub(s *ScheduleService, logger *zerolog.Logger) *ScheduleHub {
+	return &ScheduleHub{
+		s:           s,
+		logger:      logger,
+		subscribers: map[*ScheduleStreamSubscriber]struct{}{},
+	}
+}
+
+func (h *ScheduleHub) Subscribe(subscription *models.ScheduleStreamSubscription) (*ScheduleStreamSubscriber, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrScheduleStreamClosed
+	}
+	subscriber := &ScheduleStreamSubscriber{
+		subscription: subscription,
+		events:       make(chan models.ScheduleStreamEvent, streamSubscriberBuffer),
+	}
+	h.subscribers[subscriber] = struct{}{}
+	return subscriber, nil
+}
+
+func (h *ScheduleHub) Unsubscribe(subscriber *ScheduleStreamSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.drop(subscriber)
+}
+
+func (h *ScheduleHub) drop(subscriber *ScheduleStreamSubscriber) {
+	if _, ok := h.subscribers[subscriber]; ok {
+		delete(h.subscribers, subscriber)
+		close(subscriber.events)
+	}
+}
+
+// Close ends all streams, so the HTTP server can shut down without waiting for them.
+func (h *ScheduleHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for subscriber := range h.subscribers {
+		h.drop(subscriber)
+	}
+}
+
+// Publish sends the batch to the matching subscribers. A subscriber that does not keep up is dropped,
+// its client reconnects and resumes with Last-Event-ID.
+func (h *ScheduleHub) Publish(batch *models.ScheduleStreamBatch) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscriber := range h.subscribers {
+		event := MatchScheduleStreamEvent(subscriber.subscription, batch)
+		if event == nil {
+			continue
+		}
+		select {
+		case subscriber.events <- *event:
+		default:
+			h.drop(subscriber)
+		}
+	}
+}
+
+// Run listens to lesson change notifications until ctx is canceled, reconnecting after errors, then closes the hub.
+func (h *ScheduleHub) Run(ctx context.Context, dsn string) {
+	defer h.Close()
+
+	for {
+		err := repo.ListenScheduleChanges(ctx, dsn, func(lastID int64) {
+			if err := h.publishChanges(ctx, lastID); err != nil && ctx.Err() == nil {
+				h.logger.Error().Err(err).Msg("schedule stream - publish")
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		h.logger.Error().Err(err).Msg("schedule stream - listen")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(streamReconnectDelay):
+		}
+	}
+}
+
+// publishChanges reads the changes after the last published one up to lastID, 0 means all of them.
+func (h *ScheduleHub) publishChanges(ctx context.Context, lastID int64) error {
+	if h.lastID == 0 {
+		// Поток начинается с текущего состояния, история доступна через Last-Event-ID.
+		id, err := h.s.Repo.GetLastLessonChangeID(ctx)
+		if err != nil {
+			return err
+		}
+		h.lastID = id
+	}
+	for lastID == 0 || h.lastID < lastID {
+		batch, err := h.s.Repo.GetScheduleStreamChanges(ctx, h.lastID, streamChangesPage)
+		if err != nil {
+			return err
+		}
+		if len(batch.Changes) == 0 {
+			return nil
+		}
+		h.Publish(batch)
+		h.lastID = batch.Changes[len(batch.Changes)-1].Id
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func streamBatch() *models.ScheduleStreamBatch {
+	return &models.ScheduleStreamBatch{
+		Revision: 43,
+		Changes: []models.ScheduleStreamChange{
+			{Id: 10, Group: "344", Date: "2026-02-21", Teachers: []string{"Конюхов Алексей Николаевич"}},
+			{Id: 11, Group: "345", Date: "2026-02-20", Auditoriums: []string{"106а C"}},
+			{Id: 12, Group: "346", Date: "2026-02-23"},
+		},
+	}
+}
+
+func TestMatchScheduleStreamEvent(t *testing.T) {
+	subscription := &models.ScheduleStreamSubscription{
+		Groups:      []string{"344"},
+		Auditoriums: map[int]string{7: "106а C"},
+	}
+
+	event := services.MatchScheduleStreamEvent(subscription, streamBatch())
+	if event == nil {
+		t.Fatal("expected an event")
+	}
+	if event.Id != 12 || event.Revision != 43 {
+		t.Errorf("unexpected id %v and revision %v", event.Id, event.Revision)
+	}
+	if !slices.Equal(event.Groups, []string{"344"}) || !slices.Equal(event.Auditoriums, []int{7}) ||
+		len(event.Teachers) != 0 || !slices.Equal(event.Dates, []string{"2026-02-20", "2026-02-21"}) {
+		t.Errorf("unexpected event %+v", event)
+	}
+
+	other := &models.ScheduleStreamSubscription{Teachers: map[int]string{1: "Другой Преподаватель"}}
+	if event := services.MatchScheduleStreamEvent(other, streamBatch()); event != nil {
+		t.Errorf("expected no event, got %+v", event)
+	}
+}
+
+func TestScheduleHub(t *testing.T) {
+	logger := zerolog.Nop()
+	hub := services.NewScheduleHub(services.NewScheduleService(nil), &logger)
+
+	following, err := hub.Subscribe(&models.ScheduleStreamSubscription{Groups: []string{"344"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unrelated, err := hub.Subscribe(&models.ScheduleStreamSubscription{Groups: []string{"111"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hub.Publish(streamBatch())
+	if event := <-following.Events(); event.Id != 12 {
+		t.Errorf("unexpected event %+v", event)
+	}
+	select {
+	case event := <-unrelated.Events():
+		t.Errorf("unrelated subscriber got %+v", event)
+	default:
+	}
+
+	// Отстающий подписчик отключается, клиент переподключится с Last-Event-ID.
+	for range 100 {
+		hub.Publish(streamBatch())
+	}
+	for range following.Events() {
+	}
+
+	hub.Close()
+	if _, ok := <-unrelated.Events(); ok {
+		t.Error("subscriber is not closed with the hub")
+	}
+	if _, err := hub.Subscribe(&models.ScheduleStreamSubscription{}); !errors.Is(err, services.ErrScheduleStreamClosed) {
+		t.Errorf("expected closed hub, got %v", err)
+	}
+}
+
+func TestGetScheduleStreamSubscriptionValidation(t *testing.T) {
+	s := services.NewScheduleService(nil)
+	for _, params := range [][3]string{
+		{"", "", ""},
+		{" , ", "", ""},
+		{"344", "abc", ""},
+		{"", "", "1,x"},
+	} {
+		_, err := s.GetScheduleStreamSubscription(context.Background(), params[0], params[1], params[2])
+		if !errors.As(err, &services.BadRequestError{}) {
+			t.Errorf("%q: expected bad request, got %v", params, err)
+		}
+	}
+}
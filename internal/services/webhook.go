@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/repo"
+)
+
+const webhookDeliveriesLimit = 50
+
+// CreateWebhook subscribes the client to the changes after the current revision.
+// The returned webhook holds the signing secret, it is not shown again.
+func (s *ScheduleService) CreateWebhook(ctx context.Context, owner string, webhook *models.Webhook) (*models.Webhook, error) {
+	if err := validateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	secret, err := newSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = secret
+	webhook.Active = true
+	if err := s.Repo.CreateWebhook(ctx, owner, webhook); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("%v %v not found", webhook.Scope, webhook.ScopeID)}
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func validateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	target, err := url.Parse(webhook.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return BadRequestError{"url must be an absolute http or https URL"}
+	}
+	if err := checkWebhookHost(ctx, target.Hostname()); err != nil {
+		return err
+	}
+
+	webhook.ScopeID = strings.TrimSpace(webhook.ScopeID)
+	switch webhook.Scope {
+	case models.WebhookScopeGroup:
+		webhook.ScopeID = strings.ToUpper(webhook.ScopeID)
+		if webhook.ScopeID == "" {
+			return BadRequestError{"scope_id must be a group number"}
+		}
+	case models.WebhookScopeTeacher, models.WebhookScopeAuditorium:
+		if _, err := strconv.Atoi(webhook.ScopeID); err != nil {
+			return BadRequestError{fmt.Sprintf("scope_id must be a %v id", webhook.Scope)}
+		}
+	default:
+		return BadRequestError{"scope must be one of group, teacher, auditorium"}
+	}
+	return nil
+}
+
+// checkWebhookHost rejects hosts resolving to internal addresses, the sender checks the address again on dial.
+func checkWebhookHost(ctx context.Context, host string) error {
+	addresses := []netip.Addr{}
+	if address, err := netip.ParseAddr(host); err == nil {
+		addresses = append(addresses, address)
+	} else {
+		addresses, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+		if err != nil || len(addresses) == 0 {
+			return BadRequestError{fmt.Sprintf("url host %v cannot be resolved", host)}
+		}
+	}
+	for _, address := range addresses {
+		if !publicAddress(address) {
+			return BadRequestError{fmt.Sprintf("url host %v must resolve to a public address", host)}
+		}
+	}
+	return nil
+}
+
+// publicAddress reports whether webhooks may be delivered to the address: loopback, private, link-local,
+// multicast and unspecified addresses are inside the cluster or not routable, so are the denied prefixes.
+func publicAddress(address netip.Addr) bool {
+	address = address.Unmap()
+	if !address.IsValid() ||
+		address.IsLoopback() ||
+		address.IsPrivate() ||
+		address.IsLinkLocalUnicast() ||
+		address.IsLinkLocalMulticast() ||
+		address.IsInterfaceLocalMulticast() ||
+		address.IsMulticast() ||
+		address.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range deniedWebhookPrefixes() {
+		if prefix.Contains(address) {
+			return false
+		}
+	}
+	return true
+}
+
+// deniedWebhookPrefixes are ranges netip does not classify: carrier-grade NAT used by cloud networks,
+// "this network", reserved IPv4 and NAT64, which translates to any IPv4 address including private ones.
+func deniedWebhookPrefixes() []netip.Prefix {
+	return []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("100.64.0.0/10"),
+		netip.MustParsePrefix("192.0.0.0/24"),
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+		netip.MustParsePrefix("64:ff9b::/96"),
+		netip.MustParsePrefix("64:ff9b:1::/48"),
+	}
+}
+
+func (s *ScheduleService) GetWebhooks(ctx context.Context, owner string) ([]models.Webhook, error) {
+	return s.Repo.GetWebhooks(ctx, owner)
+}
+
+func (s *ScheduleService) GetWebhook(ctx context.Context, owner string, id int) (*models.Webhook, error) {
+	webhook, err := s.Repo.GetWebhook(ctx, owner, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("webhook %v not found", id)}
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// SetWebhookActive pauses or resumes the webhook, a resumed webhook is not notified about the changes made while paused.
+func (s *ScheduleService) SetWebhookActive(ctx context.Context, owner string, id int, active bool) (*models.Webhook, error) {
+	if err := s.Repo.SetWebhookActive(ctx, owner, id, active); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("webhook %v not found", id)}
+		}
+		return nil, err
+	}
+	return s.GetWebhook(ctx, owner, id)
+}
+
+func (s *ScheduleService) DeleteWebhook(ctx context.Context, owner string, id int) error {
+	if err := s.Repo.DeleteWebhook(ctx, owner, id); err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return NotFoundError{fmt.Sprintf("webhook %v not found", id)}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ScheduleService) GetWebhookDeliveries(ctx context.Context, owner string, id int) ([]models.WebhookDelivery, error) {
+	deliveries, err := s.Repo.GetWebhookDeliveries(ctx, owner, id, webhookDeliveriesLimit)
+	if err != nil {
+		if errors.Is(err, repo.ErrNoResults) {
+			return nil, NotFoundError{fmt.Sprintf("webhook %v not found", id)}
+		}
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// EnqueueWebhookEvents puts a notification to the outbox for every webhook behind the current revision.
+// Webhooks without changes in their scope only move to the revision.
+func (s *ScheduleService) EnqueueWebhookEvents(ctx context.Context) (int, error) {
+	batches, err := s.Repo.GetWebhookBatches(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	enqueued := 0
+	for index := range batches {
+		batch := &batches[index]
+		payload, err := WebhookBatchPayload(batch)
+		if err != nil {
+			return enqueued, err
+		}
+		if err := s.Repo.EnqueueWebhookEvent(ctx, batch, payload); err != nil {
+			return enqueued, fmt.Errorf("enqueue webhook %v: %w", batch.Webhook.Id, err)
+		}
+		if payload != nil {
+			enqueued++
+		}
+	}
+	return enqueued, nil
+}
+
+// WebhookBatchPayload returns the delivery body of the batch, nil when there are no changes to notify about.
+func WebhookBatchPayload(batch *models.WebhookBatch) ([]byte, error) {
+	if len(batch.Changes) == 0 {
+		return nil, nil
+	}
+	for index := range batch.Changes {
+		describeLessonChange(&batch.Changes[index])
+	}
+	return json.Marshal(models.WebhookPayload{
+		Event:    models.WebhookEventScheduleChanged,
+		Revision: batch.Revision,
+		Scope:    batch.Webhook.Scope,
+		ScopeID:  batch.Webhook.ScopeID,
+		Changes:  batch.Changes,
+	})
+}
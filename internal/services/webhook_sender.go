@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const (
+	webhookSendTimeout   = 10 * time.Second
+	webhookFirstBackoff  = 30 * time.Second
+	webhookMaxBackoff    = time.Hour
+	webhookResponseLimit = 4 << 10
+
+	// WebhookMaxAttempts is the number of deliveries after which the event is marked failed.
+	WebhookMaxAttempts = 10
+
+	WebhookEventHeader     = "X-Schedule-Event"
+	WebhookDeliveryHeader  = "X-Schedule-Delivery"
+	WebhookSignatureHeader = "X-Schedule-Signature"
+)
+
+var (
+	ErrWebhookStatus  = errors.New("webhook receiver responded with non-2xx status")
+	ErrWebhookAddress = errors.New("webhook receiver address is not public")
+)
+
+// WebhookSender POSTs outbox events to the subscribers.
+type WebhookSender struct {
+	Client *http.Client
+	Now    func() time.Time
+}
+
+// NewWebhookSender returns a sender that dials only public addresses, so a receiver host
+// re-resolved to an internal address after the subscription is not reached.
+func NewWebhookSender() *WebhookSender {
+	dialer := &net.Dialer{Timeout: webhookSendTimeout, Control: dialPublicAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &WebhookSender{
+		Client: &http.Client{Timeout: webhookSendTimeout, Transport: transport},
+		Now:    time.Now,
+	}
+}
+
+func dialPublicAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil || !publicAddress(ip) {
+		return fmt.Errorf("%w: %v", ErrWebhookAddress, host)
+	}
+	return nil
+}
+
+// Send delivers the event and returns the response status, 0 when there is no response.
+// Only 2xx statuses are successful deliveries.
+func (ws *WebhookSender) Send(ctx context.Context, event *models.WebhookEvent) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, event.URL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build webhook request: %w", err)
+	}
+
+	timestamp := ws.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "schedule-api-webhooks/1.0")
+	request.Header.Set(WebhookEventHeader, models.WebhookEventScheduleChanged)
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(event.Id, 10))
+	request.Header.Set(WebhookSignatureHeader, fmt.Sprintf("t=%d,v1=%s",
+		timestamp, SignWebhookPayload(event.Secret, timestamp, event.Payload)))
+
+	response, err := ws.Client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// Дочитываем ответ, чтобы соединение вернулось в пул.
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, webhookResponseLimit))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("%w: %v", ErrWebhookStatus, response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// SignWebhookPayload returns hex HMAC-SHA256 of "timestamp.payload" with the webhook secret.
+// Receivers recompute it from the t value of the signature header and reject old timestamps.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookBackoff returns the delay before the next attempt after the failed attempt number,
+// doubling from 30 seconds up to an hour.
+func WebhookBackoff(attempt int) time.Duration {
+	backoff := webhookFirstBackoff
+	for ; attempt > 1 && backoff < webhookMaxBackoff; attempt-- {
+		backoff *= 2
+	}
+	return min(backoff, webhookMaxBackoff)
+}
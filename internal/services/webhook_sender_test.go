@@ -0,0 +1,173 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+	"github.com/schedule-rsreu/schedule-api/internal/services"
+)
+
+func TestWebhookSenderSignsDelivery(t *testing.T) {
+	const secret = "webhook-secret"
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	payload := json.RawMessage(`{"event":"schedule.changed","revision":43}`)
+
+	var received *http.Request
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	sender := services.NewWebhookSender()
+	sender.Client = receiver.Client()
+	sender.Now = func() time.Time { return now }
+	event := &models.WebhookEvent{URL: receiver.URL, Secret: secret, Payload: payload, Attempt: 1, Id: 10}
+
+	status, err := sender.Send(context.Background(), event)
+	if err != nil || status != http.StatusNoContent {
+		t.Fatalf("unexpected result %v, %v", status, err)
+	}
+	if received.Method != http.MethodPost || string(body) != string(payload) {
+		t.Errorf("unexpected request %v %s", received.Method, body)
+	}
+	if received.Header.Get("Content-Type") != "application/json" ||
+		received.Header.Get(services.WebhookEventHeader) != models.WebhookEventScheduleChanged ||
+		received.Header.Get(services.WebhookDeliveryHeader) != "10" {
+		t.Errorf("unexpected headers %v", received.Header)
+	}
+	expected := fmt.Sprintf("t=%d,v1=%s", now.Unix(), services.SignWebhookPayload(secret, now.Unix(), body))
+	if signature := received.Header.Get(services.WebhookSignatureHeader); signature != expected {
+		t.Errorf("signature %q, expected %q", signature, expected)
+	}
+	if services.SignWebhookPayload("other-secret", now.Unix(), body) == services.SignWebhookPayload(secret, now.Unix(), body) {
+		t.Error("signature does not depend on the secret")
+	}
+}
+
+func TestWebhookSenderFailedStatus(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	sender := services.NewWebhookSender()
+	sender.Client = receiver.Client()
+	event := &models.WebhookEvent{URL: receiver.URL, Secret: "secret", Payload: json.RawMessage(`{}`), Id: 1}
+	status, err := sender.Send(context.Background(), event)
+	if status != http.StatusServiceUnavailable || !errors.Is(err, services.ErrWebhookStatus) {
+		t.Errorf("unexpected result %v, %v", status, err)
+	}
+
+	receiver.Close()
+	status, err = sender.Send(context.Background(), event)
+	if status != 0 || err == nil {
+		t.Errorf("expected a connection error, got %v, %v", status, err)
+	}
+}
+
+func TestWebhookSenderRejectsInternalAddress(t *testing.T) {
+	received := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	event := &models.WebhookEvent{URL: receiver.URL, Secret: "secret", Payload: json.RawMessage(`{}`), Id: 1}
+	status, err := services.NewWebhookSender().Send(context.Background(), event)
+	if status != 0 || !errors.Is(err, services.ErrWebhookAddress) || received {
+		t.Errorf("expected the loopback receiver not to be dialed, got %v, %v", status, err)
+	}
+}
+
+func TestCreateWebhookRejectsInternalHosts(t *testing.T) {
+	service := services.NewScheduleService(nil)
+	urls := []string{
+		"http://localhost/hook",
+		"http://127.0.0.1/hook",
+		"http://127.1.2.3:8080/hook",
+		"https://10.0.0.5/hook",
+		"https://172.16.0.1/hook",
+		"https://172.31.255.255/hook",
+		"https://192.168.1.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+		"http://0.0.0.0/hook",
+		"http://0.1.2.3/hook",
+		"http://100.64.0.1/hook",
+		"http://100.127.255.254/hook",
+		"http://198.18.0.1/hook",
+		"http://255.255.255.255/hook",
+		"http://[64:ff9b::a00:5]/hook",
+		"http://224.0.0.1/hook",
+	}
+	for _, url := range urls {
+		webhook := &models.Webhook{URL: url, Scope: models.WebhookScopeGroup, ScopeID: "344"}
+		_, err := service.CreateWebhook(context.Background(), "client", webhook)
+		if !errors.As(err, &services.BadRequestError{}) {
+			t.Errorf("%v: expected a bad request, got %v", url, err)
+		}
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	if backoff := services.WebhookBackoff(1); backoff != 30*time.Second {
+		t.Errorf("first backoff is %v", backoff)
+	}
+	previous := time.Duration(0)
+	for attempt := 1; attempt <= services.WebhookMaxAttempts; attempt++ {
+		backoff := services.WebhookBackoff(attempt)
+		if backoff < previous || backoff > time.Hour {
+			t.Errorf("backoff of attempt %v is %v after %v", attempt, backoff, previous)
+		}
+		previous = backoff
+	}
+	if previous != time.Hour {
+		t.Errorf("last backoff is %v", previous)
+	}
+}
+
+func TestWebhookBatchPayload(t *testing.T) {
+	batch := &models.WebhookBatch{
+		Webhook:  models.Webhook{Scope: models.WebhookScopeGroup, ScopeID: "344"},
+		Revision: 43,
+	}
+	if payload, err := services.WebhookBatchPayload(batch); err != nil || payload != nil {
+		t.Errorf("expected no payload without changes, got %s, %v", payload, err)
+	}
+
+	batch.Changes = []models.LessonChange{{
+		Kind:   models.LessonChangeModified,
+		Before: &models.LessonVersion{StartTime: "2026-02-20T08:10:00"},
+		After:  &models.LessonVersion{StartTime: "2026-02-20T09:55:00"},
+	}}
+	payload, err := services.WebhookBatchPayload(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded models.WebhookPayload
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Event != models.WebhookEventScheduleChanged || decoded.Revision != 43 || decoded.ScopeID != "344" ||
+		len(decoded.Changes) != 1 || len(decoded.Changes[0].Fields) != 1 || decoded.Changes[0].Fields[0] != "time" {
+		t.Errorf("unexpected payload %s", payload)
+	}
+	if change := decoded.Changes[0]; change.Before.PairNumber != 1 || change.After.PairNumber != 2 {
+		t.Errorf("expected the first and second pairs, got %s", payload)
+	}
+}
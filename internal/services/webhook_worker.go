@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/schedule-rsreu/schedule-api/internal/models"
+)
+
+const (
+	webhookClaimLimit = 20
+	// webhookLease is longer than a delivery, so a claimed event is retried only when the worker died.
+	webhookLease = time.Minute
+)
+
+// WebhookWorker enqueues notifications for new schedule revisions and delivers the outbox.
+// Several replicas may run it, outbox entries are claimed with SKIP LOCKED.
+type WebhookWorker struct {
+	s        *ScheduleService
+	sender   *WebhookSender
+	interval time.Duration
+	logger   *zerolog.Logger
+}
+
+func NewWebhookWorker(s *ScheduleService, sender *WebhookSender, interval time.Duration, logger *zerolog.Logger,
+) *WebhookWorker {
+	return &WebhookWorker{s: s, sender: sender, interval: interval, logger: logger}
+}
+
+// Run polls until ctx is canceled.
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *WebhookWorker) tick(ctx context.Context) {
+	if _, err := w.s.EnqueueWebhookEvents(ctx); err != nil && ctx.Err() == nil {
+		w.logger.Error().Err(err).Msg("webhooks - enqueue")
+	}
+
+	for ctx.Err() == nil {
+		events, err := w.s.Repo.ClaimWebhookEvents(ctx, webhookClaimLimit, webhookLease)
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Error().Err(err).Msg("webhooks - claim")
+			}
+			return
+		}
+		for index := range events {
+			w.deliver(ctx, &events[index])
+		}
+		if len(events) < webhookClaimLimit {
+			return
+		}
+	}
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, event *models.WebhookEvent) {
+	started := time.Now()
+	status, err := w.sender.Send(ctx, event)
+	delivery := &models.WebhookDelivery{
+		OutboxID:   event.Id,
+		Attempt:    event.Attempt,
+		DurationMs: int(time.Since(started).Milliseconds()),
+	}
+	if status != 0 {
+		delivery.StatusCode = &status
+	}
+
+	var retryAt *time.Time
+	if err != nil {
+		delivery.Error = err.Error()
+		if event.Attempt < WebhookMaxAttempts {
+			next := time.Now().Add(WebhookBackoff(event.Attempt))
+			retryAt = &next
+		}
+	}
+
+	logger := w.logger.With().
+		Int("webhook_id", event.WebhookID).
+		Int64("outbox_id", event.Id).
+		Int("attempt", event.Attempt).
+		Int("status", status).
+		Logger()
+	switch {
+	case err == nil:
+		logger.Info().Msg("webhooks - delivered")
+	case retryAt != nil:
+		logger.Warn().Err(err).Time("retry_at", *retryAt).Msg("webhooks - delivery failed")
+	default:
+		logger.Error().Err(err).Msg("webhooks - delivery abandoned")
+	}
+
+	// Результат сохраняется и при остановке сервиса, иначе событие уйдёт повторно после аренды.
+	if err := w.s.Repo.CompleteWebhookEvent(context.WithoutCancel(ctx), event, delivery, err == nil, retryAt); err != nil {
+		logger.Error().Err(err).Msg("webhooks - complete")
+	}
+}
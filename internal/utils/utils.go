@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"math"
 	"time"
 	_ "time/tzdata"
 )
@@ -17,25 +18,19 @@ func GetNowWithZone() time.Time {
 	return now
 }
 
-func GetWeekType() string {
+// GetWeekType returns the week type of date counting from numeratorStart, the Monday of a numerator week.
+func GetWeekType(numeratorStart, date time.Time) string {
 	const dayHours = 24
 	const weekDays = 7
 
-	var loc, err = time.LoadLocation(timeZone)
+	numeratorStart = time.Date(numeratorStart.Year(), numeratorStart.Month(), numeratorStart.Day(), 0, 0, 0, 0, time.UTC)
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
 
-	if err != nil {
-		return ""
-	}
-
-	var startDate = time.Date(2026, 2, 9, 0, 0, 0, 0, loc)
-
-	now := time.Now().In(loc)
-
-	r := now.Sub(startDate).Hours() / dayHours
+	weeks := int(math.Floor(date.Sub(numeratorStart).Hours() / dayHours / weekDays))
 
 	var res string
 
-	if int(r/weekDays)%2 == 0 {
+	if weeks%2 == 0 {
 		res = "числитель"
 	} else {
 		res = "знаменатель"
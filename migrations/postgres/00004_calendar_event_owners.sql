@@ -0,0 +1,51 @@
+-- +goose Up
+ALTER TABLE public.calendar_deleted_event
+ADD COLUMN teacher_ids integer[] NOT NULL DEFAULT '{}',
+ADD COLUMN auditorium_ids integer[] NOT NULL DEFAULT '{}';
+
+-- Парсер сохраняет в отмене только имена преподавателей и аудиторий,
+-- поэтому идентификаторы восстанавливаются триггером при вставке.
+-- +goose StatementBegin
+CREATE FUNCTION public.calendar_deleted_event_owners() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    NEW.teacher_ids := ARRAY(
+        SELECT DISTINCT teacher.id
+        FROM jsonb_array_elements(NEW.teacher_auditoriums) AS item
+        JOIN public.teacher ON teacher.full_name = item->>'teacher'
+        ORDER BY teacher.id
+    );
+    NEW.auditorium_ids := ARRAY(
+        SELECT DISTINCT auditorium.id
+        FROM jsonb_array_elements(NEW.teacher_auditoriums) AS item
+        CROSS JOIN public.auditorium
+        JOIN public.building ON building.id = auditorium.building_id
+        WHERE concat_ws(' ', auditorium.number, building.letter) = item->>'auditorium'
+        ORDER BY auditorium.id
+    );
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER calendar_deleted_event_owners
+BEFORE INSERT OR UPDATE OF teacher_auditoriums ON public.calendar_deleted_event
+FOR EACH ROW EXECUTE FUNCTION public.calendar_deleted_event_owners();
+
+UPDATE public.calendar_deleted_event
+SET teacher_auditoriums = teacher_auditoriums;
+
+CREATE INDEX calendar_deleted_event_teacher_ids_idx
+    ON public.calendar_deleted_event USING gin (teacher_ids);
+
+CREATE INDEX calendar_deleted_event_auditorium_ids_idx
+    ON public.calendar_deleted_event USING gin (auditorium_ids);
+
+-- +goose Down
+DROP TRIGGER calendar_deleted_event_owners ON public.calendar_deleted_event;
+DROP FUNCTION public.calendar_deleted_event_owners();
+
+ALTER TABLE public.calendar_deleted_event
+DROP COLUMN teacher_ids,
+DROP COLUMN auditorium_ids;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE public.calendar_feed (
+    token_hash text PRIMARY KEY,
+    group_number text NOT NULL,
+    include_rules jsonb NOT NULL DEFAULT '[]'::jsonb,
+    exclude_rules jsonb NOT NULL DEFAULT '[]'::jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE public.calendar_feed;
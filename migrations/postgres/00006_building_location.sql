@@ -0,0 +1,19 @@
+-- +goose Up
+-- Координаты корпуса используются в GEO и URL событий календаря.
+-- Пока они не заполнены, календарь указывает на главный корпус.
+ALTER TABLE public.building
+ADD COLUMN address text,
+ADD COLUMN latitude double precision,
+ADD COLUMN longitude double precision,
+ADD CONSTRAINT building_coordinates_check CHECK (
+    (latitude IS NULL) = (longitude IS NULL)
+    AND latitude BETWEEN -90 AND 90
+    AND longitude BETWEEN -180 AND 180
+);
+
+-- +goose Down
+ALTER TABLE public.building
+DROP CONSTRAINT building_coordinates_check,
+DROP COLUMN address,
+DROP COLUMN latitude,
+DROP COLUMN longitude;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE public.academic_period (
+    id serial PRIMARY KEY,
+    kind text NOT NULL CHECK (kind IN ('semester', 'holidays', 'exam_session', 'practice')),
+    title text NOT NULL,
+    start_date date NOT NULL,
+    end_date date NOT NULL,
+    -- Понедельник недели-числителя, от которого считается чередование недель семестра.
+    numerator_start date,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT academic_period_dates_check CHECK (end_date >= start_date),
+    CONSTRAINT academic_period_numerator_start_check CHECK ((kind = 'semester') = (numerator_start IS NOT NULL))
+);
+
+CREATE INDEX academic_period_dates_idx ON public.academic_period (start_date, end_date);
+
+-- Раньше начало семестра было зашито в код.
+INSERT INTO public.academic_period (kind, title, start_date, end_date, numerator_start)
+VALUES ('semester', 'Весенний семестр 2025/2026', '2026-02-09', '2026-06-30', '2026-02-09');
+
+-- +goose Down
+DROP TABLE public.academic_period;
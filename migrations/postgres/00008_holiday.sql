@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE public.holiday (
+    date date PRIMARY KEY,
+    title text NOT NULL,
+    -- holiday — праздник, non_working — перенесённый выходной, closure — университет закрыт.
+    kind text NOT NULL CHECK (kind IN ('holiday', 'non_working', 'closure')),
+    -- Дни из производственного календаря перезаписываются при импорте, добавленные вручную — нет.
+    source text NOT NULL DEFAULT 'manual' CHECK (source IN ('production_calendar', 'manual')),
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE public.holiday;
@@ -0,0 +1,240 @@
+-- +goose Up
+-- Занятие группы в виде, в котором оно сравнивается с предыдущей версией.
+-- Строки с одинаковым calendar_event_uid объединяются, как и в календаре.
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_state(state_group_number text, state_date date)
+RETURNS TABLE (uid text, lesson jsonb)
+LANGUAGE sql
+STABLE
+AS $$
+    SELECT
+        lessons.uid,
+        jsonb_build_object(
+            'uid', lessons.uid,
+            'start_time', to_char(lessons.start_time, 'YYYY-MM-DD"T"HH24:MI:SS'),
+            'end_time', to_char(lessons.end_time, 'YYYY-MM-DD"T"HH24:MI:SS'),
+            'title', lessons.title,
+            'lesson_type', lessons.lesson_type,
+            'teacher_auditoriums', coalesce((
+                SELECT jsonb_agg(
+                    jsonb_build_object('teacher', pair.teacher_name, 'auditorium', pair.auditorium_name)
+                    ORDER BY pair.teacher_name, pair.auditorium_name
+                )
+                FROM (
+                    SELECT DISTINCT
+                        coalesce(teacher.full_name, '') AS teacher_name,
+                        CASE WHEN auditorium.id IS NULL THEN ''
+                            ELSE concat_ws(' ', auditorium.number, building.letter)
+                        END AS auditorium_name
+                    FROM public.lesson_auditorium_teacher link
+                    LEFT JOIN public.teacher ON teacher.id = link.teacher_id
+                    LEFT JOIN public.auditorium ON auditorium.id = link.auditorium_id
+                    LEFT JOIN public.building ON building.id = auditorium.building_id
+                    WHERE link.lesson_id = ANY(lessons.lesson_ids)
+                      AND (teacher.id IS NOT NULL OR auditorium.id IS NOT NULL)
+                ) pair
+            ), '[]'::jsonb)
+        )
+    FROM (
+        SELECT
+            public.calendar_event_uid(g.number, l.date, l.start_time, l.title, l.type) AS uid,
+            l.start_time,
+            max(l.end_time) AS end_time,
+            l.title,
+            coalesce(l.type, '') AS lesson_type,
+            array_agg(l.id) AS lesson_ids
+        FROM public."group" g
+        JOIN public.lesson l ON l.group_id = g.id
+        WHERE g.number = state_group_number
+          AND l.date = state_date
+        GROUP BY uid, l.start_time, l.title, lesson_type
+    ) lessons
+$$;
+-- +goose StatementEnd
+
+-- Последняя записанная версия занятий, с ней сравнивается новое состояние дня.
+CREATE TABLE public.lesson_snapshot (
+    uid text PRIMARY KEY,
+    group_number text NOT NULL,
+    lesson_date date NOT NULL,
+    lesson jsonb NOT NULL
+);
+
+CREATE INDEX lesson_snapshot_group_date_idx ON public.lesson_snapshot (group_number, lesson_date);
+
+CREATE TABLE public.lesson_change (
+    id bigserial PRIMARY KEY,
+    group_number text NOT NULL,
+    uid text NOT NULL,
+    kind text NOT NULL CHECK (kind IN ('added', 'removed', 'modified')),
+    before jsonb,
+    after jsonb,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX lesson_change_group_changed_at_idx ON public.lesson_change (group_number, changed_at, id);
+
+-- Дни, изменённые в текущей транзакции. Парсер может удалить и заново вставить занятия,
+-- поэтому сравнение откладывается до коммита.
+CREATE TABLE public.lesson_change_pending (
+    group_number text NOT NULL,
+    lesson_date date NOT NULL,
+    PRIMARY KEY (group_number, lesson_date)
+);
+
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_change_mark() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO public.lesson_change_pending (group_number, lesson_date)
+    SELECT g.number, changed.lesson_date
+    FROM (VALUES (OLD.group_id, OLD.date), (NEW.group_id, NEW.date)) AS changed(group_id, lesson_date)
+    JOIN public."group" g ON g.id = changed.group_id
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_auditorium_teacher_change_mark() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO public.lesson_change_pending (group_number, lesson_date)
+    SELECT g.number, l.date
+    FROM public.lesson l
+    JOIN public."group" g ON g.id = l.group_id
+    WHERE l.id IN (OLD.lesson_id, NEW.lesson_id)
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+-- Сравнивает день группы с сохранённой версией. Перенос занятия меняет calendar_event_uid,
+-- поэтому удалённое и добавленное занятие с тем же названием и типом считаются изменённым.
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_change_log() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    DELETE FROM public.lesson_change_pending
+    WHERE group_number = NEW.group_number
+      AND lesson_date = NEW.lesson_date;
+
+    INSERT INTO public.lesson_change (group_number, uid, kind, before, after)
+    WITH current_state AS (
+        SELECT state.uid, state.lesson
+        FROM public.lesson_state(NEW.group_number, NEW.lesson_date) state
+    ),
+    previous_state AS (
+        SELECT snapshot.uid, snapshot.lesson
+        FROM public.lesson_snapshot snapshot
+        WHERE snapshot.group_number = NEW.group_number
+          AND snapshot.lesson_date = NEW.lesson_date
+    ),
+    removed AS (
+        SELECT
+            previous.uid,
+            previous.lesson,
+            row_number() OVER (
+                PARTITION BY previous.lesson->>'title', previous.lesson->>'lesson_type'
+                ORDER BY previous.lesson->>'start_time', previous.uid
+            ) AS position
+        FROM previous_state previous
+        WHERE NOT EXISTS (SELECT 1 FROM current_state WHERE current_state.uid = previous.uid)
+    ),
+    added AS (
+        SELECT
+            current_state.uid,
+            current_state.lesson,
+            row_number() OVER (
+                PARTITION BY current_state.lesson->>'title', current_state.lesson->>'lesson_type'
+                ORDER BY current_state.lesson->>'start_time', current_state.uid
+            ) AS position
+        FROM current_state
+        WHERE NOT EXISTS (SELECT 1 FROM previous_state WHERE previous_state.uid = current_state.uid)
+    ),
+    moved AS (
+        SELECT removed.uid AS before_uid, added.uid AS after_uid, removed.lesson AS before, added.lesson AS after
+        FROM removed
+        JOIN added ON added.lesson->>'title' = removed.lesson->>'title'
+          AND added.lesson->>'lesson_type' = removed.lesson->>'lesson_type'
+          AND added.position = removed.position
+    ),
+    changes AS (
+        SELECT current_state.uid, 'modified' AS kind, previous_state.lesson AS before, current_state.lesson AS after
+        FROM current_state
+        JOIN previous_state ON previous_state.uid = current_state.uid
+        WHERE previous_state.lesson <> current_state.lesson
+
+        UNION ALL
+
+        SELECT moved.after_uid, 'modified', moved.before, moved.after
+        FROM moved
+
+        UNION ALL
+
+        SELECT removed.uid, 'removed', removed.lesson, NULL
+        FROM removed
+        WHERE NOT EXISTS (SELECT 1 FROM moved WHERE moved.before_uid = removed.uid)
+
+        UNION ALL
+
+        SELECT added.uid, 'added', NULL, added.lesson
+        FROM added
+        WHERE NOT EXISTS (SELECT 1 FROM moved WHERE moved.after_uid = added.uid)
+    )
+    SELECT NEW.group_number, changes.uid, changes.kind, changes.before, changes.after
+    FROM changes
+    ORDER BY coalesce(changes.after->>'start_time', changes.before->>'start_time'), changes.uid;
+
+    DELETE FROM public.lesson_snapshot
+    WHERE group_number = NEW.group_number
+      AND lesson_date = NEW.lesson_date;
+
+    INSERT INTO public.lesson_snapshot (uid, group_number, lesson_date, lesson)
+    SELECT state.uid, NEW.group_number, NEW.lesson_date, state.lesson
+    FROM public.lesson_state(NEW.group_number, NEW.lesson_date) state;
+
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER lesson_change_mark
+AFTER INSERT OR UPDATE OR DELETE ON public.lesson
+FOR EACH ROW EXECUTE FUNCTION public.lesson_change_mark();
+
+CREATE TRIGGER lesson_auditorium_teacher_change_mark
+AFTER INSERT OR UPDATE OR DELETE ON public.lesson_auditorium_teacher
+FOR EACH ROW EXECUTE FUNCTION public.lesson_auditorium_teacher_change_mark();
+
+CREATE CONSTRAINT TRIGGER lesson_change_log
+AFTER INSERT ON public.lesson_change_pending
+DEFERRABLE INITIALLY DEFERRED
+FOR EACH ROW EXECUTE FUNCTION public.lesson_change_log();
+
+-- Текущее расписание становится исходной версией, история начинается с этой миграции.
+INSERT INTO public.lesson_snapshot (uid, group_number, lesson_date, lesson)
+SELECT state.uid, days.number, days.date, state.lesson
+FROM (
+    SELECT DISTINCT g.number, l.date
+    FROM public.lesson l
+    JOIN public."group" g ON g.id = l.group_id
+) days
+CROSS JOIN LATERAL public.lesson_state(days.number, days.date) state;
+
+-- +goose Down
+DROP TRIGGER lesson_change_log ON public.lesson_change_pending;
+DROP TRIGGER lesson_auditorium_teacher_change_mark ON public.lesson_auditorium_teacher;
+DROP TRIGGER lesson_change_mark ON public.lesson;
+DROP FUNCTION public.lesson_change_log();
+DROP FUNCTION public.lesson_auditorium_teacher_change_mark();
+DROP FUNCTION public.lesson_change_mark();
+DROP TABLE public.lesson_change_pending;
+DROP TABLE public.lesson_change;
+DROP TABLE public.lesson_snapshot;
+DROP FUNCTION public.lesson_state(text, date);
@@ -0,0 +1,61 @@
+-- +goose Up
+CREATE TABLE public.webhook (
+    id serial PRIMARY KEY,
+    -- Имя приложения из токена, подписки видны только ему.
+    owner text NOT NULL,
+    url text NOT NULL,
+    -- Ключ подписи HMAC-SHA256, нужен в открытом виде для подписи доставок.
+    secret text NOT NULL,
+    scope text NOT NULL CHECK (scope IN ('group', 'teacher', 'auditorium')),
+    group_number text,
+    teacher_id integer REFERENCES public.teacher(id) ON DELETE CASCADE,
+    auditorium_id integer REFERENCES public.auditorium(id) ON DELETE CASCADE,
+    active boolean NOT NULL DEFAULT true,
+    -- Ревизия расписания и последнее изменение из lesson_change, о которых подписчик уже уведомлён.
+    last_revision bigint NOT NULL,
+    last_change_id bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT webhook_scope_check CHECK (
+        (scope = 'group') = (group_number IS NOT NULL)
+        AND (scope = 'teacher') = (teacher_id IS NOT NULL)
+        AND (scope = 'auditorium') = (auditorium_id IS NOT NULL)
+    )
+);
+
+CREATE INDEX webhook_owner_idx ON public.webhook (owner);
+
+CREATE TABLE public.webhook_outbox (
+    id bigserial PRIMARY KEY,
+    webhook_id integer NOT NULL REFERENCES public.webhook(id) ON DELETE CASCADE,
+    revision bigint NOT NULL,
+    payload jsonb NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone NOT NULL DEFAULT now(),
+    delivered_at timestamp with time zone,
+    -- Доставка прекращена после последней попытки.
+    failed_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT webhook_outbox_revision_key UNIQUE (webhook_id, revision)
+);
+
+CREATE INDEX webhook_outbox_due_idx ON public.webhook_outbox (next_attempt_at)
+    WHERE delivered_at IS NULL AND failed_at IS NULL;
+
+CREATE TABLE public.webhook_delivery (
+    id bigserial PRIMARY KEY,
+    outbox_id bigint NOT NULL REFERENCES public.webhook_outbox(id) ON DELETE CASCADE,
+    webhook_id integer NOT NULL REFERENCES public.webhook(id) ON DELETE CASCADE,
+    attempt integer NOT NULL,
+    status_code integer,
+    error text NOT NULL DEFAULT '',
+    duration_ms integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_delivery_webhook_created_at_idx ON public.webhook_delivery (webhook_id, created_at DESC);
+
+-- +goose Down
+DROP TABLE public.webhook_delivery;
+DROP TABLE public.webhook_outbox;
+DROP TABLE public.webhook;
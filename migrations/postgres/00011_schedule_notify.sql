@@ -0,0 +1,24 @@
+-- +goose Up
+-- Уведомляет поток обновлений расписания о новых записях lesson_change. В сообщении только
+-- последний id: слушатель сам читает изменения после него, поэтому пропущенные уведомления не теряются.
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_change_notify() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    PERFORM pg_notify('schedule_changes', max(id)::text)
+    FROM new_changes
+    HAVING count(*) > 0;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER lesson_change_notify
+AFTER INSERT ON public.lesson_change
+REFERENCING NEW TABLE AS new_changes
+FOR EACH STATEMENT EXECUTE FUNCTION public.lesson_change_notify();
+
+-- +goose Down
+DROP TRIGGER lesson_change_notify ON public.lesson_change;
+DROP FUNCTION public.lesson_change_notify();
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE public.api_client (
+    id serial PRIMARY KEY,
+    -- Совпадает с app_name в JWT клиента и владельцем его вебхуков.
+    name text NOT NULL UNIQUE,
+    -- SHA-256 ключа API, сам ключ показывается только при выпуске.
+    key_hash text NOT NULL UNIQUE,
+    scopes text[] NOT NULL DEFAULT '{read}' CHECK (scopes <@ ARRAY['read', 'admin', 'webhooks']),
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- +goose Down
+DROP TABLE public.api_client;
@@ -0,0 +1,59 @@
+-- +goose Up
+-- Ревизия расписания, в которой записано изменение. Sync-token CalDAV — это ревизия, поэтому
+-- sync-collection находит изменённые после токена события по lesson_change и calendar_deleted_event.
+-- Записи до этой миграции получают 0: токены со старыми записями уже не выдаются.
+ALTER TABLE public.lesson_change
+ADD COLUMN revision bigint NOT NULL DEFAULT 0;
+
+CREATE INDEX lesson_change_revision_idx ON public.lesson_change (revision);
+CREATE INDEX calendar_deleted_event_sequence_idx ON public.calendar_deleted_event (sequence);
+
+-- lesson_change_log отложен до коммита, парсер к этому моменту уже увеличил ревизию.
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_change_revision() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    NEW.revision := (SELECT revision FROM public.calendar_revision WHERE id = 1);
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER lesson_change_revision
+BEFORE INSERT ON public.lesson_change
+FOR EACH ROW EXECUTE FUNCTION public.lesson_change_revision();
+
+-- Ревизии, изменения которых не сводятся к отдельным событиям: праздники меняют все календари сразу.
+-- Клиент с токеном старше такой ревизии получает valid-sync-token и синхронизируется заново.
+CREATE TABLE public.calendar_resync (
+    revision bigint PRIMARY KEY
+);
+
+-- Срабатывает после команды, поэтому видит ревизию, увеличенную в той же команде.
+-- +goose StatementBegin
+CREATE FUNCTION public.calendar_resync_mark() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    INSERT INTO public.calendar_resync (revision)
+    SELECT revision FROM public.calendar_revision WHERE id = 1
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER holiday_resync_mark
+AFTER INSERT OR UPDATE OR DELETE ON public.holiday
+FOR EACH STATEMENT EXECUTE FUNCTION public.calendar_resync_mark();
+
+-- +goose Down
+DROP TRIGGER holiday_resync_mark ON public.holiday;
+DROP FUNCTION public.calendar_resync_mark();
+DROP TABLE public.calendar_resync;
+DROP TRIGGER lesson_change_revision ON public.lesson_change;
+DROP FUNCTION public.lesson_change_revision();
+DROP INDEX public.calendar_deleted_event_sequence_idx;
+DROP INDEX public.lesson_change_revision_idx;
+ALTER TABLE public.lesson_change DROP COLUMN revision;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- id изменений выдаются при коммите импорта. Без блокировки импорт, начавший коммит раньше, может
+-- закоммититься позже: вебхук или клиент, уже прочитавший max(id), пропустил бы его изменения с меньшими id.
+-- Блокировка до конца транзакции выстраивает записи по порядку: видимый max(id) — граница закоммиченных.
+-- Чтение lesson_change она не блокирует.
+-- +goose StatementBegin
+CREATE FUNCTION public.lesson_change_order() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    LOCK TABLE public.lesson_change IN SHARE ROW EXCLUSIVE MODE;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER lesson_change_order
+BEFORE INSERT ON public.lesson_change
+FOR EACH STATEMENT EXECUTE FUNCTION public.lesson_change_order();
+
+-- +goose Down
+DROP TRIGGER lesson_change_order ON public.lesson_change;
+DROP FUNCTION public.lesson_change_order();
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Календари преподавателей и аудиторий отменяют занятия, у которых сменился преподаватель или аудитория,
+-- по прежней версии занятия в lesson_change.
+CREATE INDEX lesson_change_before_teacher_auditoriums_idx
+    ON public.lesson_change USING gin ((before->'teacher_auditoriums') jsonb_path_ops);
+
+-- +goose Down
+DROP INDEX public.lesson_change_before_teacher_auditoriums_idx;
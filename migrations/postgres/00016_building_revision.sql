@@ -0,0 +1,36 @@
+-- +goose Up
+-- Корпуса входят в ответы расписания и LOCATION событий календаря, но импорт их не меняет: правка корпуса
+-- увеличивает ревизию, чтобы кэш и валидаторы ответов сбросились, и отмечает её для полной синхронизации CalDAV.
+-- Триггер строчный, чтобы повторная запись тех же значений ревизию не меняла.
+-- +goose StatementBegin
+CREATE FUNCTION public.building_revision_bump() RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE public.calendar_revision
+    SET revision = revision + 1,
+        updated_at = now()
+    WHERE id = 1;
+
+    INSERT INTO public.calendar_resync (revision)
+    SELECT revision FROM public.calendar_revision WHERE id = 1
+    ON CONFLICT DO NOTHING;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER building_revision_bump_update
+AFTER UPDATE ON public.building
+FOR EACH ROW
+WHEN (OLD.* IS DISTINCT FROM NEW.*)
+EXECUTE FUNCTION public.building_revision_bump();
+
+CREATE TRIGGER building_revision_bump_insert_delete
+AFTER INSERT OR DELETE ON public.building
+FOR EACH ROW EXECUTE FUNCTION public.building_revision_bump();
+
+-- +goose Down
+DROP TRIGGER building_revision_bump_insert_delete ON public.building;
+DROP TRIGGER building_revision_bump_update ON public.building;
+DROP FUNCTION public.building_revision_bump();
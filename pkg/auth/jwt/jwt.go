@@ -15,6 +15,8 @@ type Claims struct {
 var ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
 var ErrInvalidToken = errors.New("invalid token")
 
+// ParseJWT validates the signature and the expiration, a token without exp is rejected:
+// it could only be revoked by rotating the secret of every client.
 func ParseJWT(tokenString string, jwtKey []byte) (*Claims, error) {
 	// Parse the token
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
@@ -23,7 +25,7 @@ func ParseJWT(tokenString string, jwtKey []byte) (*Claims, error) {
 			return nil, fmt.Errorf("%w: %v", ErrUnexpectedSigningMethod, token.Header["alg"])
 		}
 		return jwtKey, nil
-	})
+	}, jwt.WithExpirationRequired())
 	if err != nil {
 		return nil, err
 	}
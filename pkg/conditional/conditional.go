@@ -0,0 +1,88 @@
+// Package conditional implements HTTP conditional request validation (RFC 9110, section 13).
+package conditional
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WeakETag formats value as a weak entity tag.
+func WeakETag(value string) string {
+	return `W/"` + value + `"`
+}
+
+// StrongETag formats value as a strong entity tag.
+func StrongETag(value string) string {
+	return `"` + value + `"`
+}
+
+// NotModified reports whether the client already has the representation identified by etag and lastModified.
+// If-None-Match takes precedence over If-Modified-Since, as the RFC requires.
+func NotModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// MatchesAny reports whether a GET or HEAD request has If-None-Match: *. It matches any current representation,
+// so a handler validating before it loads the resource answers 304 only after the resource is found.
+func MatchesAny(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	for _, candidate := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		if strings.TrimSpace(candidate) == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// SetValidators writes ETag and Last-Modified response headers.
+func SetValidators(header http.Header, etag string, lastModified time.Time) {
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// ClearValidators removes ETag and Last-Modified, an error response must not be cached as the representation.
+func ClearValidators(header http.Header) {
+	header.Del("ETag")
+	header.Del("Last-Modified")
+}
+
+func etagMatches(ifNoneMatch, etag string) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || opaqueTag(candidate) == opaqueTag(etag) {
+			return true
+		}
+	}
+	return false
+}
+
+// opaqueTag strips the weakness indicator: If-None-Match uses the weak comparison.
+func opaqueTag(etag string) string {
+	return strings.TrimPrefix(etag, "W/")
+}
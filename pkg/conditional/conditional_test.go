@@ -0,0 +1,90 @@
+package conditional_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/schedule-rsreu/schedule-api/pkg/conditional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	lastModified := time.Date(2026, 8, 19, 12, 0, 0, 500, time.UTC)
+	etag := conditional.WeakETag("42")
+
+	tests := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		expected bool
+	}{
+		{"no validators", http.MethodGet, nil, false},
+		{"matching etag", http.MethodGet, map[string]string{"If-None-Match": `W/"42"`}, true},
+		{"strong form of weak etag", http.MethodGet, map[string]string{"If-None-Match": `"42"`}, true},
+		{"etag list", http.MethodGet, map[string]string{"If-None-Match": `"1", W/"42"`}, true},
+		{"wildcard", http.MethodHead, map[string]string{"If-None-Match": "*"}, true},
+		{"other etag", http.MethodGet, map[string]string{"If-None-Match": `W/"41"`}, false},
+		{
+			"etag wins over date", http.MethodGet,
+			map[string]string{"If-None-Match": `W/"41"`, "If-Modified-Since": "Wed, 19 Aug 2026 12:00:00 GMT"},
+			false,
+		},
+		{"same date", http.MethodGet, map[string]string{"If-Modified-Since": "Wed, 19 Aug 2026 12:00:00 GMT"}, true},
+		{"older date", http.MethodGet, map[string]string{"If-Modified-Since": "Wed, 19 Aug 2026 11:59:59 GMT"}, false},
+		{"invalid date", http.MethodGet, map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"unsafe method", http.MethodPost, map[string]string{"If-None-Match": `W/"42"`}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, "/", http.NoBody)
+			for key, value := range test.headers {
+				request.Header.Set(key, value)
+			}
+			assert.Equal(t, test.expected, conditional.NotModified(request, etag, lastModified))
+		})
+	}
+}
+
+func TestMatchesAny(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		header   string
+		expected bool
+	}{
+		{"no header", http.MethodGet, "", false},
+		{"wildcard", http.MethodGet, "*", true},
+		{"head", http.MethodHead, " * ", true},
+		{"etag", http.MethodGet, `W/"42"`, false},
+		{"unsafe method", http.MethodPut, "*", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, "/", http.NoBody)
+			request.Header.Set("If-None-Match", test.header)
+			assert.Equal(t, test.expected, conditional.MatchesAny(request))
+		})
+	}
+}
+
+func TestSetValidators(t *testing.T) {
+	header := http.Header{}
+	conditional.SetValidators(header, conditional.WeakETag("42"), time.Date(2026, 8, 19, 15, 0, 0, 0, time.FixedZone("MSK", 3*60*60)))
+
+	assert.Equal(t, `W/"42"`, header.Get("ETag"))
+	assert.Equal(t, "Wed, 19 Aug 2026 12:00:00 GMT", header.Get("Last-Modified"))
+}
+
+func TestClearValidators(t *testing.T) {
+	header := http.Header{}
+	conditional.SetValidators(header, conditional.WeakETag("42"), time.Date(2026, 8, 19, 12, 0, 0, 0, time.UTC))
+	conditional.ClearValidators(header)
+
+	assert.Empty(t, header.Get("ETag"))
+	assert.Empty(t, header.Get("Last-Modified"))
+}
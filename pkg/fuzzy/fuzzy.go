@@ -0,0 +1,162 @@
+// Package fuzzy matches search queries against names despite typos, partial words,
+// a wrong keyboard layout and Latin letters that look like Cyrillic ones.
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MinScore is the lowest score worth showing as a match.
+	MinScore = 0.5
+
+	minFuzzyLength = 4
+	longWordLength = 7
+)
+
+// layout maps QWERTY keys to the Russian letters on the same keys of the ЙЦУКЕН layout.
+var layout = func() map[rune]rune { //nolint:gochecknoglobals // Read-only table, built once for every query.
+	qwerty := []rune("qwertyuiop[]asdfghjkl;'zxcvbnm,.`")
+	jcuken := []rune("йцукенгшщзхъфывапролджэячсмитьбюё")
+	res := make(map[rune]rune, len(qwerty))
+	for index := range qwerty {
+		res[qwerty[index]] = jcuken[index]
+	}
+	return res
+}()
+
+// lookalikes maps Latin letters to the Cyrillic letters written the same way, as in "344M" and "344М".
+var lookalikes = map[rune]rune{ //nolint:gochecknoglobals // Read-only table looked up for every rune of a name.
+	'A': 'а', 'a': 'а', 'B': 'в', 'C': 'с', 'c': 'с', 'E': 'е', 'e': 'е', 'H': 'н', 'K': 'к', 'k': 'к',
+	'M': 'м', 'm': 'м', 'O': 'о', 'o': 'о', 'P': 'р', 'p': 'р', 'T': 'т', 'X': 'х', 'x': 'х', 'Y': 'у', 'y': 'у',
+}
+
+// SwapLayout retypes text typed in the English layout instead of the Russian one, "rjy.[jd" becomes "конюхов".
+func SwapLayout(text string) string {
+	return strings.Map(func(r rune) rune {
+		if swapped, ok := layout[unicode.ToLower(r)]; ok {
+			return swapped
+		}
+		return r
+	}, text)
+}
+
+// Tokens splits text into lower case words. Lookalike Latin letters become Cyrillic, ё becomes е,
+// and letters and digits written together are split, so "344М" and "344 м" give the same tokens.
+func Tokens(text string) []string {
+	tokens := make([]string, 0)
+	var token []rune
+	flush := func() {
+		if len(token) > 0 {
+			tokens = append(tokens, string(token))
+			token = token[:0]
+		}
+	}
+
+	for _, r := range text {
+		if folded, ok := lookalikes[r]; ok {
+			r = folded
+		}
+		r = unicode.ToLower(r)
+		if r == 'ё' {
+			r = 'е'
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if len(token) > 0 && unicode.IsDigit(r) != unicode.IsDigit(token[len(token)-1]) {
+			flush()
+		}
+		token = append(token, r)
+	}
+	flush()
+	return tokens
+}
+
+// Queries returns the tokens of the query as typed and retyped in the Russian layout, when that differs.
+func Queries(query string) [][]string {
+	queries := [][]string{Tokens(query)}
+	if swapped := SwapLayout(query); swapped != query {
+		queries = append(queries, Tokens(swapped))
+	}
+	return queries
+}
+
+// Score rates how well the query tokens match the name tokens from 0 to 1. Every query token has
+// to match a name token exactly, as its beginning or with a typo, so "Конюхов А" matches
+// "Конюхов Алексей Николаевич". Names with fewer extra words score a little higher.
+func Score(query, name []string) float64 {
+	if len(query) == 0 || len(name) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, queryToken := range query {
+		best := 0.0
+		for _, nameToken := range name {
+			best = max(best, tokenScore(queryToken, nameToken))
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	coverage := float64(len(query)) / float64(max(len(query), len(name)))
+	return total / float64(len(query)) * (0.9 + 0.1*coverage)
+}
+
+func tokenScore(query, name string) float64 {
+	switch {
+	case query == name:
+		return 1
+	case strings.HasPrefix(name, query):
+		return 0.9
+	}
+
+	queryRunes, nameRunes := []rune(query), []rune(name)
+	if len(queryRunes) < minFuzzyLength || unicode.IsDigit(queryRunes[0]) {
+		return 0
+	}
+	allowed := 1
+	if len(queryRunes) >= longWordLength {
+		allowed = 2
+	}
+	// A partial word with a typo is compared with the beginning of the name of the same length.
+	distance := Distance(queryRunes, nameRunes)
+	if len(nameRunes) > len(queryRunes) {
+		distance = min(distance, Distance(queryRunes, nameRunes[:len(queryRunes)]))
+	}
+	if distance > allowed {
+		return 0
+	}
+	return 0.9 - 0.15*float64(distance)
+}
+
+// Distance is the number of inserted, deleted, replaced and swapped neighbouring letters
+// turning a into b (the optimal string alignment distance).
+func Distance(a, b []rune) int {
+	rows := make([][]int, len(a)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(b)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(a)][len(b)]
+}
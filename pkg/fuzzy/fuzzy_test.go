@@ -0,0 +1,59 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/schedule-rsreu/schedule-api/pkg/fuzzy"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func best(query, name string) float64 {
+	score := 0.0
+	for _, tokens := range fuzzy.Queries(query) {
+		score = max(score, fuzzy.Score(tokens, fuzzy.Tokens(name)))
+	}
+	return score
+}
+
+func TestTokens(t *testing.T) {
+	assert.Equal(t, []string{"344", "м"}, fuzzy.Tokens("344M"))
+	assert.Equal(t, []string{"344", "м"}, fuzzy.Tokens("344 М"))
+	assert.Equal(t, []string{"конюхов", "а", "н"}, fuzzy.Tokens("Конюхов А.Н."))
+	assert.Equal(t, []string{"семенов"}, fuzzy.Tokens("Семёнов"))
+}
+
+func TestSwapLayout(t *testing.T) {
+	assert.Equal(t, "конюхов", fuzzy.SwapLayout("rjy.[jd"))
+	assert.Equal(t, "Конюхов", fuzzy.SwapLayout("Конюхов"))
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		query string
+		name  string
+	}{
+		{"344M", "344М"},
+		{"Конюхов А", "Конюхов Алексей Николаевич"},
+		{"конюх", "Конюхов Алексей Николаевич"},
+		{"rjy.[jd", "Конюхов Алексей Николаевич"},
+		{"Канюхов", "Конюхов Алексей Николаевич"},
+		{"Конхюов", "Конюхов Алексей Николаевич"},
+		{"матиматика", "Высшая математика"},
+		{"445С", "445 С"},
+	}
+	for _, test := range tests {
+		assert.GreaterOrEqual(t, best(test.query, test.name), fuzzy.MinScore, "%v should match %v", test.query, test.name)
+	}
+
+	assert.Zero(t, best("345", "344М"))
+	assert.Zero(t, best("Петров", "Конюхов Алексей Николаевич"))
+	assert.Greater(t, best("344м", "344М"), best("344", "3441"))
+	assert.Greater(t, best("Конюхов", "Конюхов Алексей Николаевич"), best("Канюхов", "Конюхов Алексей Николаевич"))
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, fuzzy.Distance([]rune("физика"), []rune("физика")))
+	assert.Equal(t, 1, fuzzy.Distance([]rune("фзиика"), []rune("физика")))
+	assert.Equal(t, 2, fuzzy.Distance([]rune("фика"), []rune("физика")))
+}